@@ -12,6 +12,7 @@ package v0
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	structpb "google.golang.org/protobuf/types/known/structpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
@@ -25,6 +26,99 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type WorkspaceCall_Root int32
+
+const (
+	WorkspaceCall_ROOT_UNSPECIFIED WorkspaceCall_Root = 0
+	// The session's source files, which are read-only.
+	WorkspaceCall_ROOT_SOURCE WorkspaceCall_Root = 1
+	// The session's output files.
+	WorkspaceCall_ROOT_OUTPUT WorkspaceCall_Root = 2
+)
+
+// Enum value maps for WorkspaceCall_Root.
+var (
+	WorkspaceCall_Root_name = map[int32]string{
+		0: "ROOT_UNSPECIFIED",
+		1: "ROOT_SOURCE",
+		2: "ROOT_OUTPUT",
+	}
+	WorkspaceCall_Root_value = map[string]int32{
+		"ROOT_UNSPECIFIED": 0,
+		"ROOT_SOURCE":      1,
+		"ROOT_OUTPUT":      2,
+	}
+)
+
+func (x WorkspaceCall_Root) Enum() *WorkspaceCall_Root {
+	p := new(WorkspaceCall_Root)
+	*p = x
+	return p
+}
+
+func (x WorkspaceCall_Root) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WorkspaceCall_Root) Descriptor() protoreflect.EnumDescriptor {
+	return file_bonk_v0_bonk_proto_enumTypes[0].Descriptor()
+}
+
+func (WorkspaceCall_Root) Type() protoreflect.EnumType {
+	return &file_bonk_v0_bonk_proto_enumTypes[0]
+}
+
+func (x WorkspaceCall_Root) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+type WorkspaceReply_Error_Kind int32
+
+const (
+	WorkspaceReply_Error_KIND_UNSPECIFIED WorkspaceReply_Error_Kind = 0
+	WorkspaceReply_Error_KIND_NOT_EXIST   WorkspaceReply_Error_Kind = 1
+	WorkspaceReply_Error_KIND_EXIST       WorkspaceReply_Error_Kind = 2
+	WorkspaceReply_Error_KIND_PERMISSION  WorkspaceReply_Error_Kind = 3
+)
+
+// Enum value maps for WorkspaceReply_Error_Kind.
+var (
+	WorkspaceReply_Error_Kind_name = map[int32]string{
+		0: "KIND_UNSPECIFIED",
+		1: "KIND_NOT_EXIST",
+		2: "KIND_EXIST",
+		3: "KIND_PERMISSION",
+	}
+	WorkspaceReply_Error_Kind_value = map[string]int32{
+		"KIND_UNSPECIFIED": 0,
+		"KIND_NOT_EXIST":   1,
+		"KIND_EXIST":       2,
+		"KIND_PERMISSION":  3,
+	}
+)
+
+func (x WorkspaceReply_Error_Kind) Enum() *WorkspaceReply_Error_Kind {
+	p := new(WorkspaceReply_Error_Kind)
+	*p = x
+	return p
+}
+
+func (x WorkspaceReply_Error_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WorkspaceReply_Error_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_bonk_v0_bonk_proto_enumTypes[1].Descriptor()
+}
+
+func (WorkspaceReply_Error_Kind) Type() protoreflect.EnumType {
+	return &file_bonk_v0_bonk_proto_enumTypes[1]
+}
+
+func (x WorkspaceReply_Error_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
 type OpenSessionRequest struct {
 	state                           protoimpl.MessageState                    `protogen:"opaque.v1"`
 	xxx_hidden_SessionId            *string                                   `protobuf:"bytes,1,opt,name=session_id,json=sessionId"`
@@ -87,6 +181,15 @@ func (x *OpenSessionRequest) GetLocal() *OpenSessionRequest_WorkspaceDescription
 	return nil
 }
 
+func (x *OpenSessionRequest) GetRemote() *OpenSessionRequest_WorkspaceDescriptionRemote {
+	if x != nil {
+		if x, ok := x.xxx_hidden_WorkspaceDescription.(*openSessionRequest_Remote); ok {
+			return x.Remote
+		}
+	}
+	return nil
+}
+
 func (x *OpenSessionRequest) GetTest() *OpenSessionRequest_WorkspaceDescriptionTest {
 	if x != nil {
 		if x, ok := x.xxx_hidden_WorkspaceDescription.(*openSessionRequest_Test); ok {
@@ -113,6 +216,14 @@ func (x *OpenSessionRequest) SetLocal(v *OpenSessionRequest_WorkspaceDescription
 	x.xxx_hidden_WorkspaceDescription = &openSessionRequest_Local{v}
 }
 
+func (x *OpenSessionRequest) SetRemote(v *OpenSessionRequest_WorkspaceDescriptionRemote) {
+	if v == nil {
+		x.xxx_hidden_WorkspaceDescription = nil
+		return
+	}
+	x.xxx_hidden_WorkspaceDescription = &openSessionRequest_Remote{v}
+}
+
 func (x *OpenSessionRequest) SetTest(v *OpenSessionRequest_WorkspaceDescriptionTest) {
 	if v == nil {
 		x.xxx_hidden_WorkspaceDescription = nil
@@ -150,6 +261,14 @@ func (x *OpenSessionRequest) HasLocal() bool {
 	return ok
 }
 
+func (x *OpenSessionRequest) HasRemote() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_WorkspaceDescription.(*openSessionRequest_Remote)
+	return ok
+}
+
 func (x *OpenSessionRequest) HasTest() bool {
 	if x == nil {
 		return false
@@ -177,6 +296,12 @@ func (x *OpenSessionRequest) ClearLocal() {
 	}
 }
 
+func (x *OpenSessionRequest) ClearRemote() {
+	if _, ok := x.xxx_hidden_WorkspaceDescription.(*openSessionRequest_Remote); ok {
+		x.xxx_hidden_WorkspaceDescription = nil
+	}
+}
+
 func (x *OpenSessionRequest) ClearTest() {
 	if _, ok := x.xxx_hidden_WorkspaceDescription.(*openSessionRequest_Test); ok {
 		x.xxx_hidden_WorkspaceDescription = nil
@@ -185,6 +310,7 @@ func (x *OpenSessionRequest) ClearTest() {
 
 const OpenSessionRequest_WorkspaceDescription_not_set_case case_OpenSessionRequest_WorkspaceDescription = 0
 const OpenSessionRequest_Local_case case_OpenSessionRequest_WorkspaceDescription = 5
+const OpenSessionRequest_Remote_case case_OpenSessionRequest_WorkspaceDescription = 6
 const OpenSessionRequest_Test_case case_OpenSessionRequest_WorkspaceDescription = 99
 
 func (x *OpenSessionRequest) WhichWorkspaceDescription() case_OpenSessionRequest_WorkspaceDescription {
@@ -194,6 +320,8 @@ func (x *OpenSessionRequest) WhichWorkspaceDescription() case_OpenSessionRequest
 	switch x.xxx_hidden_WorkspaceDescription.(type) {
 	case *openSessionRequest_Local:
 		return OpenSessionRequest_Local_case
+	case *openSessionRequest_Remote:
+		return OpenSessionRequest_Remote_case
 	case *openSessionRequest_Test:
 		return OpenSessionRequest_Test_case
 	default:
@@ -207,8 +335,9 @@ type OpenSessionRequest_builder struct {
 	SessionId    *string
 	LogStreaming *OpenSessionRequest_LogStreamingOptions
 	// Fields of oneof xxx_hidden_WorkspaceDescription:
-	Local *OpenSessionRequest_WorkspaceDescriptionLocal
-	Test  *OpenSessionRequest_WorkspaceDescriptionTest
+	Local  *OpenSessionRequest_WorkspaceDescriptionLocal
+	Remote *OpenSessionRequest_WorkspaceDescriptionRemote
+	Test   *OpenSessionRequest_WorkspaceDescriptionTest
 	// -- end of xxx_hidden_WorkspaceDescription
 }
 
@@ -224,6 +353,9 @@ func (b0 OpenSessionRequest_builder) Build() *OpenSessionRequest {
 	if b.Local != nil {
 		x.xxx_hidden_WorkspaceDescription = &openSessionRequest_Local{b.Local}
 	}
+	if b.Remote != nil {
+		x.xxx_hidden_WorkspaceDescription = &openSessionRequest_Remote{b.Remote}
+	}
 	if b.Test != nil {
 		x.xxx_hidden_WorkspaceDescription = &openSessionRequest_Test{b.Test}
 	}
@@ -248,12 +380,18 @@ type openSessionRequest_Local struct {
 	Local *OpenSessionRequest_WorkspaceDescriptionLocal `protobuf:"bytes,5,opt,name=local,oneof"`
 }
 
+type openSessionRequest_Remote struct {
+	Remote *OpenSessionRequest_WorkspaceDescriptionRemote `protobuf:"bytes,6,opt,name=remote,oneof"`
+}
+
 type openSessionRequest_Test struct {
 	Test *OpenSessionRequest_WorkspaceDescriptionTest `protobuf:"bytes,99,opt,name=test,oneof"`
 }
 
 func (*openSessionRequest_Local) isOpenSessionRequest_WorkspaceDescription() {}
 
+func (*openSessionRequest_Remote) isOpenSessionRequest_WorkspaceDescription() {}
+
 func (*openSessionRequest_Test) isOpenSessionRequest_WorkspaceDescription() {}
 
 type OpenSessionResponse struct {
@@ -732,9 +870,9 @@ func (b0 ExecuteTaskRequest_builder) Build() *ExecuteTaskRequest {
 }
 
 type ExecuteTaskResponse struct {
-	state                    protoimpl.MessageState               `protogen:"opaque.v1"`
-	xxx_hidden_Output        []string                             `protobuf:"bytes,1,rep,name=output"`
-	xxx_hidden_FollowupTasks *[]*ExecuteTaskResponse_FollowupTask `protobuf:"bytes,2,rep,name=followup_tasks,json=followupTasks"`
+	state                    protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Output        []string               `protobuf:"bytes,1,rep,name=output"`
+	xxx_hidden_FollowupTasks *[]*BuildTask          `protobuf:"bytes,2,rep,name=followup_tasks,json=followupTasks"`
 	unknownFields            protoimpl.UnknownFields
 	sizeCache                protoimpl.SizeCache
 }
@@ -771,7 +909,7 @@ func (x *ExecuteTaskResponse) GetOutput() []string {
 	return nil
 }
 
-func (x *ExecuteTaskResponse) GetFollowupTasks() []*ExecuteTaskResponse_FollowupTask {
+func (x *ExecuteTaskResponse) GetFollowupTasks() []*BuildTask {
 	if x != nil {
 		if x.xxx_hidden_FollowupTasks != nil {
 			return *x.xxx_hidden_FollowupTasks
@@ -784,7 +922,7 @@ func (x *ExecuteTaskResponse) SetOutput(v []string) {
 	x.xxx_hidden_Output = v
 }
 
-func (x *ExecuteTaskResponse) SetFollowupTasks(v []*ExecuteTaskResponse_FollowupTask) {
+func (x *ExecuteTaskResponse) SetFollowupTasks(v []*BuildTask) {
 	x.xxx_hidden_FollowupTasks = &v
 }
 
@@ -792,7 +930,7 @@ type ExecuteTaskResponse_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
 	Output        []string
-	FollowupTasks []*ExecuteTaskResponse_FollowupTask
+	FollowupTasks []*BuildTask
 }
 
 func (b0 ExecuteTaskResponse_builder) Build() *ExecuteTaskResponse {
@@ -804,31 +942,75 @@ func (b0 ExecuteTaskResponse_builder) Build() *ExecuteTaskResponse {
 	return m0
 }
 
-type OpenSessionRequest_LogStreamingOptions struct {
-	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Level       int64                  `protobuf:"varint,1,opt,name=level"`
-	xxx_hidden_AddSource   bool                   `protobuf:"varint,2,opt,name=add_source,json=addSource"`
+type DescribeRequest struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DescribeRequest) Reset() {
+	*x = DescribeRequest{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DescribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DescribeRequest) ProtoMessage() {}
+
+func (x *DescribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type DescribeRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 DescribeRequest_builder) Build() *DescribeRequest {
+	m0 := &DescribeRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
+}
+
+type DescribeResponse struct {
+	state                  protoimpl.MessageState        `protogen:"opaque.v1"`
+	xxx_hidden_Name        *string                       `protobuf:"bytes,1,opt,name=name"`
+	xxx_hidden_Version     *string                       `protobuf:"bytes,2,opt,name=version"`
+	xxx_hidden_Executors   *[]*DescribeResponse_Executor `protobuf:"bytes,3,rep,name=executors"`
 	XXX_raceDetectHookData protoimpl.RaceDetectHookData
 	XXX_presence           [1]uint32
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
 }
 
-func (x *OpenSessionRequest_LogStreamingOptions) Reset() {
-	*x = OpenSessionRequest_LogStreamingOptions{}
-	mi := &file_bonk_v0_bonk_proto_msgTypes[6]
+func (x *DescribeResponse) Reset() {
+	*x = DescribeResponse{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OpenSessionRequest_LogStreamingOptions) String() string {
+func (x *DescribeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OpenSessionRequest_LogStreamingOptions) ProtoMessage() {}
+func (*DescribeResponse) ProtoMessage() {}
 
-func (x *OpenSessionRequest_LogStreamingOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_bonk_v0_bonk_proto_msgTypes[6]
+func (x *DescribeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -839,100 +1021,122 @@ func (x *OpenSessionRequest_LogStreamingOptions) ProtoReflect() protoreflect.Mes
 	return mi.MessageOf(x)
 }
 
-func (x *OpenSessionRequest_LogStreamingOptions) GetLevel() int64 {
+func (x *DescribeResponse) GetName() string {
 	if x != nil {
-		return x.xxx_hidden_Level
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
 	}
-	return 0
+	return ""
 }
 
-func (x *OpenSessionRequest_LogStreamingOptions) GetAddSource() bool {
+func (x *DescribeResponse) GetVersion() string {
 	if x != nil {
-		return x.xxx_hidden_AddSource
+		if x.xxx_hidden_Version != nil {
+			return *x.xxx_hidden_Version
+		}
+		return ""
 	}
-	return false
+	return ""
 }
 
-func (x *OpenSessionRequest_LogStreamingOptions) SetLevel(v int64) {
-	x.xxx_hidden_Level = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+func (x *DescribeResponse) GetExecutors() []*DescribeResponse_Executor {
+	if x != nil {
+		if x.xxx_hidden_Executors != nil {
+			return *x.xxx_hidden_Executors
+		}
+	}
+	return nil
 }
 
-func (x *OpenSessionRequest_LogStreamingOptions) SetAddSource(v bool) {
-	x.xxx_hidden_AddSource = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+func (x *DescribeResponse) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
 }
 
-func (x *OpenSessionRequest_LogStreamingOptions) HasLevel() bool {
+func (x *DescribeResponse) SetVersion(v string) {
+	x.xxx_hidden_Version = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *DescribeResponse) SetExecutors(v []*DescribeResponse_Executor) {
+	x.xxx_hidden_Executors = &v
+}
+
+func (x *DescribeResponse) HasName() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
-func (x *OpenSessionRequest_LogStreamingOptions) HasAddSource() bool {
+func (x *DescribeResponse) HasVersion() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
 }
 
-func (x *OpenSessionRequest_LogStreamingOptions) ClearLevel() {
+func (x *DescribeResponse) ClearName() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
-	x.xxx_hidden_Level = 0
+	x.xxx_hidden_Name = nil
 }
 
-func (x *OpenSessionRequest_LogStreamingOptions) ClearAddSource() {
+func (x *DescribeResponse) ClearVersion() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
-	x.xxx_hidden_AddSource = false
+	x.xxx_hidden_Version = nil
 }
 
-type OpenSessionRequest_LogStreamingOptions_builder struct {
+type DescribeResponse_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Level     *int64
-	AddSource *bool
+	Name      *string
+	Version   *string
+	Executors []*DescribeResponse_Executor
 }
 
-func (b0 OpenSessionRequest_LogStreamingOptions_builder) Build() *OpenSessionRequest_LogStreamingOptions {
-	m0 := &OpenSessionRequest_LogStreamingOptions{}
+func (b0 DescribeResponse_builder) Build() *DescribeResponse {
+	m0 := &DescribeResponse{}
 	b, x := &b0, m0
 	_, _ = b, x
-	if b.Level != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
-		x.xxx_hidden_Level = *b.Level
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_Name = b.Name
 	}
-	if b.AddSource != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
-		x.xxx_hidden_AddSource = *b.AddSource
+	if b.Version != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_Version = b.Version
 	}
+	x.xxx_hidden_Executors = &b.Executors
 	return m0
 }
 
-type OpenSessionRequest_WorkspaceDescriptionLocal struct {
-	state                   protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_AbsolutePath *string                `protobuf:"bytes,1,opt,name=absolute_path,json=absolutePath"`
-	XXX_raceDetectHookData  protoimpl.RaceDetectHookData
-	XXX_presence            [1]uint32
-	unknownFields           protoimpl.UnknownFields
-	sizeCache               protoimpl.SizeCache
+type CancelTaskRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_SessionId   *string                `protobuf:"bytes,1,opt,name=session_id,json=sessionId"`
+	xxx_hidden_Id          *string                `protobuf:"bytes,2,opt,name=id"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
-func (x *OpenSessionRequest_WorkspaceDescriptionLocal) Reset() {
-	*x = OpenSessionRequest_WorkspaceDescriptionLocal{}
-	mi := &file_bonk_v0_bonk_proto_msgTypes[7]
+func (x *CancelTaskRequest) Reset() {
+	*x = CancelTaskRequest{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OpenSessionRequest_WorkspaceDescriptionLocal) String() string {
+func (x *CancelTaskRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OpenSessionRequest_WorkspaceDescriptionLocal) ProtoMessage() {}
+func (*CancelTaskRequest) ProtoMessage() {}
 
-func (x *OpenSessionRequest_WorkspaceDescriptionLocal) ProtoReflect() protoreflect.Message {
-	mi := &file_bonk_v0_bonk_proto_msgTypes[7]
+func (x *CancelTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -943,71 +1147,106 @@ func (x *OpenSessionRequest_WorkspaceDescriptionLocal) ProtoReflect() protorefle
 	return mi.MessageOf(x)
 }
 
-func (x *OpenSessionRequest_WorkspaceDescriptionLocal) GetAbsolutePath() string {
+func (x *CancelTaskRequest) GetSessionId() string {
 	if x != nil {
-		if x.xxx_hidden_AbsolutePath != nil {
-			return *x.xxx_hidden_AbsolutePath
+		if x.xxx_hidden_SessionId != nil {
+			return *x.xxx_hidden_SessionId
 		}
 		return ""
 	}
 	return ""
 }
 
-func (x *OpenSessionRequest_WorkspaceDescriptionLocal) SetAbsolutePath(v string) {
-	x.xxx_hidden_AbsolutePath = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+func (x *CancelTaskRequest) GetId() string {
+	if x != nil {
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
+	}
+	return ""
 }
 
-func (x *OpenSessionRequest_WorkspaceDescriptionLocal) HasAbsolutePath() bool {
+func (x *CancelTaskRequest) SetSessionId(v string) {
+	x.xxx_hidden_SessionId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *CancelTaskRequest) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *CancelTaskRequest) HasSessionId() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
-func (x *OpenSessionRequest_WorkspaceDescriptionLocal) ClearAbsolutePath() {
+func (x *CancelTaskRequest) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *CancelTaskRequest) ClearSessionId() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
-	x.xxx_hidden_AbsolutePath = nil
+	x.xxx_hidden_SessionId = nil
 }
 
-type OpenSessionRequest_WorkspaceDescriptionLocal_builder struct {
+func (x *CancelTaskRequest) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Id = nil
+}
+
+type CancelTaskRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	AbsolutePath *string
+	SessionId *string
+	Id        *string
 }
 
-func (b0 OpenSessionRequest_WorkspaceDescriptionLocal_builder) Build() *OpenSessionRequest_WorkspaceDescriptionLocal {
-	m0 := &OpenSessionRequest_WorkspaceDescriptionLocal{}
+func (b0 CancelTaskRequest_builder) Build() *CancelTaskRequest {
+	m0 := &CancelTaskRequest{}
 	b, x := &b0, m0
 	_, _ = b, x
-	if b.AbsolutePath != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
-		x.xxx_hidden_AbsolutePath = b.AbsolutePath
+	if b.SessionId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_SessionId = b.SessionId
+	}
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Id = b.Id
 	}
 	return m0
 }
 
-type OpenSessionRequest_WorkspaceDescriptionTest struct {
-	state         protoimpl.MessageState `protogen:"opaque.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type CancelTaskResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Canceled    bool                   `protobuf:"varint,1,opt,name=canceled"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
-func (x *OpenSessionRequest_WorkspaceDescriptionTest) Reset() {
-	*x = OpenSessionRequest_WorkspaceDescriptionTest{}
-	mi := &file_bonk_v0_bonk_proto_msgTypes[8]
+func (x *CancelTaskResponse) Reset() {
+	*x = CancelTaskResponse{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OpenSessionRequest_WorkspaceDescriptionTest) String() string {
+func (x *CancelTaskResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OpenSessionRequest_WorkspaceDescriptionTest) ProtoMessage() {}
+func (*CancelTaskResponse) ProtoMessage() {}
 
-func (x *OpenSessionRequest_WorkspaceDescriptionTest) ProtoReflect() protoreflect.Message {
-	mi := &file_bonk_v0_bonk_proto_msgTypes[8]
+func (x *CancelTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1018,39 +1257,5006 @@ func (x *OpenSessionRequest_WorkspaceDescriptionTest) ProtoReflect() protoreflec
 	return mi.MessageOf(x)
 }
 
-type OpenSessionRequest_WorkspaceDescriptionTest_builder struct {
-	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+func (x *CancelTaskResponse) GetCanceled() bool {
+	if x != nil {
+		return x.xxx_hidden_Canceled
+	}
+	return false
+}
 
+func (x *CancelTaskResponse) SetCanceled(v bool) {
+	x.xxx_hidden_Canceled = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
 }
 
-func (b0 OpenSessionRequest_WorkspaceDescriptionTest_builder) Build() *OpenSessionRequest_WorkspaceDescriptionTest {
+func (x *CancelTaskResponse) HasCanceled() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *CancelTaskResponse) ClearCanceled() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Canceled = false
+}
+
+type CancelTaskResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// False if the task wasn't running.
+	Canceled *bool
+}
+
+func (b0 CancelTaskResponse_builder) Build() *CancelTaskResponse {
+	m0 := &CancelTaskResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Canceled != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Canceled = *b.Canceled
+	}
+	return m0
+}
+
+// Attached as a status detail to CodeExecErr errors returned from ExecuteTask,
+// so that clients can reconstruct the executor's error.
+type ExecutionError struct {
+	state                  protoimpl.MessageState      `protogen:"opaque.v1"`
+	xxx_hidden_Kind        *string                     `protobuf:"bytes,1,opt,name=kind"`
+	xxx_hidden_Message     *string                     `protobuf:"bytes,2,opt,name=message"`
+	xxx_hidden_Positions   *[]*ExecutionError_Position `protobuf:"bytes,3,rep,name=positions"`
+	xxx_hidden_Retryable   bool                        `protobuf:"varint,4,opt,name=retryable"`
+	xxx_hidden_Causes      *[]*ExecutionError          `protobuf:"bytes,5,rep,name=causes"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ExecutionError) Reset() {
+	*x = ExecutionError{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecutionError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecutionError) ProtoMessage() {}
+
+func (x *ExecutionError) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ExecutionError) GetKind() string {
+	if x != nil {
+		if x.xxx_hidden_Kind != nil {
+			return *x.xxx_hidden_Kind
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ExecutionError) GetMessage() string {
+	if x != nil {
+		if x.xxx_hidden_Message != nil {
+			return *x.xxx_hidden_Message
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ExecutionError) GetPositions() []*ExecutionError_Position {
+	if x != nil {
+		if x.xxx_hidden_Positions != nil {
+			return *x.xxx_hidden_Positions
+		}
+	}
+	return nil
+}
+
+func (x *ExecutionError) GetRetryable() bool {
+	if x != nil {
+		return x.xxx_hidden_Retryable
+	}
+	return false
+}
+
+func (x *ExecutionError) GetCauses() []*ExecutionError {
+	if x != nil {
+		if x.xxx_hidden_Causes != nil {
+			return *x.xxx_hidden_Causes
+		}
+	}
+	return nil
+}
+
+func (x *ExecutionError) SetKind(v string) {
+	x.xxx_hidden_Kind = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 5)
+}
+
+func (x *ExecutionError) SetMessage(v string) {
+	x.xxx_hidden_Message = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 5)
+}
+
+func (x *ExecutionError) SetPositions(v []*ExecutionError_Position) {
+	x.xxx_hidden_Positions = &v
+}
+
+func (x *ExecutionError) SetRetryable(v bool) {
+	x.xxx_hidden_Retryable = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 5)
+}
+
+func (x *ExecutionError) SetCauses(v []*ExecutionError) {
+	x.xxx_hidden_Causes = &v
+}
+
+func (x *ExecutionError) HasKind() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ExecutionError) HasMessage() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *ExecutionError) HasRetryable() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *ExecutionError) ClearKind() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Kind = nil
+}
+
+func (x *ExecutionError) ClearMessage() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Message = nil
+}
+
+func (x *ExecutionError) ClearRetryable() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Retryable = false
+}
+
+type ExecutionError_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Kind      *string
+	Message   *string
+	Positions []*ExecutionError_Position
+	Retryable *bool
+	Causes    []*ExecutionError
+}
+
+func (b0 ExecutionError_builder) Build() *ExecutionError {
+	m0 := &ExecutionError{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Kind != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 5)
+		x.xxx_hidden_Kind = b.Kind
+	}
+	if b.Message != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 5)
+		x.xxx_hidden_Message = b.Message
+	}
+	x.xxx_hidden_Positions = &b.Positions
+	if b.Retryable != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 5)
+		x.xxx_hidden_Retryable = *b.Retryable
+	}
+	x.xxx_hidden_Causes = &b.Causes
+	return m0
+}
+
+// A task submitted as part of a build, or returned by an executor as a followup.
+type BuildTask struct {
+	state                   protoimpl.MessageState   `protogen:"opaque.v1"`
+	xxx_hidden_Id           *string                  `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_Executor     *string                  `protobuf:"bytes,2,opt,name=executor"`
+	xxx_hidden_Inputs       []string                 `protobuf:"bytes,3,rep,name=inputs"`
+	xxx_hidden_Arguments    *structpb.Value          `protobuf:"bytes,4,opt,name=arguments"`
+	xxx_hidden_Dependencies []string                 `protobuf:"bytes,5,rep,name=dependencies"`
+	xxx_hidden_Resources    map[string]int64         `protobuf:"bytes,6,rep,name=resources" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	xxx_hidden_Timeout      *durationpb.Duration     `protobuf:"bytes,7,opt,name=timeout"`
+	xxx_hidden_Retry        *RetryPolicy             `protobuf:"bytes,8,opt,name=retry"`
+	xxx_hidden_Matrix       map[string]*MatrixValues `protobuf:"bytes,9,rep,name=matrix" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	xxx_hidden_When         *Condition               `protobuf:"bytes,10,opt,name=when"`
+	XXX_raceDetectHookData  protoimpl.RaceDetectHookData
+	XXX_presence            [1]uint32
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *BuildTask) Reset() {
+	*x = BuildTask{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuildTask) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildTask) ProtoMessage() {}
+
+func (x *BuildTask) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *BuildTask) GetId() string {
+	if x != nil {
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *BuildTask) GetExecutor() string {
+	if x != nil {
+		if x.xxx_hidden_Executor != nil {
+			return *x.xxx_hidden_Executor
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *BuildTask) GetInputs() []string {
+	if x != nil {
+		return x.xxx_hidden_Inputs
+	}
+	return nil
+}
+
+func (x *BuildTask) GetArguments() *structpb.Value {
+	if x != nil {
+		return x.xxx_hidden_Arguments
+	}
+	return nil
+}
+
+func (x *BuildTask) GetDependencies() []string {
+	if x != nil {
+		return x.xxx_hidden_Dependencies
+	}
+	return nil
+}
+
+func (x *BuildTask) GetResources() map[string]int64 {
+	if x != nil {
+		return x.xxx_hidden_Resources
+	}
+	return nil
+}
+
+func (x *BuildTask) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.xxx_hidden_Timeout
+	}
+	return nil
+}
+
+func (x *BuildTask) GetRetry() *RetryPolicy {
+	if x != nil {
+		return x.xxx_hidden_Retry
+	}
+	return nil
+}
+
+func (x *BuildTask) GetMatrix() map[string]*MatrixValues {
+	if x != nil {
+		return x.xxx_hidden_Matrix
+	}
+	return nil
+}
+
+func (x *BuildTask) GetWhen() *Condition {
+	if x != nil {
+		return x.xxx_hidden_When
+	}
+	return nil
+}
+
+func (x *BuildTask) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 10)
+}
+
+func (x *BuildTask) SetExecutor(v string) {
+	x.xxx_hidden_Executor = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 10)
+}
+
+func (x *BuildTask) SetInputs(v []string) {
+	x.xxx_hidden_Inputs = v
+}
+
+func (x *BuildTask) SetArguments(v *structpb.Value) {
+	x.xxx_hidden_Arguments = v
+}
+
+func (x *BuildTask) SetDependencies(v []string) {
+	x.xxx_hidden_Dependencies = v
+}
+
+func (x *BuildTask) SetResources(v map[string]int64) {
+	x.xxx_hidden_Resources = v
+}
+
+func (x *BuildTask) SetTimeout(v *durationpb.Duration) {
+	x.xxx_hidden_Timeout = v
+}
+
+func (x *BuildTask) SetRetry(v *RetryPolicy) {
+	x.xxx_hidden_Retry = v
+}
+
+func (x *BuildTask) SetMatrix(v map[string]*MatrixValues) {
+	x.xxx_hidden_Matrix = v
+}
+
+func (x *BuildTask) SetWhen(v *Condition) {
+	x.xxx_hidden_When = v
+}
+
+func (x *BuildTask) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *BuildTask) HasExecutor() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *BuildTask) HasArguments() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Arguments != nil
+}
+
+func (x *BuildTask) HasTimeout() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Timeout != nil
+}
+
+func (x *BuildTask) HasRetry() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Retry != nil
+}
+
+func (x *BuildTask) HasWhen() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_When != nil
+}
+
+func (x *BuildTask) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = nil
+}
+
+func (x *BuildTask) ClearExecutor() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Executor = nil
+}
+
+func (x *BuildTask) ClearArguments() {
+	x.xxx_hidden_Arguments = nil
+}
+
+func (x *BuildTask) ClearTimeout() {
+	x.xxx_hidden_Timeout = nil
+}
+
+func (x *BuildTask) ClearRetry() {
+	x.xxx_hidden_Retry = nil
+}
+
+func (x *BuildTask) ClearWhen() {
+	x.xxx_hidden_When = nil
+}
+
+type BuildTask_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id           *string
+	Executor     *string
+	Inputs       []string
+	Arguments    *structpb.Value
+	Dependencies []string
+	Resources    map[string]int64
+	Timeout      *durationpb.Duration
+	Retry        *RetryPolicy
+	Matrix       map[string]*MatrixValues
+	When         *Condition
+}
+
+func (b0 BuildTask_builder) Build() *BuildTask {
+	m0 := &BuildTask{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 10)
+		x.xxx_hidden_Id = b.Id
+	}
+	if b.Executor != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 10)
+		x.xxx_hidden_Executor = b.Executor
+	}
+	x.xxx_hidden_Inputs = b.Inputs
+	x.xxx_hidden_Arguments = b.Arguments
+	x.xxx_hidden_Dependencies = b.Dependencies
+	x.xxx_hidden_Resources = b.Resources
+	x.xxx_hidden_Timeout = b.Timeout
+	x.xxx_hidden_Retry = b.Retry
+	x.xxx_hidden_Matrix = b.Matrix
+	x.xxx_hidden_When = b.When
+	return m0
+}
+
+// This is meant to mirror task.Condition
+type Condition struct {
+	state                  protoimpl.MessageState     `protogen:"opaque.v1"`
+	xxx_hidden_Profiles    []string                   `protobuf:"bytes,1,rep,name=profiles"`
+	xxx_hidden_Env         map[string]string          `protobuf:"bytes,2,rep,name=env" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	xxx_hidden_Exists      []string                   `protobuf:"bytes,3,rep,name=exists"`
+	xxx_hidden_InputsExist bool                       `protobuf:"varint,4,opt,name=inputs_exist,json=inputsExist"`
+	xxx_hidden_Results     map[string]string          `protobuf:"bytes,5,rep,name=results" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	xxx_hidden_Not         *Condition                 `protobuf:"bytes,6,opt,name=not"`
+	xxx_hidden_Outputs     map[string]*OutputPatterns `protobuf:"bytes,7,rep,name=outputs" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *Condition) Reset() {
+	*x = Condition{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Condition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Condition) ProtoMessage() {}
+
+func (x *Condition) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *Condition) GetProfiles() []string {
+	if x != nil {
+		return x.xxx_hidden_Profiles
+	}
+	return nil
+}
+
+func (x *Condition) GetEnv() map[string]string {
+	if x != nil {
+		return x.xxx_hidden_Env
+	}
+	return nil
+}
+
+func (x *Condition) GetExists() []string {
+	if x != nil {
+		return x.xxx_hidden_Exists
+	}
+	return nil
+}
+
+func (x *Condition) GetInputsExist() bool {
+	if x != nil {
+		return x.xxx_hidden_InputsExist
+	}
+	return false
+}
+
+func (x *Condition) GetResults() map[string]string {
+	if x != nil {
+		return x.xxx_hidden_Results
+	}
+	return nil
+}
+
+func (x *Condition) GetNot() *Condition {
+	if x != nil {
+		return x.xxx_hidden_Not
+	}
+	return nil
+}
+
+func (x *Condition) GetOutputs() map[string]*OutputPatterns {
+	if x != nil {
+		return x.xxx_hidden_Outputs
+	}
+	return nil
+}
+
+func (x *Condition) SetProfiles(v []string) {
+	x.xxx_hidden_Profiles = v
+}
+
+func (x *Condition) SetEnv(v map[string]string) {
+	x.xxx_hidden_Env = v
+}
+
+func (x *Condition) SetExists(v []string) {
+	x.xxx_hidden_Exists = v
+}
+
+func (x *Condition) SetInputsExist(v bool) {
+	x.xxx_hidden_InputsExist = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 7)
+}
+
+func (x *Condition) SetResults(v map[string]string) {
+	x.xxx_hidden_Results = v
+}
+
+func (x *Condition) SetNot(v *Condition) {
+	x.xxx_hidden_Not = v
+}
+
+func (x *Condition) SetOutputs(v map[string]*OutputPatterns) {
+	x.xxx_hidden_Outputs = v
+}
+
+func (x *Condition) HasInputsExist() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *Condition) HasNot() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Not != nil
+}
+
+func (x *Condition) ClearInputsExist() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_InputsExist = false
+}
+
+func (x *Condition) ClearNot() {
+	x.xxx_hidden_Not = nil
+}
+
+type Condition_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Profiles    []string
+	Env         map[string]string
+	Exists      []string
+	InputsExist *bool
+	// Maps task IDs to the outcomes they must finish with.
+	Results map[string]string
+	Not     *Condition
+	// Maps task IDs to patterns which must match their outputs.
+	Outputs map[string]*OutputPatterns
+}
+
+func (b0 Condition_builder) Build() *Condition {
+	m0 := &Condition{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Profiles = b.Profiles
+	x.xxx_hidden_Env = b.Env
+	x.xxx_hidden_Exists = b.Exists
+	if b.InputsExist != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 7)
+		x.xxx_hidden_InputsExist = *b.InputsExist
+	}
+	x.xxx_hidden_Results = b.Results
+	x.xxx_hidden_Not = b.Not
+	x.xxx_hidden_Outputs = b.Outputs
+	return m0
+}
+
+// The patterns of Condition.outputs for a task
+type OutputPatterns struct {
+	state               protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Patterns []string               `protobuf:"bytes,1,rep,name=patterns"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *OutputPatterns) Reset() {
+	*x = OutputPatterns{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OutputPatterns) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OutputPatterns) ProtoMessage() {}
+
+func (x *OutputPatterns) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *OutputPatterns) GetPatterns() []string {
+	if x != nil {
+		return x.xxx_hidden_Patterns
+	}
+	return nil
+}
+
+func (x *OutputPatterns) SetPatterns(v []string) {
+	x.xxx_hidden_Patterns = v
+}
+
+type OutputPatterns_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Patterns []string
+}
+
+func (b0 OutputPatterns_builder) Build() *OutputPatterns {
+	m0 := &OutputPatterns{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Patterns = b.Patterns
+	return m0
+}
+
+// The values of a parameter of task.Matrix
+type MatrixValues struct {
+	state             protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Values []string               `protobuf:"bytes,1,rep,name=values"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *MatrixValues) Reset() {
+	*x = MatrixValues{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatrixValues) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatrixValues) ProtoMessage() {}
+
+func (x *MatrixValues) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *MatrixValues) GetValues() []string {
+	if x != nil {
+		return x.xxx_hidden_Values
+	}
+	return nil
+}
+
+func (x *MatrixValues) SetValues(v []string) {
+	x.xxx_hidden_Values = v
+}
+
+type MatrixValues_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Values []string
+}
+
+func (b0 MatrixValues_builder) Build() *MatrixValues {
+	m0 := &MatrixValues{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Values = b.Values
+	return m0
+}
+
+// This is meant to mirror task.RetryPolicy
+type RetryPolicy struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_MaxAttempts int64                  `protobuf:"varint,1,opt,name=max_attempts,json=maxAttempts"`
+	xxx_hidden_Backoff     *durationpb.Duration   `protobuf:"bytes,2,opt,name=backoff"`
+	xxx_hidden_MaxBackoff  *durationpb.Duration   `protobuf:"bytes,3,opt,name=max_backoff,json=maxBackoff"`
+	xxx_hidden_RetryOn     []string               `protobuf:"bytes,4,rep,name=retry_on,json=retryOn"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *RetryPolicy) Reset() {
+	*x = RetryPolicy{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryPolicy) ProtoMessage() {}
+
+func (x *RetryPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RetryPolicy) GetMaxAttempts() int64 {
+	if x != nil {
+		return x.xxx_hidden_MaxAttempts
+	}
+	return 0
+}
+
+func (x *RetryPolicy) GetBackoff() *durationpb.Duration {
+	if x != nil {
+		return x.xxx_hidden_Backoff
+	}
+	return nil
+}
+
+func (x *RetryPolicy) GetMaxBackoff() *durationpb.Duration {
+	if x != nil {
+		return x.xxx_hidden_MaxBackoff
+	}
+	return nil
+}
+
+func (x *RetryPolicy) GetRetryOn() []string {
+	if x != nil {
+		return x.xxx_hidden_RetryOn
+	}
+	return nil
+}
+
+func (x *RetryPolicy) SetMaxAttempts(v int64) {
+	x.xxx_hidden_MaxAttempts = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
+}
+
+func (x *RetryPolicy) SetBackoff(v *durationpb.Duration) {
+	x.xxx_hidden_Backoff = v
+}
+
+func (x *RetryPolicy) SetMaxBackoff(v *durationpb.Duration) {
+	x.xxx_hidden_MaxBackoff = v
+}
+
+func (x *RetryPolicy) SetRetryOn(v []string) {
+	x.xxx_hidden_RetryOn = v
+}
+
+func (x *RetryPolicy) HasMaxAttempts() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *RetryPolicy) HasBackoff() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Backoff != nil
+}
+
+func (x *RetryPolicy) HasMaxBackoff() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_MaxBackoff != nil
+}
+
+func (x *RetryPolicy) ClearMaxAttempts() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_MaxAttempts = 0
+}
+
+func (x *RetryPolicy) ClearBackoff() {
+	x.xxx_hidden_Backoff = nil
+}
+
+func (x *RetryPolicy) ClearMaxBackoff() {
+	x.xxx_hidden_MaxBackoff = nil
+}
+
+type RetryPolicy_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	MaxAttempts *int64
+	Backoff     *durationpb.Duration
+	MaxBackoff  *durationpb.Duration
+	RetryOn     []string
+}
+
+func (b0 RetryPolicy_builder) Build() *RetryPolicy {
+	m0 := &RetryPolicy{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.MaxAttempts != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
+		x.xxx_hidden_MaxAttempts = *b.MaxAttempts
+	}
+	x.xxx_hidden_Backoff = b.Backoff
+	x.xxx_hidden_MaxBackoff = b.MaxBackoff
+	x.xxx_hidden_RetryOn = b.RetryOn
+	return m0
+}
+
+type SubmitBuildRequest struct {
+	state                    protoimpl.MessageState         `protogen:"opaque.v1"`
+	xxx_hidden_Sessions      *[]*SubmitBuildRequest_Session `protobuf:"bytes,1,rep,name=sessions"`
+	xxx_hidden_Facts         *Facts                         `protobuf:"bytes,2,opt,name=facts"`
+	xxx_hidden_Configuration *string                        `protobuf:"bytes,3,opt,name=configuration"`
+	XXX_raceDetectHookData   protoimpl.RaceDetectHookData
+	XXX_presence             [1]uint32
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *SubmitBuildRequest) Reset() {
+	*x = SubmitBuildRequest{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitBuildRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitBuildRequest) ProtoMessage() {}
+
+func (x *SubmitBuildRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *SubmitBuildRequest) GetSessions() []*SubmitBuildRequest_Session {
+	if x != nil {
+		if x.xxx_hidden_Sessions != nil {
+			return *x.xxx_hidden_Sessions
+		}
+	}
+	return nil
+}
+
+func (x *SubmitBuildRequest) GetFacts() *Facts {
+	if x != nil {
+		return x.xxx_hidden_Facts
+	}
+	return nil
+}
+
+func (x *SubmitBuildRequest) GetConfiguration() string {
+	if x != nil {
+		if x.xxx_hidden_Configuration != nil {
+			return *x.xxx_hidden_Configuration
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SubmitBuildRequest) SetSessions(v []*SubmitBuildRequest_Session) {
+	x.xxx_hidden_Sessions = &v
+}
+
+func (x *SubmitBuildRequest) SetFacts(v *Facts) {
+	x.xxx_hidden_Facts = v
+}
+
+func (x *SubmitBuildRequest) SetConfiguration(v string) {
+	x.xxx_hidden_Configuration = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *SubmitBuildRequest) HasFacts() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Facts != nil
+}
+
+func (x *SubmitBuildRequest) HasConfiguration() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *SubmitBuildRequest) ClearFacts() {
+	x.xxx_hidden_Facts = nil
+}
+
+func (x *SubmitBuildRequest) ClearConfiguration() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Configuration = nil
+}
+
+type SubmitBuildRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Sessions []*SubmitBuildRequest_Session
+	// The facts task conditions are evaluated against, which are the server's own if unset.
+	Facts *Facts
+	// Identifies the options the client would build with itself.
+	// The server refuses builds whose configuration differs from its own, so that they're built locally instead.
+	Configuration *string
+}
+
+func (b0 SubmitBuildRequest_builder) Build() *SubmitBuildRequest {
+	m0 := &SubmitBuildRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Sessions = &b.Sessions
+	x.xxx_hidden_Facts = b.Facts
+	if b.Configuration != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_Configuration = b.Configuration
+	}
+	return m0
+}
+
+// This is meant to mirror task.Facts
+type Facts struct {
+	state               protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Profiles []string               `protobuf:"bytes,1,rep,name=profiles"`
+	xxx_hidden_Env      map[string]string      `protobuf:"bytes,2,rep,name=env" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *Facts) Reset() {
+	*x = Facts{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Facts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Facts) ProtoMessage() {}
+
+func (x *Facts) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *Facts) GetProfiles() []string {
+	if x != nil {
+		return x.xxx_hidden_Profiles
+	}
+	return nil
+}
+
+func (x *Facts) GetEnv() map[string]string {
+	if x != nil {
+		return x.xxx_hidden_Env
+	}
+	return nil
+}
+
+func (x *Facts) SetProfiles(v []string) {
+	x.xxx_hidden_Profiles = v
+}
+
+func (x *Facts) SetEnv(v map[string]string) {
+	x.xxx_hidden_Env = v
+}
+
+type Facts_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Profiles []string
+	Env      map[string]string
+}
+
+func (b0 Facts_builder) Build() *Facts {
+	m0 := &Facts{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Profiles = b.Profiles
+	x.xxx_hidden_Env = b.Env
+	return m0
+}
+
+type BuildEvent struct {
+	state            protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Event isBuildEvent_Event     `protobuf_oneof:"event"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *BuildEvent) Reset() {
+	*x = BuildEvent{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuildEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildEvent) ProtoMessage() {}
+
+func (x *BuildEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *BuildEvent) GetStarted() *BuildEvent_Started {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Event.(*buildEvent_Started_); ok {
+			return x.Started
+		}
+	}
+	return nil
+}
+
+func (x *BuildEvent) GetTaskStatus() *BuildEvent_TaskStatus {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Event.(*buildEvent_TaskStatus_); ok {
+			return x.TaskStatus
+		}
+	}
+	return nil
+}
+
+func (x *BuildEvent) GetFinished() *BuildEvent_Finished {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Event.(*buildEvent_Finished_); ok {
+			return x.Finished
+		}
+	}
+	return nil
+}
+
+func (x *BuildEvent) SetStarted(v *BuildEvent_Started) {
+	if v == nil {
+		x.xxx_hidden_Event = nil
+		return
+	}
+	x.xxx_hidden_Event = &buildEvent_Started_{v}
+}
+
+func (x *BuildEvent) SetTaskStatus(v *BuildEvent_TaskStatus) {
+	if v == nil {
+		x.xxx_hidden_Event = nil
+		return
+	}
+	x.xxx_hidden_Event = &buildEvent_TaskStatus_{v}
+}
+
+func (x *BuildEvent) SetFinished(v *BuildEvent_Finished) {
+	if v == nil {
+		x.xxx_hidden_Event = nil
+		return
+	}
+	x.xxx_hidden_Event = &buildEvent_Finished_{v}
+}
+
+func (x *BuildEvent) HasEvent() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Event != nil
+}
+
+func (x *BuildEvent) HasStarted() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Event.(*buildEvent_Started_)
+	return ok
+}
+
+func (x *BuildEvent) HasTaskStatus() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Event.(*buildEvent_TaskStatus_)
+	return ok
+}
+
+func (x *BuildEvent) HasFinished() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Event.(*buildEvent_Finished_)
+	return ok
+}
+
+func (x *BuildEvent) ClearEvent() {
+	x.xxx_hidden_Event = nil
+}
+
+func (x *BuildEvent) ClearStarted() {
+	if _, ok := x.xxx_hidden_Event.(*buildEvent_Started_); ok {
+		x.xxx_hidden_Event = nil
+	}
+}
+
+func (x *BuildEvent) ClearTaskStatus() {
+	if _, ok := x.xxx_hidden_Event.(*buildEvent_TaskStatus_); ok {
+		x.xxx_hidden_Event = nil
+	}
+}
+
+func (x *BuildEvent) ClearFinished() {
+	if _, ok := x.xxx_hidden_Event.(*buildEvent_Finished_); ok {
+		x.xxx_hidden_Event = nil
+	}
+}
+
+const BuildEvent_Event_not_set_case case_BuildEvent_Event = 0
+const BuildEvent_Started_case case_BuildEvent_Event = 1
+const BuildEvent_TaskStatus_case case_BuildEvent_Event = 2
+const BuildEvent_Finished_case case_BuildEvent_Event = 3
+
+func (x *BuildEvent) WhichEvent() case_BuildEvent_Event {
+	if x == nil {
+		return BuildEvent_Event_not_set_case
+	}
+	switch x.xxx_hidden_Event.(type) {
+	case *buildEvent_Started_:
+		return BuildEvent_Started_case
+	case *buildEvent_TaskStatus_:
+		return BuildEvent_TaskStatus_case
+	case *buildEvent_Finished_:
+		return BuildEvent_Finished_case
+	default:
+		return BuildEvent_Event_not_set_case
+	}
+}
+
+type BuildEvent_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Fields of oneof xxx_hidden_Event:
+	Started    *BuildEvent_Started
+	TaskStatus *BuildEvent_TaskStatus
+	Finished   *BuildEvent_Finished
+	// -- end of xxx_hidden_Event
+}
+
+func (b0 BuildEvent_builder) Build() *BuildEvent {
+	m0 := &BuildEvent{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Started != nil {
+		x.xxx_hidden_Event = &buildEvent_Started_{b.Started}
+	}
+	if b.TaskStatus != nil {
+		x.xxx_hidden_Event = &buildEvent_TaskStatus_{b.TaskStatus}
+	}
+	if b.Finished != nil {
+		x.xxx_hidden_Event = &buildEvent_Finished_{b.Finished}
+	}
+	return m0
+}
+
+type case_BuildEvent_Event protoreflect.FieldNumber
+
+func (x case_BuildEvent_Event) String() string {
+	md := file_bonk_v0_bonk_proto_msgTypes[18].Descriptor()
+	if x == 0 {
+		return "not set"
+	}
+	return protoimpl.X.MessageFieldStringOf(md, protoreflect.FieldNumber(x))
+}
+
+type isBuildEvent_Event interface {
+	isBuildEvent_Event()
+}
+
+type buildEvent_Started_ struct {
+	Started *BuildEvent_Started `protobuf:"bytes,1,opt,name=started,oneof"`
+}
+
+type buildEvent_TaskStatus_ struct {
+	TaskStatus *BuildEvent_TaskStatus `protobuf:"bytes,2,opt,name=task_status,json=taskStatus,oneof"`
+}
+
+type buildEvent_Finished_ struct {
+	Finished *BuildEvent_Finished `protobuf:"bytes,3,opt,name=finished,oneof"`
+}
+
+func (*buildEvent_Started_) isBuildEvent_Event() {}
+
+func (*buildEvent_TaskStatus_) isBuildEvent_Event() {}
+
+func (*buildEvent_Finished_) isBuildEvent_Event() {}
+
+type CancelBuildRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_BuildId     *string                `protobuf:"bytes,1,opt,name=build_id,json=buildId"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *CancelBuildRequest) Reset() {
+	*x = CancelBuildRequest{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelBuildRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelBuildRequest) ProtoMessage() {}
+
+func (x *CancelBuildRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CancelBuildRequest) GetBuildId() string {
+	if x != nil {
+		if x.xxx_hidden_BuildId != nil {
+			return *x.xxx_hidden_BuildId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *CancelBuildRequest) SetBuildId(v string) {
+	x.xxx_hidden_BuildId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *CancelBuildRequest) HasBuildId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *CancelBuildRequest) ClearBuildId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_BuildId = nil
+}
+
+type CancelBuildRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	BuildId *string
+}
+
+func (b0 CancelBuildRequest_builder) Build() *CancelBuildRequest {
+	m0 := &CancelBuildRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.BuildId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_BuildId = b.BuildId
+	}
+	return m0
+}
+
+type CancelBuildResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Canceled    bool                   `protobuf:"varint,1,opt,name=canceled"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *CancelBuildResponse) Reset() {
+	*x = CancelBuildResponse{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelBuildResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelBuildResponse) ProtoMessage() {}
+
+func (x *CancelBuildResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CancelBuildResponse) GetCanceled() bool {
+	if x != nil {
+		return x.xxx_hidden_Canceled
+	}
+	return false
+}
+
+func (x *CancelBuildResponse) SetCanceled(v bool) {
+	x.xxx_hidden_Canceled = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *CancelBuildResponse) HasCanceled() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *CancelBuildResponse) ClearCanceled() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Canceled = false
+}
+
+type CancelBuildResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// False if the build wasn't running.
+	Canceled *bool
+}
+
+func (b0 CancelBuildResponse_builder) Build() *CancelBuildResponse {
+	m0 := &CancelBuildResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Canceled != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Canceled = *b.Canceled
+	}
+	return m0
+}
+
+// Sent by an executor to access the files of a session with a remote workspace.
+type WorkspaceCall struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id          int64                  `protobuf:"varint,1,opt,name=id"`
+	xxx_hidden_Call        isWorkspaceCall_Call   `protobuf_oneof:"call"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *WorkspaceCall) Reset() {
+	*x = WorkspaceCall{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkspaceCall) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceCall) ProtoMessage() {}
+
+func (x *WorkspaceCall) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *WorkspaceCall) GetId() int64 {
+	if x != nil {
+		return x.xxx_hidden_Id
+	}
+	return 0
+}
+
+func (x *WorkspaceCall) GetAck() *WorkspaceCall_Ack {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Call.(*workspaceCall_Ack_); ok {
+			return x.Ack
+		}
+	}
+	return nil
+}
+
+func (x *WorkspaceCall) GetStat() *WorkspaceCall_Stat {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Call.(*workspaceCall_Stat_); ok {
+			return x.Stat
+		}
+	}
+	return nil
+}
+
+func (x *WorkspaceCall) GetReadDir() *WorkspaceCall_ReadDir {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Call.(*workspaceCall_ReadDir_); ok {
+			return x.ReadDir
+		}
+	}
+	return nil
+}
+
+func (x *WorkspaceCall) GetReadFile() *WorkspaceCall_ReadFile {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Call.(*workspaceCall_ReadFile_); ok {
+			return x.ReadFile
+		}
+	}
+	return nil
+}
+
+func (x *WorkspaceCall) GetWriteFile() *WorkspaceCall_WriteFile {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Call.(*workspaceCall_WriteFile_); ok {
+			return x.WriteFile
+		}
+	}
+	return nil
+}
+
+func (x *WorkspaceCall) GetMkdir() *WorkspaceCall_Mkdir {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Call.(*workspaceCall_Mkdir_); ok {
+			return x.Mkdir
+		}
+	}
+	return nil
+}
+
+func (x *WorkspaceCall) GetRemove() *WorkspaceCall_Remove {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Call.(*workspaceCall_Remove_); ok {
+			return x.Remove
+		}
+	}
+	return nil
+}
+
+func (x *WorkspaceCall) GetRename() *WorkspaceCall_Rename {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Call.(*workspaceCall_Rename_); ok {
+			return x.Rename
+		}
+	}
+	return nil
+}
+
+func (x *WorkspaceCall) SetId(v int64) {
+	x.xxx_hidden_Id = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *WorkspaceCall) SetAck(v *WorkspaceCall_Ack) {
+	if v == nil {
+		x.xxx_hidden_Call = nil
+		return
+	}
+	x.xxx_hidden_Call = &workspaceCall_Ack_{v}
+}
+
+func (x *WorkspaceCall) SetStat(v *WorkspaceCall_Stat) {
+	if v == nil {
+		x.xxx_hidden_Call = nil
+		return
+	}
+	x.xxx_hidden_Call = &workspaceCall_Stat_{v}
+}
+
+func (x *WorkspaceCall) SetReadDir(v *WorkspaceCall_ReadDir) {
+	if v == nil {
+		x.xxx_hidden_Call = nil
+		return
+	}
+	x.xxx_hidden_Call = &workspaceCall_ReadDir_{v}
+}
+
+func (x *WorkspaceCall) SetReadFile(v *WorkspaceCall_ReadFile) {
+	if v == nil {
+		x.xxx_hidden_Call = nil
+		return
+	}
+	x.xxx_hidden_Call = &workspaceCall_ReadFile_{v}
+}
+
+func (x *WorkspaceCall) SetWriteFile(v *WorkspaceCall_WriteFile) {
+	if v == nil {
+		x.xxx_hidden_Call = nil
+		return
+	}
+	x.xxx_hidden_Call = &workspaceCall_WriteFile_{v}
+}
+
+func (x *WorkspaceCall) SetMkdir(v *WorkspaceCall_Mkdir) {
+	if v == nil {
+		x.xxx_hidden_Call = nil
+		return
+	}
+	x.xxx_hidden_Call = &workspaceCall_Mkdir_{v}
+}
+
+func (x *WorkspaceCall) SetRemove(v *WorkspaceCall_Remove) {
+	if v == nil {
+		x.xxx_hidden_Call = nil
+		return
+	}
+	x.xxx_hidden_Call = &workspaceCall_Remove_{v}
+}
+
+func (x *WorkspaceCall) SetRename(v *WorkspaceCall_Rename) {
+	if v == nil {
+		x.xxx_hidden_Call = nil
+		return
+	}
+	x.xxx_hidden_Call = &workspaceCall_Rename_{v}
+}
+
+func (x *WorkspaceCall) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *WorkspaceCall) HasCall() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Call != nil
+}
+
+func (x *WorkspaceCall) HasAck() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Call.(*workspaceCall_Ack_)
+	return ok
+}
+
+func (x *WorkspaceCall) HasStat() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Call.(*workspaceCall_Stat_)
+	return ok
+}
+
+func (x *WorkspaceCall) HasReadDir() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Call.(*workspaceCall_ReadDir_)
+	return ok
+}
+
+func (x *WorkspaceCall) HasReadFile() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Call.(*workspaceCall_ReadFile_)
+	return ok
+}
+
+func (x *WorkspaceCall) HasWriteFile() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Call.(*workspaceCall_WriteFile_)
+	return ok
+}
+
+func (x *WorkspaceCall) HasMkdir() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Call.(*workspaceCall_Mkdir_)
+	return ok
+}
+
+func (x *WorkspaceCall) HasRemove() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Call.(*workspaceCall_Remove_)
+	return ok
+}
+
+func (x *WorkspaceCall) HasRename() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Call.(*workspaceCall_Rename_)
+	return ok
+}
+
+func (x *WorkspaceCall) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = 0
+}
+
+func (x *WorkspaceCall) ClearCall() {
+	x.xxx_hidden_Call = nil
+}
+
+func (x *WorkspaceCall) ClearAck() {
+	if _, ok := x.xxx_hidden_Call.(*workspaceCall_Ack_); ok {
+		x.xxx_hidden_Call = nil
+	}
+}
+
+func (x *WorkspaceCall) ClearStat() {
+	if _, ok := x.xxx_hidden_Call.(*workspaceCall_Stat_); ok {
+		x.xxx_hidden_Call = nil
+	}
+}
+
+func (x *WorkspaceCall) ClearReadDir() {
+	if _, ok := x.xxx_hidden_Call.(*workspaceCall_ReadDir_); ok {
+		x.xxx_hidden_Call = nil
+	}
+}
+
+func (x *WorkspaceCall) ClearReadFile() {
+	if _, ok := x.xxx_hidden_Call.(*workspaceCall_ReadFile_); ok {
+		x.xxx_hidden_Call = nil
+	}
+}
+
+func (x *WorkspaceCall) ClearWriteFile() {
+	if _, ok := x.xxx_hidden_Call.(*workspaceCall_WriteFile_); ok {
+		x.xxx_hidden_Call = nil
+	}
+}
+
+func (x *WorkspaceCall) ClearMkdir() {
+	if _, ok := x.xxx_hidden_Call.(*workspaceCall_Mkdir_); ok {
+		x.xxx_hidden_Call = nil
+	}
+}
+
+func (x *WorkspaceCall) ClearRemove() {
+	if _, ok := x.xxx_hidden_Call.(*workspaceCall_Remove_); ok {
+		x.xxx_hidden_Call = nil
+	}
+}
+
+func (x *WorkspaceCall) ClearRename() {
+	if _, ok := x.xxx_hidden_Call.(*workspaceCall_Rename_); ok {
+		x.xxx_hidden_Call = nil
+	}
+}
+
+const WorkspaceCall_Call_not_set_case case_WorkspaceCall_Call = 0
+const WorkspaceCall_Ack_case case_WorkspaceCall_Call = 2
+const WorkspaceCall_Stat_case case_WorkspaceCall_Call = 3
+const WorkspaceCall_ReadDir_case case_WorkspaceCall_Call = 4
+const WorkspaceCall_ReadFile_case case_WorkspaceCall_Call = 5
+const WorkspaceCall_WriteFile_case case_WorkspaceCall_Call = 6
+const WorkspaceCall_Mkdir_case case_WorkspaceCall_Call = 7
+const WorkspaceCall_Remove_case case_WorkspaceCall_Call = 8
+const WorkspaceCall_Rename_case case_WorkspaceCall_Call = 9
+
+func (x *WorkspaceCall) WhichCall() case_WorkspaceCall_Call {
+	if x == nil {
+		return WorkspaceCall_Call_not_set_case
+	}
+	switch x.xxx_hidden_Call.(type) {
+	case *workspaceCall_Ack_:
+		return WorkspaceCall_Ack_case
+	case *workspaceCall_Stat_:
+		return WorkspaceCall_Stat_case
+	case *workspaceCall_ReadDir_:
+		return WorkspaceCall_ReadDir_case
+	case *workspaceCall_ReadFile_:
+		return WorkspaceCall_ReadFile_case
+	case *workspaceCall_WriteFile_:
+		return WorkspaceCall_WriteFile_case
+	case *workspaceCall_Mkdir_:
+		return WorkspaceCall_Mkdir_case
+	case *workspaceCall_Remove_:
+		return WorkspaceCall_Remove_case
+	case *workspaceCall_Rename_:
+		return WorkspaceCall_Rename_case
+	default:
+		return WorkspaceCall_Call_not_set_case
+	}
+}
+
+type WorkspaceCall_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Identifies the replies to the call.
+	Id *int64
+	// Fields of oneof xxx_hidden_Call:
+	Ack       *WorkspaceCall_Ack
+	Stat      *WorkspaceCall_Stat
+	ReadDir   *WorkspaceCall_ReadDir
+	ReadFile  *WorkspaceCall_ReadFile
+	WriteFile *WorkspaceCall_WriteFile
+	Mkdir     *WorkspaceCall_Mkdir
+	Remove    *WorkspaceCall_Remove
+	Rename    *WorkspaceCall_Rename
+	// -- end of xxx_hidden_Call
+}
+
+func (b0 WorkspaceCall_builder) Build() *WorkspaceCall {
+	m0 := &WorkspaceCall{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Id = *b.Id
+	}
+	if b.Ack != nil {
+		x.xxx_hidden_Call = &workspaceCall_Ack_{b.Ack}
+	}
+	if b.Stat != nil {
+		x.xxx_hidden_Call = &workspaceCall_Stat_{b.Stat}
+	}
+	if b.ReadDir != nil {
+		x.xxx_hidden_Call = &workspaceCall_ReadDir_{b.ReadDir}
+	}
+	if b.ReadFile != nil {
+		x.xxx_hidden_Call = &workspaceCall_ReadFile_{b.ReadFile}
+	}
+	if b.WriteFile != nil {
+		x.xxx_hidden_Call = &workspaceCall_WriteFile_{b.WriteFile}
+	}
+	if b.Mkdir != nil {
+		x.xxx_hidden_Call = &workspaceCall_Mkdir_{b.Mkdir}
+	}
+	if b.Remove != nil {
+		x.xxx_hidden_Call = &workspaceCall_Remove_{b.Remove}
+	}
+	if b.Rename != nil {
+		x.xxx_hidden_Call = &workspaceCall_Rename_{b.Rename}
+	}
+	return m0
+}
+
+type case_WorkspaceCall_Call protoreflect.FieldNumber
+
+func (x case_WorkspaceCall_Call) String() string {
+	md := file_bonk_v0_bonk_proto_msgTypes[21].Descriptor()
+	if x == 0 {
+		return "not set"
+	}
+	return protoimpl.X.MessageFieldStringOf(md, protoreflect.FieldNumber(x))
+}
+
+type isWorkspaceCall_Call interface {
+	isWorkspaceCall_Call()
+}
+
+type workspaceCall_Ack_ struct {
+	Ack *WorkspaceCall_Ack `protobuf:"bytes,2,opt,name=ack,oneof"`
+}
+
+type workspaceCall_Stat_ struct {
+	Stat *WorkspaceCall_Stat `protobuf:"bytes,3,opt,name=stat,oneof"`
+}
+
+type workspaceCall_ReadDir_ struct {
+	ReadDir *WorkspaceCall_ReadDir `protobuf:"bytes,4,opt,name=read_dir,json=readDir,oneof"`
+}
+
+type workspaceCall_ReadFile_ struct {
+	ReadFile *WorkspaceCall_ReadFile `protobuf:"bytes,5,opt,name=read_file,json=readFile,oneof"`
+}
+
+type workspaceCall_WriteFile_ struct {
+	WriteFile *WorkspaceCall_WriteFile `protobuf:"bytes,6,opt,name=write_file,json=writeFile,oneof"`
+}
+
+type workspaceCall_Mkdir_ struct {
+	Mkdir *WorkspaceCall_Mkdir `protobuf:"bytes,7,opt,name=mkdir,oneof"`
+}
+
+type workspaceCall_Remove_ struct {
+	Remove *WorkspaceCall_Remove `protobuf:"bytes,8,opt,name=remove,oneof"`
+}
+
+type workspaceCall_Rename_ struct {
+	Rename *WorkspaceCall_Rename `protobuf:"bytes,9,opt,name=rename,oneof"`
+}
+
+func (*workspaceCall_Ack_) isWorkspaceCall_Call() {}
+
+func (*workspaceCall_Stat_) isWorkspaceCall_Call() {}
+
+func (*workspaceCall_ReadDir_) isWorkspaceCall_Call() {}
+
+func (*workspaceCall_ReadFile_) isWorkspaceCall_Call() {}
+
+func (*workspaceCall_WriteFile_) isWorkspaceCall_Call() {}
+
+func (*workspaceCall_Mkdir_) isWorkspaceCall_Call() {}
+
+func (*workspaceCall_Remove_) isWorkspaceCall_Call() {}
+
+func (*workspaceCall_Rename_) isWorkspaceCall_Call() {}
+
+// Sent by the client serving a remote workspace, in reply to WorkspaceCalls.
+type WorkspaceReply struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id          int64                  `protobuf:"varint,1,opt,name=id"`
+	xxx_hidden_Reply       isWorkspaceReply_Reply `protobuf_oneof:"reply"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *WorkspaceReply) Reset() {
+	*x = WorkspaceReply{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkspaceReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceReply) ProtoMessage() {}
+
+func (x *WorkspaceReply) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *WorkspaceReply) GetId() int64 {
+	if x != nil {
+		return x.xxx_hidden_Id
+	}
+	return 0
+}
+
+func (x *WorkspaceReply) GetAttach() *WorkspaceReply_Attach {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Reply.(*workspaceReply_Attach_); ok {
+			return x.Attach
+		}
+	}
+	return nil
+}
+
+func (x *WorkspaceReply) GetInfo() *WorkspaceReply_FileInfo {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Reply.(*workspaceReply_Info); ok {
+			return x.Info
+		}
+	}
+	return nil
+}
+
+func (x *WorkspaceReply) GetEntries() *WorkspaceReply_DirEntries {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Reply.(*workspaceReply_Entries); ok {
+			return x.Entries
+		}
+	}
+	return nil
+}
+
+func (x *WorkspaceReply) GetContent() *WorkspaceReply_Content {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Reply.(*workspaceReply_Content_); ok {
+			return x.Content
+		}
+	}
+	return nil
+}
+
+func (x *WorkspaceReply) GetDone() *WorkspaceReply_Done {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Reply.(*workspaceReply_Done_); ok {
+			return x.Done
+		}
+	}
+	return nil
+}
+
+func (x *WorkspaceReply) GetError() *WorkspaceReply_Error {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Reply.(*workspaceReply_Error_); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+func (x *WorkspaceReply) SetId(v int64) {
+	x.xxx_hidden_Id = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *WorkspaceReply) SetAttach(v *WorkspaceReply_Attach) {
+	if v == nil {
+		x.xxx_hidden_Reply = nil
+		return
+	}
+	x.xxx_hidden_Reply = &workspaceReply_Attach_{v}
+}
+
+func (x *WorkspaceReply) SetInfo(v *WorkspaceReply_FileInfo) {
+	if v == nil {
+		x.xxx_hidden_Reply = nil
+		return
+	}
+	x.xxx_hidden_Reply = &workspaceReply_Info{v}
+}
+
+func (x *WorkspaceReply) SetEntries(v *WorkspaceReply_DirEntries) {
+	if v == nil {
+		x.xxx_hidden_Reply = nil
+		return
+	}
+	x.xxx_hidden_Reply = &workspaceReply_Entries{v}
+}
+
+func (x *WorkspaceReply) SetContent(v *WorkspaceReply_Content) {
+	if v == nil {
+		x.xxx_hidden_Reply = nil
+		return
+	}
+	x.xxx_hidden_Reply = &workspaceReply_Content_{v}
+}
+
+func (x *WorkspaceReply) SetDone(v *WorkspaceReply_Done) {
+	if v == nil {
+		x.xxx_hidden_Reply = nil
+		return
+	}
+	x.xxx_hidden_Reply = &workspaceReply_Done_{v}
+}
+
+func (x *WorkspaceReply) SetError(v *WorkspaceReply_Error) {
+	if v == nil {
+		x.xxx_hidden_Reply = nil
+		return
+	}
+	x.xxx_hidden_Reply = &workspaceReply_Error_{v}
+}
+
+func (x *WorkspaceReply) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *WorkspaceReply) HasReply() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Reply != nil
+}
+
+func (x *WorkspaceReply) HasAttach() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Reply.(*workspaceReply_Attach_)
+	return ok
+}
+
+func (x *WorkspaceReply) HasInfo() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Reply.(*workspaceReply_Info)
+	return ok
+}
+
+func (x *WorkspaceReply) HasEntries() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Reply.(*workspaceReply_Entries)
+	return ok
+}
+
+func (x *WorkspaceReply) HasContent() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Reply.(*workspaceReply_Content_)
+	return ok
+}
+
+func (x *WorkspaceReply) HasDone() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Reply.(*workspaceReply_Done_)
+	return ok
+}
+
+func (x *WorkspaceReply) HasError() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Reply.(*workspaceReply_Error_)
+	return ok
+}
+
+func (x *WorkspaceReply) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = 0
+}
+
+func (x *WorkspaceReply) ClearReply() {
+	x.xxx_hidden_Reply = nil
+}
+
+func (x *WorkspaceReply) ClearAttach() {
+	if _, ok := x.xxx_hidden_Reply.(*workspaceReply_Attach_); ok {
+		x.xxx_hidden_Reply = nil
+	}
+}
+
+func (x *WorkspaceReply) ClearInfo() {
+	if _, ok := x.xxx_hidden_Reply.(*workspaceReply_Info); ok {
+		x.xxx_hidden_Reply = nil
+	}
+}
+
+func (x *WorkspaceReply) ClearEntries() {
+	if _, ok := x.xxx_hidden_Reply.(*workspaceReply_Entries); ok {
+		x.xxx_hidden_Reply = nil
+	}
+}
+
+func (x *WorkspaceReply) ClearContent() {
+	if _, ok := x.xxx_hidden_Reply.(*workspaceReply_Content_); ok {
+		x.xxx_hidden_Reply = nil
+	}
+}
+
+func (x *WorkspaceReply) ClearDone() {
+	if _, ok := x.xxx_hidden_Reply.(*workspaceReply_Done_); ok {
+		x.xxx_hidden_Reply = nil
+	}
+}
+
+func (x *WorkspaceReply) ClearError() {
+	if _, ok := x.xxx_hidden_Reply.(*workspaceReply_Error_); ok {
+		x.xxx_hidden_Reply = nil
+	}
+}
+
+const WorkspaceReply_Reply_not_set_case case_WorkspaceReply_Reply = 0
+const WorkspaceReply_Attach_case case_WorkspaceReply_Reply = 2
+const WorkspaceReply_Info_case case_WorkspaceReply_Reply = 3
+const WorkspaceReply_Entries_case case_WorkspaceReply_Reply = 4
+const WorkspaceReply_Content_case case_WorkspaceReply_Reply = 5
+const WorkspaceReply_Done_case case_WorkspaceReply_Reply = 6
+const WorkspaceReply_Error_case case_WorkspaceReply_Reply = 7
+
+func (x *WorkspaceReply) WhichReply() case_WorkspaceReply_Reply {
+	if x == nil {
+		return WorkspaceReply_Reply_not_set_case
+	}
+	switch x.xxx_hidden_Reply.(type) {
+	case *workspaceReply_Attach_:
+		return WorkspaceReply_Attach_case
+	case *workspaceReply_Info:
+		return WorkspaceReply_Info_case
+	case *workspaceReply_Entries:
+		return WorkspaceReply_Entries_case
+	case *workspaceReply_Content_:
+		return WorkspaceReply_Content_case
+	case *workspaceReply_Done_:
+		return WorkspaceReply_Done_case
+	case *workspaceReply_Error_:
+		return WorkspaceReply_Error_case
+	default:
+		return WorkspaceReply_Reply_not_set_case
+	}
+}
+
+type WorkspaceReply_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The id of the call being replied to.
+	Id *int64
+	// Fields of oneof xxx_hidden_Reply:
+	Attach  *WorkspaceReply_Attach
+	Info    *WorkspaceReply_FileInfo
+	Entries *WorkspaceReply_DirEntries
+	Content *WorkspaceReply_Content
+	Done    *WorkspaceReply_Done
+	Error   *WorkspaceReply_Error
+	// -- end of xxx_hidden_Reply
+}
+
+func (b0 WorkspaceReply_builder) Build() *WorkspaceReply {
+	m0 := &WorkspaceReply{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Id = *b.Id
+	}
+	if b.Attach != nil {
+		x.xxx_hidden_Reply = &workspaceReply_Attach_{b.Attach}
+	}
+	if b.Info != nil {
+		x.xxx_hidden_Reply = &workspaceReply_Info{b.Info}
+	}
+	if b.Entries != nil {
+		x.xxx_hidden_Reply = &workspaceReply_Entries{b.Entries}
+	}
+	if b.Content != nil {
+		x.xxx_hidden_Reply = &workspaceReply_Content_{b.Content}
+	}
+	if b.Done != nil {
+		x.xxx_hidden_Reply = &workspaceReply_Done_{b.Done}
+	}
+	if b.Error != nil {
+		x.xxx_hidden_Reply = &workspaceReply_Error_{b.Error}
+	}
+	return m0
+}
+
+type case_WorkspaceReply_Reply protoreflect.FieldNumber
+
+func (x case_WorkspaceReply_Reply) String() string {
+	md := file_bonk_v0_bonk_proto_msgTypes[22].Descriptor()
+	if x == 0 {
+		return "not set"
+	}
+	return protoimpl.X.MessageFieldStringOf(md, protoreflect.FieldNumber(x))
+}
+
+type isWorkspaceReply_Reply interface {
+	isWorkspaceReply_Reply()
+}
+
+type workspaceReply_Attach_ struct {
+	Attach *WorkspaceReply_Attach `protobuf:"bytes,2,opt,name=attach,oneof"`
+}
+
+type workspaceReply_Info struct {
+	Info *WorkspaceReply_FileInfo `protobuf:"bytes,3,opt,name=info,oneof"`
+}
+
+type workspaceReply_Entries struct {
+	Entries *WorkspaceReply_DirEntries `protobuf:"bytes,4,opt,name=entries,oneof"`
+}
+
+type workspaceReply_Content_ struct {
+	Content *WorkspaceReply_Content `protobuf:"bytes,5,opt,name=content,oneof"`
+}
+
+type workspaceReply_Done_ struct {
+	Done *WorkspaceReply_Done `protobuf:"bytes,6,opt,name=done,oneof"`
+}
+
+type workspaceReply_Error_ struct {
+	Error *WorkspaceReply_Error `protobuf:"bytes,7,opt,name=error,oneof"`
+}
+
+func (*workspaceReply_Attach_) isWorkspaceReply_Reply() {}
+
+func (*workspaceReply_Info) isWorkspaceReply_Reply() {}
+
+func (*workspaceReply_Entries) isWorkspaceReply_Reply() {}
+
+func (*workspaceReply_Content_) isWorkspaceReply_Reply() {}
+
+func (*workspaceReply_Done_) isWorkspaceReply_Reply() {}
+
+func (*workspaceReply_Error_) isWorkspaceReply_Reply() {}
+
+type OpenSessionRequest_LogStreamingOptions struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Level       int64                  `protobuf:"varint,1,opt,name=level"`
+	xxx_hidden_AddSource   bool                   `protobuf:"varint,2,opt,name=add_source,json=addSource"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *OpenSessionRequest_LogStreamingOptions) Reset() {
+	*x = OpenSessionRequest_LogStreamingOptions{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpenSessionRequest_LogStreamingOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenSessionRequest_LogStreamingOptions) ProtoMessage() {}
+
+func (x *OpenSessionRequest_LogStreamingOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *OpenSessionRequest_LogStreamingOptions) GetLevel() int64 {
+	if x != nil {
+		return x.xxx_hidden_Level
+	}
+	return 0
+}
+
+func (x *OpenSessionRequest_LogStreamingOptions) GetAddSource() bool {
+	if x != nil {
+		return x.xxx_hidden_AddSource
+	}
+	return false
+}
+
+func (x *OpenSessionRequest_LogStreamingOptions) SetLevel(v int64) {
+	x.xxx_hidden_Level = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *OpenSessionRequest_LogStreamingOptions) SetAddSource(v bool) {
+	x.xxx_hidden_AddSource = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *OpenSessionRequest_LogStreamingOptions) HasLevel() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *OpenSessionRequest_LogStreamingOptions) HasAddSource() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *OpenSessionRequest_LogStreamingOptions) ClearLevel() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Level = 0
+}
+
+func (x *OpenSessionRequest_LogStreamingOptions) ClearAddSource() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_AddSource = false
+}
+
+type OpenSessionRequest_LogStreamingOptions_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Level     *int64
+	AddSource *bool
+}
+
+func (b0 OpenSessionRequest_LogStreamingOptions_builder) Build() *OpenSessionRequest_LogStreamingOptions {
+	m0 := &OpenSessionRequest_LogStreamingOptions{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Level != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Level = *b.Level
+	}
+	if b.AddSource != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_AddSource = *b.AddSource
+	}
+	return m0
+}
+
+type OpenSessionRequest_WorkspaceDescriptionLocal struct {
+	state                   protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_AbsolutePath *string                `protobuf:"bytes,1,opt,name=absolute_path,json=absolutePath"`
+	XXX_raceDetectHookData  protoimpl.RaceDetectHookData
+	XXX_presence            [1]uint32
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *OpenSessionRequest_WorkspaceDescriptionLocal) Reset() {
+	*x = OpenSessionRequest_WorkspaceDescriptionLocal{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpenSessionRequest_WorkspaceDescriptionLocal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenSessionRequest_WorkspaceDescriptionLocal) ProtoMessage() {}
+
+func (x *OpenSessionRequest_WorkspaceDescriptionLocal) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *OpenSessionRequest_WorkspaceDescriptionLocal) GetAbsolutePath() string {
+	if x != nil {
+		if x.xxx_hidden_AbsolutePath != nil {
+			return *x.xxx_hidden_AbsolutePath
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *OpenSessionRequest_WorkspaceDescriptionLocal) SetAbsolutePath(v string) {
+	x.xxx_hidden_AbsolutePath = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *OpenSessionRequest_WorkspaceDescriptionLocal) HasAbsolutePath() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *OpenSessionRequest_WorkspaceDescriptionLocal) ClearAbsolutePath() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_AbsolutePath = nil
+}
+
+type OpenSessionRequest_WorkspaceDescriptionLocal_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	AbsolutePath *string
+}
+
+func (b0 OpenSessionRequest_WorkspaceDescriptionLocal_builder) Build() *OpenSessionRequest_WorkspaceDescriptionLocal {
+	m0 := &OpenSessionRequest_WorkspaceDescriptionLocal{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.AbsolutePath != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_AbsolutePath = b.AbsolutePath
+	}
+	return m0
+}
+
+// The workspace is served by the client over a Workspace stream, which is attached before the session is opened.
+type OpenSessionRequest_WorkspaceDescriptionRemote struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OpenSessionRequest_WorkspaceDescriptionRemote) Reset() {
+	*x = OpenSessionRequest_WorkspaceDescriptionRemote{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpenSessionRequest_WorkspaceDescriptionRemote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenSessionRequest_WorkspaceDescriptionRemote) ProtoMessage() {}
+
+func (x *OpenSessionRequest_WorkspaceDescriptionRemote) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type OpenSessionRequest_WorkspaceDescriptionRemote_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 OpenSessionRequest_WorkspaceDescriptionRemote_builder) Build() *OpenSessionRequest_WorkspaceDescriptionRemote {
+	m0 := &OpenSessionRequest_WorkspaceDescriptionRemote{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
+}
+
+type OpenSessionRequest_WorkspaceDescriptionTest struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OpenSessionRequest_WorkspaceDescriptionTest) Reset() {
+	*x = OpenSessionRequest_WorkspaceDescriptionTest{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpenSessionRequest_WorkspaceDescriptionTest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenSessionRequest_WorkspaceDescriptionTest) ProtoMessage() {}
+
+func (x *OpenSessionRequest_WorkspaceDescriptionTest) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type OpenSessionRequest_WorkspaceDescriptionTest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 OpenSessionRequest_WorkspaceDescriptionTest_builder) Build() *OpenSessionRequest_WorkspaceDescriptionTest {
 	m0 := &OpenSessionRequest_WorkspaceDescriptionTest{}
 	b, x := &b0, m0
 	_, _ = b, x
 	return m0
 }
 
-type OpenSessionResponse_Ack struct {
-	state         protoimpl.MessageState `protogen:"opaque.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type OpenSessionResponse_Ack struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OpenSessionResponse_Ack) Reset() {
+	*x = OpenSessionResponse_Ack{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpenSessionResponse_Ack) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenSessionResponse_Ack) ProtoMessage() {}
+
+func (x *OpenSessionResponse_Ack) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type OpenSessionResponse_Ack_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 OpenSessionResponse_Ack_builder) Build() *OpenSessionResponse_Ack {
+	m0 := &OpenSessionResponse_Ack{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
+}
+
+// This is meant to mirror [slog.Record](https://pkg.go.dev/log/slog#Record)
+type OpenSessionResponse_LogRecord struct {
+	state                  protoimpl.MessageState     `protogen:"opaque.v1"`
+	xxx_hidden_Time        *timestamppb.Timestamp     `protobuf:"bytes,1,opt,name=time"`
+	xxx_hidden_Message     *string                    `protobuf:"bytes,2,opt,name=message"`
+	xxx_hidden_Level       int64                      `protobuf:"varint,3,opt,name=level"`
+	xxx_hidden_Attrs       map[string]*structpb.Value `protobuf:"bytes,4,rep,name=attrs" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *OpenSessionResponse_LogRecord) Reset() {
+	*x = OpenSessionResponse_LogRecord{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpenSessionResponse_LogRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenSessionResponse_LogRecord) ProtoMessage() {}
+
+func (x *OpenSessionResponse_LogRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *OpenSessionResponse_LogRecord) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_Time
+	}
+	return nil
+}
+
+func (x *OpenSessionResponse_LogRecord) GetMessage() string {
+	if x != nil {
+		if x.xxx_hidden_Message != nil {
+			return *x.xxx_hidden_Message
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *OpenSessionResponse_LogRecord) GetLevel() int64 {
+	if x != nil {
+		return x.xxx_hidden_Level
+	}
+	return 0
+}
+
+func (x *OpenSessionResponse_LogRecord) GetAttrs() map[string]*structpb.Value {
+	if x != nil {
+		return x.xxx_hidden_Attrs
+	}
+	return nil
+}
+
+func (x *OpenSessionResponse_LogRecord) SetTime(v *timestamppb.Timestamp) {
+	x.xxx_hidden_Time = v
+}
+
+func (x *OpenSessionResponse_LogRecord) SetMessage(v string) {
+	x.xxx_hidden_Message = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
+}
+
+func (x *OpenSessionResponse_LogRecord) SetLevel(v int64) {
+	x.xxx_hidden_Level = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+}
+
+func (x *OpenSessionResponse_LogRecord) SetAttrs(v map[string]*structpb.Value) {
+	x.xxx_hidden_Attrs = v
+}
+
+func (x *OpenSessionResponse_LogRecord) HasTime() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Time != nil
+}
+
+func (x *OpenSessionResponse_LogRecord) HasMessage() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *OpenSessionResponse_LogRecord) HasLevel() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *OpenSessionResponse_LogRecord) ClearTime() {
+	x.xxx_hidden_Time = nil
+}
+
+func (x *OpenSessionResponse_LogRecord) ClearMessage() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Message = nil
+}
+
+func (x *OpenSessionResponse_LogRecord) ClearLevel() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Level = 0
+}
+
+type OpenSessionResponse_LogRecord_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Time    *timestamppb.Timestamp
+	Message *string
+	Level   *int64
+	Attrs   map[string]*structpb.Value
+}
+
+func (b0 OpenSessionResponse_LogRecord_builder) Build() *OpenSessionResponse_LogRecord {
+	m0 := &OpenSessionResponse_LogRecord{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Time = b.Time
+	if b.Message != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
+		x.xxx_hidden_Message = b.Message
+	}
+	if b.Level != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
+		x.xxx_hidden_Level = *b.Level
+	}
+	x.xxx_hidden_Attrs = b.Attrs
+	return m0
+}
+
+type DescribeResponse_Executor struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Name        *string                `protobuf:"bytes,1,opt,name=name"`
+	xxx_hidden_CueSchema   *string                `protobuf:"bytes,2,opt,name=cue_schema,json=cueSchema"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *DescribeResponse_Executor) Reset() {
+	*x = DescribeResponse_Executor{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DescribeResponse_Executor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DescribeResponse_Executor) ProtoMessage() {}
+
+func (x *DescribeResponse_Executor) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *DescribeResponse_Executor) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *DescribeResponse_Executor) GetCueSchema() string {
+	if x != nil {
+		if x.xxx_hidden_CueSchema != nil {
+			return *x.xxx_hidden_CueSchema
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *DescribeResponse_Executor) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *DescribeResponse_Executor) SetCueSchema(v string) {
+	x.xxx_hidden_CueSchema = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *DescribeResponse_Executor) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *DescribeResponse_Executor) HasCueSchema() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *DescribeResponse_Executor) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Name = nil
+}
+
+func (x *DescribeResponse_Executor) ClearCueSchema() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_CueSchema = nil
+}
+
+type DescribeResponse_Executor_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The full path of the executor, as used in a task's executor field.
+	Name *string
+	// The CUE schema of the executor's arguments, if known.
+	CueSchema *string
+}
+
+func (b0 DescribeResponse_Executor_builder) Build() *DescribeResponse_Executor {
+	m0 := &DescribeResponse_Executor{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Name = b.Name
+	}
+	if b.CueSchema != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_CueSchema = b.CueSchema
+	}
+	return m0
+}
+
+type ExecutionError_Position struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Filename    *string                `protobuf:"bytes,1,opt,name=filename"`
+	xxx_hidden_Line        int64                  `protobuf:"varint,2,opt,name=line"`
+	xxx_hidden_Column      int64                  `protobuf:"varint,3,opt,name=column"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ExecutionError_Position) Reset() {
+	*x = ExecutionError_Position{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecutionError_Position) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecutionError_Position) ProtoMessage() {}
+
+func (x *ExecutionError_Position) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ExecutionError_Position) GetFilename() string {
+	if x != nil {
+		if x.xxx_hidden_Filename != nil {
+			return *x.xxx_hidden_Filename
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ExecutionError_Position) GetLine() int64 {
+	if x != nil {
+		return x.xxx_hidden_Line
+	}
+	return 0
+}
+
+func (x *ExecutionError_Position) GetColumn() int64 {
+	if x != nil {
+		return x.xxx_hidden_Column
+	}
+	return 0
+}
+
+func (x *ExecutionError_Position) SetFilename(v string) {
+	x.xxx_hidden_Filename = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *ExecutionError_Position) SetLine(v int64) {
+	x.xxx_hidden_Line = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *ExecutionError_Position) SetColumn(v int64) {
+	x.xxx_hidden_Column = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *ExecutionError_Position) HasFilename() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ExecutionError_Position) HasLine() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *ExecutionError_Position) HasColumn() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *ExecutionError_Position) ClearFilename() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Filename = nil
+}
+
+func (x *ExecutionError_Position) ClearLine() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Line = 0
+}
+
+func (x *ExecutionError_Position) ClearColumn() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Column = 0
+}
+
+type ExecutionError_Position_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Filename *string
+	Line     *int64
+	Column   *int64
+}
+
+func (b0 ExecutionError_Position_builder) Build() *ExecutionError_Position {
+	m0 := &ExecutionError_Position{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Filename != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_Filename = b.Filename
+	}
+	if b.Line != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_Line = *b.Line
+	}
+	if b.Column != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_Column = *b.Column
+	}
+	return m0
+}
+
+type SubmitBuildRequest_Session struct {
+	state                   protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id           *string                `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_AbsolutePath *string                `protobuf:"bytes,2,opt,name=absolute_path,json=absolutePath"`
+	xxx_hidden_Tasks        *[]*BuildTask          `protobuf:"bytes,3,rep,name=tasks"`
+	XXX_raceDetectHookData  protoimpl.RaceDetectHookData
+	XXX_presence            [1]uint32
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *SubmitBuildRequest_Session) Reset() {
+	*x = SubmitBuildRequest_Session{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitBuildRequest_Session) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitBuildRequest_Session) ProtoMessage() {}
+
+func (x *SubmitBuildRequest_Session) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *SubmitBuildRequest_Session) GetId() string {
+	if x != nil {
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SubmitBuildRequest_Session) GetAbsolutePath() string {
+	if x != nil {
+		if x.xxx_hidden_AbsolutePath != nil {
+			return *x.xxx_hidden_AbsolutePath
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SubmitBuildRequest_Session) GetTasks() []*BuildTask {
+	if x != nil {
+		if x.xxx_hidden_Tasks != nil {
+			return *x.xxx_hidden_Tasks
+		}
+	}
+	return nil
+}
+
+func (x *SubmitBuildRequest_Session) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *SubmitBuildRequest_Session) SetAbsolutePath(v string) {
+	x.xxx_hidden_AbsolutePath = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *SubmitBuildRequest_Session) SetTasks(v []*BuildTask) {
+	x.xxx_hidden_Tasks = &v
+}
+
+func (x *SubmitBuildRequest_Session) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *SubmitBuildRequest_Session) HasAbsolutePath() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *SubmitBuildRequest_Session) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = nil
+}
+
+func (x *SubmitBuildRequest_Session) ClearAbsolutePath() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_AbsolutePath = nil
+}
+
+type SubmitBuildRequest_Session_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id *string
+	// The workspace is shared with the server, so sessions are always local.
+	AbsolutePath *string
+	Tasks        []*BuildTask
+}
+
+func (b0 SubmitBuildRequest_Session_builder) Build() *SubmitBuildRequest_Session {
+	m0 := &SubmitBuildRequest_Session{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_Id = b.Id
+	}
+	if b.AbsolutePath != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_AbsolutePath = b.AbsolutePath
+	}
+	x.xxx_hidden_Tasks = &b.Tasks
+	return m0
+}
+
+type BuildEvent_Started struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_BuildId     *string                `protobuf:"bytes,1,opt,name=build_id,json=buildId"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *BuildEvent_Started) Reset() {
+	*x = BuildEvent_Started{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuildEvent_Started) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildEvent_Started) ProtoMessage() {}
+
+func (x *BuildEvent_Started) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *BuildEvent_Started) GetBuildId() string {
+	if x != nil {
+		if x.xxx_hidden_BuildId != nil {
+			return *x.xxx_hidden_BuildId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *BuildEvent_Started) SetBuildId(v string) {
+	x.xxx_hidden_BuildId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *BuildEvent_Started) HasBuildId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *BuildEvent_Started) ClearBuildId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_BuildId = nil
+}
+
+type BuildEvent_Started_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	BuildId *string
+}
+
+func (b0 BuildEvent_Started_builder) Build() *BuildEvent_Started {
+	m0 := &BuildEvent_Started{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.BuildId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_BuildId = b.BuildId
+	}
+	return m0
+}
+
+// This is meant to mirror observable.TaskStatusMsg
+type BuildEvent_TaskStatus struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_SessionId   *string                `protobuf:"bytes,1,opt,name=session_id,json=sessionId"`
+	xxx_hidden_TaskId      *string                `protobuf:"bytes,2,opt,name=task_id,json=taskId"`
+	xxx_hidden_Status      int64                  `protobuf:"varint,3,opt,name=status"`
+	xxx_hidden_Time        *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=time"`
+	xxx_hidden_Executor    *string                `protobuf:"bytes,5,opt,name=executor"`
+	xxx_hidden_Arguments   *structpb.Value        `protobuf:"bytes,6,opt,name=arguments"`
+	xxx_hidden_Outputs     []string               `protobuf:"bytes,7,rep,name=outputs"`
+	xxx_hidden_Error       *ExecutionError        `protobuf:"bytes,8,opt,name=error"`
+	xxx_hidden_Attempt     int64                  `protobuf:"varint,9,opt,name=attempt"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *BuildEvent_TaskStatus) Reset() {
+	*x = BuildEvent_TaskStatus{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuildEvent_TaskStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildEvent_TaskStatus) ProtoMessage() {}
+
+func (x *BuildEvent_TaskStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *BuildEvent_TaskStatus) GetSessionId() string {
+	if x != nil {
+		if x.xxx_hidden_SessionId != nil {
+			return *x.xxx_hidden_SessionId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *BuildEvent_TaskStatus) GetTaskId() string {
+	if x != nil {
+		if x.xxx_hidden_TaskId != nil {
+			return *x.xxx_hidden_TaskId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *BuildEvent_TaskStatus) GetStatus() int64 {
+	if x != nil {
+		return x.xxx_hidden_Status
+	}
+	return 0
+}
+
+func (x *BuildEvent_TaskStatus) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_Time
+	}
+	return nil
+}
+
+func (x *BuildEvent_TaskStatus) GetExecutor() string {
+	if x != nil {
+		if x.xxx_hidden_Executor != nil {
+			return *x.xxx_hidden_Executor
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *BuildEvent_TaskStatus) GetArguments() *structpb.Value {
+	if x != nil {
+		return x.xxx_hidden_Arguments
+	}
+	return nil
+}
+
+func (x *BuildEvent_TaskStatus) GetOutputs() []string {
+	if x != nil {
+		return x.xxx_hidden_Outputs
+	}
+	return nil
+}
+
+func (x *BuildEvent_TaskStatus) GetError() *ExecutionError {
+	if x != nil {
+		return x.xxx_hidden_Error
+	}
+	return nil
+}
+
+func (x *BuildEvent_TaskStatus) GetAttempt() int64 {
+	if x != nil {
+		return x.xxx_hidden_Attempt
+	}
+	return 0
+}
+
+func (x *BuildEvent_TaskStatus) SetSessionId(v string) {
+	x.xxx_hidden_SessionId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 9)
+}
+
+func (x *BuildEvent_TaskStatus) SetTaskId(v string) {
+	x.xxx_hidden_TaskId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 9)
+}
+
+func (x *BuildEvent_TaskStatus) SetStatus(v int64) {
+	x.xxx_hidden_Status = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 9)
+}
+
+func (x *BuildEvent_TaskStatus) SetTime(v *timestamppb.Timestamp) {
+	x.xxx_hidden_Time = v
+}
+
+func (x *BuildEvent_TaskStatus) SetExecutor(v string) {
+	x.xxx_hidden_Executor = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 9)
+}
+
+func (x *BuildEvent_TaskStatus) SetArguments(v *structpb.Value) {
+	x.xxx_hidden_Arguments = v
+}
+
+func (x *BuildEvent_TaskStatus) SetOutputs(v []string) {
+	x.xxx_hidden_Outputs = v
+}
+
+func (x *BuildEvent_TaskStatus) SetError(v *ExecutionError) {
+	x.xxx_hidden_Error = v
+}
+
+func (x *BuildEvent_TaskStatus) SetAttempt(v int64) {
+	x.xxx_hidden_Attempt = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 8, 9)
+}
+
+func (x *BuildEvent_TaskStatus) HasSessionId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *BuildEvent_TaskStatus) HasTaskId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *BuildEvent_TaskStatus) HasStatus() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *BuildEvent_TaskStatus) HasTime() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Time != nil
+}
+
+func (x *BuildEvent_TaskStatus) HasExecutor() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *BuildEvent_TaskStatus) HasArguments() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Arguments != nil
+}
+
+func (x *BuildEvent_TaskStatus) HasError() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Error != nil
+}
+
+func (x *BuildEvent_TaskStatus) HasAttempt() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 8)
+}
+
+func (x *BuildEvent_TaskStatus) ClearSessionId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_SessionId = nil
+}
+
+func (x *BuildEvent_TaskStatus) ClearTaskId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_TaskId = nil
+}
+
+func (x *BuildEvent_TaskStatus) ClearStatus() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Status = 0
+}
+
+func (x *BuildEvent_TaskStatus) ClearTime() {
+	x.xxx_hidden_Time = nil
+}
+
+func (x *BuildEvent_TaskStatus) ClearExecutor() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Executor = nil
+}
+
+func (x *BuildEvent_TaskStatus) ClearArguments() {
+	x.xxx_hidden_Arguments = nil
+}
+
+func (x *BuildEvent_TaskStatus) ClearError() {
+	x.xxx_hidden_Error = nil
+}
+
+func (x *BuildEvent_TaskStatus) ClearAttempt() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 8)
+	x.xxx_hidden_Attempt = 0
+}
+
+type BuildEvent_TaskStatus_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	SessionId *string
+	TaskId    *string
+	Status    *int64
+	Time      *timestamppb.Timestamp
+	Executor  *string
+	Arguments *structpb.Value
+	Outputs   []string
+	Error     *ExecutionError
+	Attempt   *int64
+}
+
+func (b0 BuildEvent_TaskStatus_builder) Build() *BuildEvent_TaskStatus {
+	m0 := &BuildEvent_TaskStatus{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.SessionId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 9)
+		x.xxx_hidden_SessionId = b.SessionId
+	}
+	if b.TaskId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 9)
+		x.xxx_hidden_TaskId = b.TaskId
+	}
+	if b.Status != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 9)
+		x.xxx_hidden_Status = *b.Status
+	}
+	x.xxx_hidden_Time = b.Time
+	if b.Executor != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 9)
+		x.xxx_hidden_Executor = b.Executor
+	}
+	x.xxx_hidden_Arguments = b.Arguments
+	x.xxx_hidden_Outputs = b.Outputs
+	x.xxx_hidden_Error = b.Error
+	if b.Attempt != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 8, 9)
+		x.xxx_hidden_Attempt = *b.Attempt
+	}
+	return m0
+}
+
+type BuildEvent_Finished struct {
+	state            protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Error *ExecutionError        `protobuf:"bytes,1,opt,name=error"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *BuildEvent_Finished) Reset() {
+	*x = BuildEvent_Finished{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuildEvent_Finished) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildEvent_Finished) ProtoMessage() {}
+
+func (x *BuildEvent_Finished) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *BuildEvent_Finished) GetError() *ExecutionError {
+	if x != nil {
+		return x.xxx_hidden_Error
+	}
+	return nil
+}
+
+func (x *BuildEvent_Finished) SetError(v *ExecutionError) {
+	x.xxx_hidden_Error = v
+}
+
+func (x *BuildEvent_Finished) HasError() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Error != nil
+}
+
+func (x *BuildEvent_Finished) ClearError() {
+	x.xxx_hidden_Error = nil
+}
+
+type BuildEvent_Finished_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Unset if the build succeeded.
+	Error *ExecutionError
+}
+
+func (b0 BuildEvent_Finished_builder) Build() *BuildEvent_Finished {
+	m0 := &BuildEvent_Finished{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Error = b.Error
+	return m0
+}
+
+// Sent once the workspace is attached, after which the session may be opened.
+type WorkspaceCall_Ack struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkspaceCall_Ack) Reset() {
+	*x = WorkspaceCall_Ack{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkspaceCall_Ack) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceCall_Ack) ProtoMessage() {}
+
+func (x *WorkspaceCall_Ack) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type WorkspaceCall_Ack_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 WorkspaceCall_Ack_builder) Build() *WorkspaceCall_Ack {
+	m0 := &WorkspaceCall_Ack{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
+}
+
+type WorkspaceCall_Stat struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Root        WorkspaceCall_Root     `protobuf:"varint,1,opt,name=root,enum=bonk.v0.WorkspaceCall_Root"`
+	xxx_hidden_Path        *string                `protobuf:"bytes,2,opt,name=path"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *WorkspaceCall_Stat) Reset() {
+	*x = WorkspaceCall_Stat{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkspaceCall_Stat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceCall_Stat) ProtoMessage() {}
+
+func (x *WorkspaceCall_Stat) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *WorkspaceCall_Stat) GetRoot() WorkspaceCall_Root {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 0) {
+			return x.xxx_hidden_Root
+		}
+	}
+	return WorkspaceCall_ROOT_UNSPECIFIED
+}
+
+func (x *WorkspaceCall_Stat) GetPath() string {
+	if x != nil {
+		if x.xxx_hidden_Path != nil {
+			return *x.xxx_hidden_Path
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *WorkspaceCall_Stat) SetRoot(v WorkspaceCall_Root) {
+	x.xxx_hidden_Root = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *WorkspaceCall_Stat) SetPath(v string) {
+	x.xxx_hidden_Path = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *WorkspaceCall_Stat) HasRoot() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *WorkspaceCall_Stat) HasPath() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *WorkspaceCall_Stat) ClearRoot() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Root = WorkspaceCall_ROOT_UNSPECIFIED
+}
+
+func (x *WorkspaceCall_Stat) ClearPath() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Path = nil
+}
+
+type WorkspaceCall_Stat_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Root *WorkspaceCall_Root
+	Path *string
+}
+
+func (b0 WorkspaceCall_Stat_builder) Build() *WorkspaceCall_Stat {
+	m0 := &WorkspaceCall_Stat{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Root != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Root = *b.Root
+	}
+	if b.Path != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Path = b.Path
+	}
+	return m0
+}
+
+type WorkspaceCall_ReadDir struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Root        WorkspaceCall_Root     `protobuf:"varint,1,opt,name=root,enum=bonk.v0.WorkspaceCall_Root"`
+	xxx_hidden_Path        *string                `protobuf:"bytes,2,opt,name=path"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *WorkspaceCall_ReadDir) Reset() {
+	*x = WorkspaceCall_ReadDir{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkspaceCall_ReadDir) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceCall_ReadDir) ProtoMessage() {}
+
+func (x *WorkspaceCall_ReadDir) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *WorkspaceCall_ReadDir) GetRoot() WorkspaceCall_Root {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 0) {
+			return x.xxx_hidden_Root
+		}
+	}
+	return WorkspaceCall_ROOT_UNSPECIFIED
+}
+
+func (x *WorkspaceCall_ReadDir) GetPath() string {
+	if x != nil {
+		if x.xxx_hidden_Path != nil {
+			return *x.xxx_hidden_Path
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *WorkspaceCall_ReadDir) SetRoot(v WorkspaceCall_Root) {
+	x.xxx_hidden_Root = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *WorkspaceCall_ReadDir) SetPath(v string) {
+	x.xxx_hidden_Path = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *WorkspaceCall_ReadDir) HasRoot() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *WorkspaceCall_ReadDir) HasPath() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *WorkspaceCall_ReadDir) ClearRoot() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Root = WorkspaceCall_ROOT_UNSPECIFIED
+}
+
+func (x *WorkspaceCall_ReadDir) ClearPath() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Path = nil
+}
+
+type WorkspaceCall_ReadDir_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Root *WorkspaceCall_Root
+	Path *string
+}
+
+func (b0 WorkspaceCall_ReadDir_builder) Build() *WorkspaceCall_ReadDir {
+	m0 := &WorkspaceCall_ReadDir{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Root != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Root = *b.Root
+	}
+	if b.Path != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Path = b.Path
+	}
+	return m0
+}
+
+// Replied to with the file's content, split across as many replies as needed.
+type WorkspaceCall_ReadFile struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Root        WorkspaceCall_Root     `protobuf:"varint,1,opt,name=root,enum=bonk.v0.WorkspaceCall_Root"`
+	xxx_hidden_Path        *string                `protobuf:"bytes,2,opt,name=path"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *WorkspaceCall_ReadFile) Reset() {
+	*x = WorkspaceCall_ReadFile{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkspaceCall_ReadFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceCall_ReadFile) ProtoMessage() {}
+
+func (x *WorkspaceCall_ReadFile) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *WorkspaceCall_ReadFile) GetRoot() WorkspaceCall_Root {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 0) {
+			return x.xxx_hidden_Root
+		}
+	}
+	return WorkspaceCall_ROOT_UNSPECIFIED
+}
+
+func (x *WorkspaceCall_ReadFile) GetPath() string {
+	if x != nil {
+		if x.xxx_hidden_Path != nil {
+			return *x.xxx_hidden_Path
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *WorkspaceCall_ReadFile) SetRoot(v WorkspaceCall_Root) {
+	x.xxx_hidden_Root = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *WorkspaceCall_ReadFile) SetPath(v string) {
+	x.xxx_hidden_Path = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *WorkspaceCall_ReadFile) HasRoot() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *WorkspaceCall_ReadFile) HasPath() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *WorkspaceCall_ReadFile) ClearRoot() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Root = WorkspaceCall_ROOT_UNSPECIFIED
+}
+
+func (x *WorkspaceCall_ReadFile) ClearPath() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Path = nil
+}
+
+type WorkspaceCall_ReadFile_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Root *WorkspaceCall_Root
+	Path *string
+}
+
+func (b0 WorkspaceCall_ReadFile_builder) Build() *WorkspaceCall_ReadFile {
+	m0 := &WorkspaceCall_ReadFile{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Root != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Root = *b.Root
+	}
+	if b.Path != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Path = b.Path
+	}
+	return m0
+}
+
+type WorkspaceCall_WriteFile struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Root        WorkspaceCall_Root     `protobuf:"varint,1,opt,name=root,enum=bonk.v0.WorkspaceCall_Root"`
+	xxx_hidden_Path        *string                `protobuf:"bytes,2,opt,name=path"`
+	xxx_hidden_Offset      int64                  `protobuf:"varint,3,opt,name=offset"`
+	xxx_hidden_Data        []byte                 `protobuf:"bytes,4,opt,name=data"`
+	xxx_hidden_Create      bool                   `protobuf:"varint,5,opt,name=create"`
+	xxx_hidden_Truncate    bool                   `protobuf:"varint,6,opt,name=truncate"`
+	xxx_hidden_Mode        uint32                 `protobuf:"varint,7,opt,name=mode"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *WorkspaceCall_WriteFile) Reset() {
+	*x = WorkspaceCall_WriteFile{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkspaceCall_WriteFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceCall_WriteFile) ProtoMessage() {}
+
+func (x *WorkspaceCall_WriteFile) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *WorkspaceCall_WriteFile) GetRoot() WorkspaceCall_Root {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 0) {
+			return x.xxx_hidden_Root
+		}
+	}
+	return WorkspaceCall_ROOT_UNSPECIFIED
+}
+
+func (x *WorkspaceCall_WriteFile) GetPath() string {
+	if x != nil {
+		if x.xxx_hidden_Path != nil {
+			return *x.xxx_hidden_Path
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *WorkspaceCall_WriteFile) GetOffset() int64 {
+	if x != nil {
+		return x.xxx_hidden_Offset
+	}
+	return 0
+}
+
+func (x *WorkspaceCall_WriteFile) GetData() []byte {
+	if x != nil {
+		return x.xxx_hidden_Data
+	}
+	return nil
+}
+
+func (x *WorkspaceCall_WriteFile) GetCreate() bool {
+	if x != nil {
+		return x.xxx_hidden_Create
+	}
+	return false
+}
+
+func (x *WorkspaceCall_WriteFile) GetTruncate() bool {
+	if x != nil {
+		return x.xxx_hidden_Truncate
+	}
+	return false
+}
+
+func (x *WorkspaceCall_WriteFile) GetMode() uint32 {
+	if x != nil {
+		return x.xxx_hidden_Mode
+	}
+	return 0
+}
+
+func (x *WorkspaceCall_WriteFile) SetRoot(v WorkspaceCall_Root) {
+	x.xxx_hidden_Root = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 7)
+}
+
+func (x *WorkspaceCall_WriteFile) SetPath(v string) {
+	x.xxx_hidden_Path = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 7)
+}
+
+func (x *WorkspaceCall_WriteFile) SetOffset(v int64) {
+	x.xxx_hidden_Offset = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 7)
+}
+
+func (x *WorkspaceCall_WriteFile) SetData(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Data = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 7)
+}
+
+func (x *WorkspaceCall_WriteFile) SetCreate(v bool) {
+	x.xxx_hidden_Create = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 7)
+}
+
+func (x *WorkspaceCall_WriteFile) SetTruncate(v bool) {
+	x.xxx_hidden_Truncate = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 7)
+}
+
+func (x *WorkspaceCall_WriteFile) SetMode(v uint32) {
+	x.xxx_hidden_Mode = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 7)
+}
+
+func (x *WorkspaceCall_WriteFile) HasRoot() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *WorkspaceCall_WriteFile) HasPath() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *WorkspaceCall_WriteFile) HasOffset() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *WorkspaceCall_WriteFile) HasData() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *WorkspaceCall_WriteFile) HasCreate() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *WorkspaceCall_WriteFile) HasTruncate() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *WorkspaceCall_WriteFile) HasMode() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *WorkspaceCall_WriteFile) ClearRoot() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Root = WorkspaceCall_ROOT_UNSPECIFIED
+}
+
+func (x *WorkspaceCall_WriteFile) ClearPath() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Path = nil
+}
+
+func (x *WorkspaceCall_WriteFile) ClearOffset() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Offset = 0
+}
+
+func (x *WorkspaceCall_WriteFile) ClearData() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Data = nil
+}
+
+func (x *WorkspaceCall_WriteFile) ClearCreate() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Create = false
+}
+
+func (x *WorkspaceCall_WriteFile) ClearTruncate() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Truncate = false
+}
+
+func (x *WorkspaceCall_WriteFile) ClearMode() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_Mode = 0
+}
+
+type WorkspaceCall_WriteFile_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Root   *WorkspaceCall_Root
+	Path   *string
+	Offset *int64
+	Data   []byte
+	// Whether to create the file if it doesn't exist, and truncate it before writing.
+	Create   *bool
+	Truncate *bool
+	Mode     *uint32
+}
+
+func (b0 WorkspaceCall_WriteFile_builder) Build() *WorkspaceCall_WriteFile {
+	m0 := &WorkspaceCall_WriteFile{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Root != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 7)
+		x.xxx_hidden_Root = *b.Root
+	}
+	if b.Path != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 7)
+		x.xxx_hidden_Path = b.Path
+	}
+	if b.Offset != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 7)
+		x.xxx_hidden_Offset = *b.Offset
+	}
+	if b.Data != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 7)
+		x.xxx_hidden_Data = b.Data
+	}
+	if b.Create != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 7)
+		x.xxx_hidden_Create = *b.Create
+	}
+	if b.Truncate != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 7)
+		x.xxx_hidden_Truncate = *b.Truncate
+	}
+	if b.Mode != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 7)
+		x.xxx_hidden_Mode = *b.Mode
+	}
+	return m0
+}
+
+type WorkspaceCall_Mkdir struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Root        WorkspaceCall_Root     `protobuf:"varint,1,opt,name=root,enum=bonk.v0.WorkspaceCall_Root"`
+	xxx_hidden_Path        *string                `protobuf:"bytes,2,opt,name=path"`
+	xxx_hidden_Mode        uint32                 `protobuf:"varint,3,opt,name=mode"`
+	xxx_hidden_All         bool                   `protobuf:"varint,4,opt,name=all"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *WorkspaceCall_Mkdir) Reset() {
+	*x = WorkspaceCall_Mkdir{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkspaceCall_Mkdir) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceCall_Mkdir) ProtoMessage() {}
+
+func (x *WorkspaceCall_Mkdir) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *WorkspaceCall_Mkdir) GetRoot() WorkspaceCall_Root {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 0) {
+			return x.xxx_hidden_Root
+		}
+	}
+	return WorkspaceCall_ROOT_UNSPECIFIED
+}
+
+func (x *WorkspaceCall_Mkdir) GetPath() string {
+	if x != nil {
+		if x.xxx_hidden_Path != nil {
+			return *x.xxx_hidden_Path
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *WorkspaceCall_Mkdir) GetMode() uint32 {
+	if x != nil {
+		return x.xxx_hidden_Mode
+	}
+	return 0
+}
+
+func (x *WorkspaceCall_Mkdir) GetAll() bool {
+	if x != nil {
+		return x.xxx_hidden_All
+	}
+	return false
+}
+
+func (x *WorkspaceCall_Mkdir) SetRoot(v WorkspaceCall_Root) {
+	x.xxx_hidden_Root = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
+}
+
+func (x *WorkspaceCall_Mkdir) SetPath(v string) {
+	x.xxx_hidden_Path = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
+}
+
+func (x *WorkspaceCall_Mkdir) SetMode(v uint32) {
+	x.xxx_hidden_Mode = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+}
+
+func (x *WorkspaceCall_Mkdir) SetAll(v bool) {
+	x.xxx_hidden_All = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 4)
+}
+
+func (x *WorkspaceCall_Mkdir) HasRoot() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *WorkspaceCall_Mkdir) HasPath() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *WorkspaceCall_Mkdir) HasMode() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *WorkspaceCall_Mkdir) HasAll() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *WorkspaceCall_Mkdir) ClearRoot() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Root = WorkspaceCall_ROOT_UNSPECIFIED
+}
+
+func (x *WorkspaceCall_Mkdir) ClearPath() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Path = nil
+}
+
+func (x *WorkspaceCall_Mkdir) ClearMode() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Mode = 0
+}
+
+func (x *WorkspaceCall_Mkdir) ClearAll() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_All = false
+}
+
+type WorkspaceCall_Mkdir_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Root *WorkspaceCall_Root
+	Path *string
+	Mode *uint32
+	// Whether to create any missing parents, ignoring an existing directory.
+	All *bool
+}
+
+func (b0 WorkspaceCall_Mkdir_builder) Build() *WorkspaceCall_Mkdir {
+	m0 := &WorkspaceCall_Mkdir{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Root != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
+		x.xxx_hidden_Root = *b.Root
+	}
+	if b.Path != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
+		x.xxx_hidden_Path = b.Path
+	}
+	if b.Mode != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
+		x.xxx_hidden_Mode = *b.Mode
+	}
+	if b.All != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 4)
+		x.xxx_hidden_All = *b.All
+	}
+	return m0
+}
+
+type WorkspaceCall_Remove struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Root        WorkspaceCall_Root     `protobuf:"varint,1,opt,name=root,enum=bonk.v0.WorkspaceCall_Root"`
+	xxx_hidden_Path        *string                `protobuf:"bytes,2,opt,name=path"`
+	xxx_hidden_All         bool                   `protobuf:"varint,3,opt,name=all"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *WorkspaceCall_Remove) Reset() {
+	*x = WorkspaceCall_Remove{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkspaceCall_Remove) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceCall_Remove) ProtoMessage() {}
+
+func (x *WorkspaceCall_Remove) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *WorkspaceCall_Remove) GetRoot() WorkspaceCall_Root {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 0) {
+			return x.xxx_hidden_Root
+		}
+	}
+	return WorkspaceCall_ROOT_UNSPECIFIED
+}
+
+func (x *WorkspaceCall_Remove) GetPath() string {
+	if x != nil {
+		if x.xxx_hidden_Path != nil {
+			return *x.xxx_hidden_Path
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *WorkspaceCall_Remove) GetAll() bool {
+	if x != nil {
+		return x.xxx_hidden_All
+	}
+	return false
+}
+
+func (x *WorkspaceCall_Remove) SetRoot(v WorkspaceCall_Root) {
+	x.xxx_hidden_Root = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *WorkspaceCall_Remove) SetPath(v string) {
+	x.xxx_hidden_Path = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *WorkspaceCall_Remove) SetAll(v bool) {
+	x.xxx_hidden_All = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *WorkspaceCall_Remove) HasRoot() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *WorkspaceCall_Remove) HasPath() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *WorkspaceCall_Remove) HasAll() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *WorkspaceCall_Remove) ClearRoot() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Root = WorkspaceCall_ROOT_UNSPECIFIED
+}
+
+func (x *WorkspaceCall_Remove) ClearPath() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Path = nil
+}
+
+func (x *WorkspaceCall_Remove) ClearAll() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_All = false
+}
+
+type WorkspaceCall_Remove_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Root *WorkspaceCall_Root
+	Path *string
+	// Whether to remove any children too.
+	All *bool
+}
+
+func (b0 WorkspaceCall_Remove_builder) Build() *WorkspaceCall_Remove {
+	m0 := &WorkspaceCall_Remove{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Root != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_Root = *b.Root
+	}
+	if b.Path != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_Path = b.Path
+	}
+	if b.All != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_All = *b.All
+	}
+	return m0
+}
+
+type WorkspaceCall_Rename struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Root        WorkspaceCall_Root     `protobuf:"varint,1,opt,name=root,enum=bonk.v0.WorkspaceCall_Root"`
+	xxx_hidden_OldPath     *string                `protobuf:"bytes,2,opt,name=old_path,json=oldPath"`
+	xxx_hidden_NewPath     *string                `protobuf:"bytes,3,opt,name=new_path,json=newPath"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *WorkspaceCall_Rename) Reset() {
+	*x = WorkspaceCall_Rename{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkspaceCall_Rename) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceCall_Rename) ProtoMessage() {}
+
+func (x *WorkspaceCall_Rename) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *WorkspaceCall_Rename) GetRoot() WorkspaceCall_Root {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 0) {
+			return x.xxx_hidden_Root
+		}
+	}
+	return WorkspaceCall_ROOT_UNSPECIFIED
+}
+
+func (x *WorkspaceCall_Rename) GetOldPath() string {
+	if x != nil {
+		if x.xxx_hidden_OldPath != nil {
+			return *x.xxx_hidden_OldPath
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *WorkspaceCall_Rename) GetNewPath() string {
+	if x != nil {
+		if x.xxx_hidden_NewPath != nil {
+			return *x.xxx_hidden_NewPath
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *WorkspaceCall_Rename) SetRoot(v WorkspaceCall_Root) {
+	x.xxx_hidden_Root = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *WorkspaceCall_Rename) SetOldPath(v string) {
+	x.xxx_hidden_OldPath = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *WorkspaceCall_Rename) SetNewPath(v string) {
+	x.xxx_hidden_NewPath = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *WorkspaceCall_Rename) HasRoot() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *WorkspaceCall_Rename) HasOldPath() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *WorkspaceCall_Rename) HasNewPath() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *WorkspaceCall_Rename) ClearRoot() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Root = WorkspaceCall_ROOT_UNSPECIFIED
+}
+
+func (x *WorkspaceCall_Rename) ClearOldPath() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_OldPath = nil
+}
+
+func (x *WorkspaceCall_Rename) ClearNewPath() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_NewPath = nil
+}
+
+type WorkspaceCall_Rename_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Root    *WorkspaceCall_Root
+	OldPath *string
+	NewPath *string
+}
+
+func (b0 WorkspaceCall_Rename_builder) Build() *WorkspaceCall_Rename {
+	m0 := &WorkspaceCall_Rename{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Root != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_Root = *b.Root
+	}
+	if b.OldPath != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_OldPath = b.OldPath
+	}
+	if b.NewPath != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_NewPath = b.NewPath
+	}
+	return m0
+}
+
+// Sent first to attach the stream to a session.
+type WorkspaceReply_Attach struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_SessionId   *string                `protobuf:"bytes,1,opt,name=session_id,json=sessionId"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *WorkspaceReply_Attach) Reset() {
+	*x = WorkspaceReply_Attach{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkspaceReply_Attach) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceReply_Attach) ProtoMessage() {}
+
+func (x *WorkspaceReply_Attach) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *WorkspaceReply_Attach) GetSessionId() string {
+	if x != nil {
+		if x.xxx_hidden_SessionId != nil {
+			return *x.xxx_hidden_SessionId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *WorkspaceReply_Attach) SetSessionId(v string) {
+	x.xxx_hidden_SessionId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *WorkspaceReply_Attach) HasSessionId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *WorkspaceReply_Attach) ClearSessionId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_SessionId = nil
+}
+
+type WorkspaceReply_Attach_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	SessionId *string
+}
+
+func (b0 WorkspaceReply_Attach_builder) Build() *WorkspaceReply_Attach {
+	m0 := &WorkspaceReply_Attach{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.SessionId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_SessionId = b.SessionId
+	}
+	return m0
+}
+
+type WorkspaceReply_FileInfo struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Name        *string                `protobuf:"bytes,1,opt,name=name"`
+	xxx_hidden_Size        int64                  `protobuf:"varint,2,opt,name=size"`
+	xxx_hidden_Mode        uint32                 `protobuf:"varint,3,opt,name=mode"`
+	xxx_hidden_ModTime     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=mod_time,json=modTime"`
+	xxx_hidden_Digest      *string                `protobuf:"bytes,5,opt,name=digest"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *WorkspaceReply_FileInfo) Reset() {
+	*x = WorkspaceReply_FileInfo{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkspaceReply_FileInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceReply_FileInfo) ProtoMessage() {}
+
+func (x *WorkspaceReply_FileInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *WorkspaceReply_FileInfo) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *WorkspaceReply_FileInfo) GetSize() int64 {
+	if x != nil {
+		return x.xxx_hidden_Size
+	}
+	return 0
+}
+
+func (x *WorkspaceReply_FileInfo) GetMode() uint32 {
+	if x != nil {
+		return x.xxx_hidden_Mode
+	}
+	return 0
+}
+
+func (x *WorkspaceReply_FileInfo) GetModTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_ModTime
+	}
+	return nil
+}
+
+func (x *WorkspaceReply_FileInfo) GetDigest() string {
+	if x != nil {
+		if x.xxx_hidden_Digest != nil {
+			return *x.xxx_hidden_Digest
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *WorkspaceReply_FileInfo) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 5)
+}
+
+func (x *WorkspaceReply_FileInfo) SetSize(v int64) {
+	x.xxx_hidden_Size = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 5)
+}
+
+func (x *WorkspaceReply_FileInfo) SetMode(v uint32) {
+	x.xxx_hidden_Mode = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 5)
+}
+
+func (x *WorkspaceReply_FileInfo) SetModTime(v *timestamppb.Timestamp) {
+	x.xxx_hidden_ModTime = v
+}
+
+func (x *WorkspaceReply_FileInfo) SetDigest(v string) {
+	x.xxx_hidden_Digest = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 5)
+}
+
+func (x *WorkspaceReply_FileInfo) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *WorkspaceReply_FileInfo) HasSize() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *WorkspaceReply_FileInfo) HasMode() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *WorkspaceReply_FileInfo) HasModTime() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_ModTime != nil
+}
+
+func (x *WorkspaceReply_FileInfo) HasDigest() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *WorkspaceReply_FileInfo) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Name = nil
+}
+
+func (x *WorkspaceReply_FileInfo) ClearSize() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Size = 0
+}
+
+func (x *WorkspaceReply_FileInfo) ClearMode() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Mode = 0
+}
+
+func (x *WorkspaceReply_FileInfo) ClearModTime() {
+	x.xxx_hidden_ModTime = nil
+}
+
+func (x *WorkspaceReply_FileInfo) ClearDigest() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Digest = nil
+}
+
+type WorkspaceReply_FileInfo_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Name    *string
+	Size    *int64
+	Mode    *uint32
+	ModTime *timestamppb.Timestamp
+	// The SHA-256 digest of a regular file's content, which is only set in reply to Stat.
+	Digest *string
+}
+
+func (b0 WorkspaceReply_FileInfo_builder) Build() *WorkspaceReply_FileInfo {
+	m0 := &WorkspaceReply_FileInfo{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 5)
+		x.xxx_hidden_Name = b.Name
+	}
+	if b.Size != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 5)
+		x.xxx_hidden_Size = *b.Size
+	}
+	if b.Mode != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 5)
+		x.xxx_hidden_Mode = *b.Mode
+	}
+	x.xxx_hidden_ModTime = b.ModTime
+	if b.Digest != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 5)
+		x.xxx_hidden_Digest = b.Digest
+	}
+	return m0
+}
+
+type WorkspaceReply_DirEntries struct {
+	state              protoimpl.MessageState      `protogen:"opaque.v1"`
+	xxx_hidden_Entries *[]*WorkspaceReply_FileInfo `protobuf:"bytes,1,rep,name=entries"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
-func (x *OpenSessionResponse_Ack) Reset() {
-	*x = OpenSessionResponse_Ack{}
-	mi := &file_bonk_v0_bonk_proto_msgTypes[9]
+func (x *WorkspaceReply_DirEntries) Reset() {
+	*x = WorkspaceReply_DirEntries{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OpenSessionResponse_Ack) String() string {
+func (x *WorkspaceReply_DirEntries) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OpenSessionResponse_Ack) ProtoMessage() {}
+func (*WorkspaceReply_DirEntries) ProtoMessage() {}
 
-func (x *OpenSessionResponse_Ack) ProtoReflect() protoreflect.Message {
-	mi := &file_bonk_v0_bonk_proto_msgTypes[9]
+func (x *WorkspaceReply_DirEntries) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1061,46 +6267,58 @@ func (x *OpenSessionResponse_Ack) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-type OpenSessionResponse_Ack_builder struct {
+func (x *WorkspaceReply_DirEntries) GetEntries() []*WorkspaceReply_FileInfo {
+	if x != nil {
+		if x.xxx_hidden_Entries != nil {
+			return *x.xxx_hidden_Entries
+		}
+	}
+	return nil
+}
+
+func (x *WorkspaceReply_DirEntries) SetEntries(v []*WorkspaceReply_FileInfo) {
+	x.xxx_hidden_Entries = &v
+}
+
+type WorkspaceReply_DirEntries_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
+	Entries []*WorkspaceReply_FileInfo
 }
 
-func (b0 OpenSessionResponse_Ack_builder) Build() *OpenSessionResponse_Ack {
-	m0 := &OpenSessionResponse_Ack{}
+func (b0 WorkspaceReply_DirEntries_builder) Build() *WorkspaceReply_DirEntries {
+	m0 := &WorkspaceReply_DirEntries{}
 	b, x := &b0, m0
 	_, _ = b, x
+	x.xxx_hidden_Entries = &b.Entries
 	return m0
 }
 
-// This is meant to mirror [slog.Record](https://pkg.go.dev/log/slog#Record)
-type OpenSessionResponse_LogRecord struct {
-	state                  protoimpl.MessageState     `protogen:"opaque.v1"`
-	xxx_hidden_Time        *timestamppb.Timestamp     `protobuf:"bytes,1,opt,name=time"`
-	xxx_hidden_Message     *string                    `protobuf:"bytes,2,opt,name=message"`
-	xxx_hidden_Level       int64                      `protobuf:"varint,3,opt,name=level"`
-	xxx_hidden_Attrs       map[string]*structpb.Value `protobuf:"bytes,4,rep,name=attrs" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+type WorkspaceReply_Content struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Data        []byte                 `protobuf:"bytes,1,opt,name=data"`
+	xxx_hidden_Eof         bool                   `protobuf:"varint,2,opt,name=eof"`
 	XXX_raceDetectHookData protoimpl.RaceDetectHookData
 	XXX_presence           [1]uint32
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
 }
 
-func (x *OpenSessionResponse_LogRecord) Reset() {
-	*x = OpenSessionResponse_LogRecord{}
-	mi := &file_bonk_v0_bonk_proto_msgTypes[10]
+func (x *WorkspaceReply_Content) Reset() {
+	*x = WorkspaceReply_Content{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OpenSessionResponse_LogRecord) String() string {
+func (x *WorkspaceReply_Content) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OpenSessionResponse_LogRecord) ProtoMessage() {}
+func (*WorkspaceReply_Content) ProtoMessage() {}
 
-func (x *OpenSessionResponse_LogRecord) ProtoReflect() protoreflect.Message {
-	mi := &file_bonk_v0_bonk_proto_msgTypes[10]
+func (x *WorkspaceReply_Content) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1111,143 +6329,149 @@ func (x *OpenSessionResponse_LogRecord) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *OpenSessionResponse_LogRecord) GetTime() *timestamppb.Timestamp {
+func (x *WorkspaceReply_Content) GetData() []byte {
 	if x != nil {
-		return x.xxx_hidden_Time
+		return x.xxx_hidden_Data
 	}
 	return nil
 }
 
-func (x *OpenSessionResponse_LogRecord) GetMessage() string {
+func (x *WorkspaceReply_Content) GetEof() bool {
 	if x != nil {
-		if x.xxx_hidden_Message != nil {
-			return *x.xxx_hidden_Message
-		}
-		return ""
+		return x.xxx_hidden_Eof
 	}
-	return ""
+	return false
 }
 
-func (x *OpenSessionResponse_LogRecord) GetLevel() int64 {
-	if x != nil {
-		return x.xxx_hidden_Level
+func (x *WorkspaceReply_Content) SetData(v []byte) {
+	if v == nil {
+		v = []byte{}
 	}
-	return 0
+	x.xxx_hidden_Data = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
 }
 
-func (x *OpenSessionResponse_LogRecord) GetAttrs() map[string]*structpb.Value {
-	if x != nil {
-		return x.xxx_hidden_Attrs
-	}
-	return nil
+func (x *WorkspaceReply_Content) SetEof(v bool) {
+	x.xxx_hidden_Eof = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
 }
 
-func (x *OpenSessionResponse_LogRecord) SetTime(v *timestamppb.Timestamp) {
-	x.xxx_hidden_Time = v
+func (x *WorkspaceReply_Content) HasData() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
-func (x *OpenSessionResponse_LogRecord) SetMessage(v string) {
-	x.xxx_hidden_Message = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
+func (x *WorkspaceReply_Content) HasEof() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
 }
 
-func (x *OpenSessionResponse_LogRecord) SetLevel(v int64) {
-	x.xxx_hidden_Level = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+func (x *WorkspaceReply_Content) ClearData() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Data = nil
 }
 
-func (x *OpenSessionResponse_LogRecord) SetAttrs(v map[string]*structpb.Value) {
-	x.xxx_hidden_Attrs = v
+func (x *WorkspaceReply_Content) ClearEof() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Eof = false
 }
 
-func (x *OpenSessionResponse_LogRecord) HasTime() bool {
-	if x == nil {
-		return false
-	}
-	return x.xxx_hidden_Time != nil
+type WorkspaceReply_Content_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Data []byte
+	// Set on the final chunk of a file's content.
+	Eof *bool
 }
 
-func (x *OpenSessionResponse_LogRecord) HasMessage() bool {
-	if x == nil {
-		return false
+func (b0 WorkspaceReply_Content_builder) Build() *WorkspaceReply_Content {
+	m0 := &WorkspaceReply_Content{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Data != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Data = b.Data
 	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+	if b.Eof != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Eof = *b.Eof
+	}
+	return m0
 }
 
-func (x *OpenSessionResponse_LogRecord) HasLevel() bool {
-	if x == nil {
-		return false
-	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+// Replies to calls which don't return anything.
+type WorkspaceReply_Done struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OpenSessionResponse_LogRecord) ClearTime() {
-	x.xxx_hidden_Time = nil
+func (x *WorkspaceReply_Done) Reset() {
+	*x = WorkspaceReply_Done{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *OpenSessionResponse_LogRecord) ClearMessage() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
-	x.xxx_hidden_Message = nil
+func (x *WorkspaceReply_Done) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *OpenSessionResponse_LogRecord) ClearLevel() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
-	x.xxx_hidden_Level = 0
+func (*WorkspaceReply_Done) ProtoMessage() {}
+
+func (x *WorkspaceReply_Done) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-type OpenSessionResponse_LogRecord_builder struct {
+type WorkspaceReply_Done_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Time    *timestamppb.Timestamp
-	Message *string
-	Level   *int64
-	Attrs   map[string]*structpb.Value
 }
 
-func (b0 OpenSessionResponse_LogRecord_builder) Build() *OpenSessionResponse_LogRecord {
-	m0 := &OpenSessionResponse_LogRecord{}
+func (b0 WorkspaceReply_Done_builder) Build() *WorkspaceReply_Done {
+	m0 := &WorkspaceReply_Done{}
 	b, x := &b0, m0
 	_, _ = b, x
-	x.xxx_hidden_Time = b.Time
-	if b.Message != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
-		x.xxx_hidden_Message = b.Message
-	}
-	if b.Level != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
-		x.xxx_hidden_Level = *b.Level
-	}
-	x.xxx_hidden_Attrs = b.Attrs
 	return m0
 }
 
-type ExecuteTaskResponse_FollowupTask struct {
-	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
-	xxx_hidden_Executor    *string                `protobuf:"bytes,2,opt,name=executor"`
-	xxx_hidden_Inputs      []string               `protobuf:"bytes,3,rep,name=inputs"`
-	xxx_hidden_Arguments   *structpb.Value        `protobuf:"bytes,4,opt,name=arguments"`
+type WorkspaceReply_Error struct {
+	state                  protoimpl.MessageState    `protogen:"opaque.v1"`
+	xxx_hidden_Kind        WorkspaceReply_Error_Kind `protobuf:"varint,1,opt,name=kind,enum=bonk.v0.WorkspaceReply_Error_Kind"`
+	xxx_hidden_Message     *string                   `protobuf:"bytes,2,opt,name=message"`
 	XXX_raceDetectHookData protoimpl.RaceDetectHookData
 	XXX_presence           [1]uint32
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
 }
 
-func (x *ExecuteTaskResponse_FollowupTask) Reset() {
-	*x = ExecuteTaskResponse_FollowupTask{}
-	mi := &file_bonk_v0_bonk_proto_msgTypes[12]
+func (x *WorkspaceReply_Error) Reset() {
+	*x = WorkspaceReply_Error{}
+	mi := &file_bonk_v0_bonk_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ExecuteTaskResponse_FollowupTask) String() string {
+func (x *WorkspaceReply_Error) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExecuteTaskResponse_FollowupTask) ProtoMessage() {}
+func (*WorkspaceReply_Error) ProtoMessage() {}
 
-func (x *ExecuteTaskResponse_FollowupTask) ProtoReflect() protoreflect.Message {
-	mi := &file_bonk_v0_bonk_proto_msgTypes[12]
+func (x *WorkspaceReply_Error) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_bonk_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1258,116 +6482,78 @@ func (x *ExecuteTaskResponse_FollowupTask) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *ExecuteTaskResponse_FollowupTask) GetId() string {
+func (x *WorkspaceReply_Error) GetKind() WorkspaceReply_Error_Kind {
 	if x != nil {
-		if x.xxx_hidden_Id != nil {
-			return *x.xxx_hidden_Id
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 0) {
+			return x.xxx_hidden_Kind
 		}
-		return ""
 	}
-	return ""
+	return WorkspaceReply_Error_KIND_UNSPECIFIED
 }
 
-func (x *ExecuteTaskResponse_FollowupTask) GetExecutor() string {
+func (x *WorkspaceReply_Error) GetMessage() string {
 	if x != nil {
-		if x.xxx_hidden_Executor != nil {
-			return *x.xxx_hidden_Executor
+		if x.xxx_hidden_Message != nil {
+			return *x.xxx_hidden_Message
 		}
 		return ""
 	}
 	return ""
 }
 
-func (x *ExecuteTaskResponse_FollowupTask) GetInputs() []string {
-	if x != nil {
-		return x.xxx_hidden_Inputs
-	}
-	return nil
-}
-
-func (x *ExecuteTaskResponse_FollowupTask) GetArguments() *structpb.Value {
-	if x != nil {
-		return x.xxx_hidden_Arguments
-	}
-	return nil
-}
-
-func (x *ExecuteTaskResponse_FollowupTask) SetId(v string) {
-	x.xxx_hidden_Id = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
-}
-
-func (x *ExecuteTaskResponse_FollowupTask) SetExecutor(v string) {
-	x.xxx_hidden_Executor = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
-}
-
-func (x *ExecuteTaskResponse_FollowupTask) SetInputs(v []string) {
-	x.xxx_hidden_Inputs = v
+func (x *WorkspaceReply_Error) SetKind(v WorkspaceReply_Error_Kind) {
+	x.xxx_hidden_Kind = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
 }
 
-func (x *ExecuteTaskResponse_FollowupTask) SetArguments(v *structpb.Value) {
-	x.xxx_hidden_Arguments = v
+func (x *WorkspaceReply_Error) SetMessage(v string) {
+	x.xxx_hidden_Message = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
 }
 
-func (x *ExecuteTaskResponse_FollowupTask) HasId() bool {
+func (x *WorkspaceReply_Error) HasKind() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
-func (x *ExecuteTaskResponse_FollowupTask) HasExecutor() bool {
+func (x *WorkspaceReply_Error) HasMessage() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
 }
 
-func (x *ExecuteTaskResponse_FollowupTask) HasArguments() bool {
-	if x == nil {
-		return false
-	}
-	return x.xxx_hidden_Arguments != nil
-}
-
-func (x *ExecuteTaskResponse_FollowupTask) ClearId() {
+func (x *WorkspaceReply_Error) ClearKind() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
-	x.xxx_hidden_Id = nil
+	x.xxx_hidden_Kind = WorkspaceReply_Error_KIND_UNSPECIFIED
 }
 
-func (x *ExecuteTaskResponse_FollowupTask) ClearExecutor() {
+func (x *WorkspaceReply_Error) ClearMessage() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
-	x.xxx_hidden_Executor = nil
-}
-
-func (x *ExecuteTaskResponse_FollowupTask) ClearArguments() {
-	x.xxx_hidden_Arguments = nil
+	x.xxx_hidden_Message = nil
 }
 
-type ExecuteTaskResponse_FollowupTask_builder struct {
+type WorkspaceReply_Error_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Id        *string
-	Executor  *string
-	Inputs    []string
-	Arguments *structpb.Value
+	Kind    *WorkspaceReply_Error_Kind
+	Message *string
 }
 
-func (b0 ExecuteTaskResponse_FollowupTask_builder) Build() *ExecuteTaskResponse_FollowupTask {
-	m0 := &ExecuteTaskResponse_FollowupTask{}
+func (b0 WorkspaceReply_Error_builder) Build() *WorkspaceReply_Error {
+	m0 := &WorkspaceReply_Error{}
 	b, x := &b0, m0
 	_, _ = b, x
-	if b.Id != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
-		x.xxx_hidden_Id = b.Id
+	if b.Kind != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Kind = *b.Kind
 	}
-	if b.Executor != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
-		x.xxx_hidden_Executor = b.Executor
+	if b.Message != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Message = b.Message
 	}
-	x.xxx_hidden_Inputs = b.Inputs
-	x.xxx_hidden_Arguments = b.Arguments
 	return m0
 }
 
@@ -1375,19 +6561,21 @@ var File_bonk_v0_bonk_proto protoreflect.FileDescriptor
 
 const file_bonk_v0_bonk_proto_rawDesc = "" +
 	"\n" +
-	"\x12bonk/v0/bonk.proto\x12\abonk.v0\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe7\x03\n" +
+	"\x12bonk/v0/bonk.proto\x12\abonk.v0\x1a\x1egoogle/protobuf/duration.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd7\x04\n" +
 	"\x12OpenSessionRequest\x12\x1d\n" +
 	"\n" +
 	"session_id\x18\x01 \x01(\tR\tsessionId\x12T\n" +
 	"\rlog_streaming\x18\x02 \x01(\v2/.bonk.v0.OpenSessionRequest.LogStreamingOptionsR\flogStreaming\x12M\n" +
-	"\x05local\x18\x05 \x01(\v25.bonk.v0.OpenSessionRequest.WorkspaceDescriptionLocalH\x00R\x05local\x12J\n" +
+	"\x05local\x18\x05 \x01(\v25.bonk.v0.OpenSessionRequest.WorkspaceDescriptionLocalH\x00R\x05local\x12P\n" +
+	"\x06remote\x18\x06 \x01(\v26.bonk.v0.OpenSessionRequest.WorkspaceDescriptionRemoteH\x00R\x06remote\x12J\n" +
 	"\x04test\x18c \x01(\v24.bonk.v0.OpenSessionRequest.WorkspaceDescriptionTestH\x00R\x04test\x1aJ\n" +
 	"\x13LogStreamingOptions\x12\x14\n" +
 	"\x05level\x18\x01 \x01(\x03R\x05level\x12\x1d\n" +
 	"\n" +
 	"add_source\x18\x02 \x01(\bR\taddSource\x1a@\n" +
 	"\x19WorkspaceDescriptionLocal\x12#\n" +
-	"\rabsolute_path\x18\x01 \x01(\tR\fabsolutePath\x1a\x1a\n" +
+	"\rabsolute_path\x18\x01 \x01(\tR\fabsolutePath\x1a\x1c\n" +
+	"\x1aWorkspaceDescriptionRemote\x1a\x1a\n" +
 	"\x18WorkspaceDescriptionTestB\x17\n" +
 	"\x15workspace_description\"\xaf\x03\n" +
 	"\x13OpenSessionResponse\x124\n" +
@@ -1414,62 +6602,366 @@ const file_bonk_v0_bonk_proto_rawDesc = "" +
 	"\x02id\x18\x02 \x01(\tR\x02id\x12\x1a\n" +
 	"\bexecutor\x18\x03 \x01(\tR\bexecutor\x12\x16\n" +
 	"\x06inputs\x18\x04 \x03(\tR\x06inputs\x124\n" +
-	"\targuments\x18\x05 \x01(\v2\x16.google.protobuf.ValueR\targuments\"\x8a\x02\n" +
+	"\targuments\x18\x05 \x01(\v2\x16.google.protobuf.ValueR\targuments\"h\n" +
 	"\x13ExecuteTaskResponse\x12\x16\n" +
-	"\x06output\x18\x01 \x03(\tR\x06output\x12P\n" +
-	"\x0efollowup_tasks\x18\x02 \x03(\v2).bonk.v0.ExecuteTaskResponse.FollowupTaskR\rfollowupTasks\x1a\x88\x01\n" +
-	"\fFollowupTask\x12\x0e\n" +
+	"\x06output\x18\x01 \x03(\tR\x06output\x129\n" +
+	"\x0efollowup_tasks\x18\x02 \x03(\v2\x12.bonk.v0.BuildTaskR\rfollowupTasks\"\x11\n" +
+	"\x0fDescribeRequest\"\xc1\x01\n" +
+	"\x10DescribeResponse\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12@\n" +
+	"\texecutors\x18\x03 \x03(\v2\".bonk.v0.DescribeResponse.ExecutorR\texecutors\x1a=\n" +
+	"\bExecutor\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"cue_schema\x18\x02 \x01(\tR\tcueSchema\"B\n" +
+	"\x11CancelTaskRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\"0\n" +
+	"\x12CancelTaskResponse\x12\x1a\n" +
+	"\bcanceled\x18\x01 \x01(\bR\bcanceled\"\xa1\x02\n" +
+	"\x0eExecutionError\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12>\n" +
+	"\tpositions\x18\x03 \x03(\v2 .bonk.v0.ExecutionError.PositionR\tpositions\x12\x1c\n" +
+	"\tretryable\x18\x04 \x01(\bR\tretryable\x12/\n" +
+	"\x06causes\x18\x05 \x03(\v2\x17.bonk.v0.ExecutionErrorR\x06causes\x1aR\n" +
+	"\bPosition\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12\x12\n" +
+	"\x04line\x18\x02 \x01(\x03R\x04line\x12\x16\n" +
+	"\x06column\x18\x03 \x01(\x03R\x06column\"\xbb\x04\n" +
+	"\tBuildTask\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
 	"\bexecutor\x18\x02 \x01(\tR\bexecutor\x12\x16\n" +
 	"\x06inputs\x18\x03 \x03(\tR\x06inputs\x124\n" +
-	"\targuments\x18\x04 \x01(\v2\x16.google.protobuf.ValueR\targuments2\xf4\x01\n" +
+	"\targuments\x18\x04 \x01(\v2\x16.google.protobuf.ValueR\targuments\x12\"\n" +
+	"\fdependencies\x18\x05 \x03(\tR\fdependencies\x12?\n" +
+	"\tresources\x18\x06 \x03(\v2!.bonk.v0.BuildTask.ResourcesEntryR\tresources\x123\n" +
+	"\atimeout\x18\a \x01(\v2\x19.google.protobuf.DurationR\atimeout\x12*\n" +
+	"\x05retry\x18\b \x01(\v2\x14.bonk.v0.RetryPolicyR\x05retry\x126\n" +
+	"\x06matrix\x18\t \x03(\v2\x1e.bonk.v0.BuildTask.MatrixEntryR\x06matrix\x12&\n" +
+	"\x04when\x18\n" +
+	" \x01(\v2\x12.bonk.v0.ConditionR\x04when\x1a<\n" +
+	"\x0eResourcesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\x1aP\n" +
+	"\vMatrixEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12+\n" +
+	"\x05value\x18\x02 \x01(\v2\x15.bonk.v0.MatrixValuesR\x05value:\x028\x01\"\xf6\x03\n" +
+	"\tCondition\x12\x1a\n" +
+	"\bprofiles\x18\x01 \x03(\tR\bprofiles\x12-\n" +
+	"\x03env\x18\x02 \x03(\v2\x1b.bonk.v0.Condition.EnvEntryR\x03env\x12\x16\n" +
+	"\x06exists\x18\x03 \x03(\tR\x06exists\x12!\n" +
+	"\finputs_exist\x18\x04 \x01(\bR\vinputsExist\x129\n" +
+	"\aresults\x18\x05 \x03(\v2\x1f.bonk.v0.Condition.ResultsEntryR\aresults\x12$\n" +
+	"\x03not\x18\x06 \x01(\v2\x12.bonk.v0.ConditionR\x03not\x129\n" +
+	"\aoutputs\x18\a \x03(\v2\x1f.bonk.v0.Condition.OutputsEntryR\aoutputs\x1a6\n" +
+	"\bEnvEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a:\n" +
+	"\fResultsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aS\n" +
+	"\fOutputsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12-\n" +
+	"\x05value\x18\x02 \x01(\v2\x17.bonk.v0.OutputPatternsR\x05value:\x028\x01\",\n" +
+	"\x0eOutputPatterns\x12\x1a\n" +
+	"\bpatterns\x18\x01 \x03(\tR\bpatterns\"&\n" +
+	"\fMatrixValues\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\tR\x06values\"\xbc\x01\n" +
+	"\vRetryPolicy\x12!\n" +
+	"\fmax_attempts\x18\x01 \x01(\x03R\vmaxAttempts\x123\n" +
+	"\abackoff\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\abackoff\x12:\n" +
+	"\vmax_backoff\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\n" +
+	"maxBackoff\x12\x19\n" +
+	"\bretry_on\x18\x04 \x03(\tR\aretryOn\"\x8b\x02\n" +
+	"\x12SubmitBuildRequest\x12?\n" +
+	"\bsessions\x18\x01 \x03(\v2#.bonk.v0.SubmitBuildRequest.SessionR\bsessions\x12$\n" +
+	"\x05facts\x18\x02 \x01(\v2\x0e.bonk.v0.FactsR\x05facts\x12$\n" +
+	"\rconfiguration\x18\x03 \x01(\tR\rconfiguration\x1ah\n" +
+	"\aSession\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12#\n" +
+	"\rabsolute_path\x18\x02 \x01(\tR\fabsolutePath\x12(\n" +
+	"\x05tasks\x18\x03 \x03(\v2\x12.bonk.v0.BuildTaskR\x05tasks\"\x86\x01\n" +
+	"\x05Facts\x12\x1a\n" +
+	"\bprofiles\x18\x01 \x03(\tR\bprofiles\x12)\n" +
+	"\x03env\x18\x02 \x03(\v2\x17.bonk.v0.Facts.EnvEntryR\x03env\x1a6\n" +
+	"\bEnvEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xf2\x04\n" +
+	"\n" +
+	"BuildEvent\x127\n" +
+	"\astarted\x18\x01 \x01(\v2\x1b.bonk.v0.BuildEvent.StartedH\x00R\astarted\x12A\n" +
+	"\vtask_status\x18\x02 \x01(\v2\x1e.bonk.v0.BuildEvent.TaskStatusH\x00R\n" +
+	"taskStatus\x12:\n" +
+	"\bfinished\x18\x03 \x01(\v2\x1c.bonk.v0.BuildEvent.FinishedH\x00R\bfinished\x1a$\n" +
+	"\aStarted\x12\x19\n" +
+	"\bbuild_id\x18\x01 \x01(\tR\abuildId\x1a\xc1\x02\n" +
+	"\n" +
+	"TaskStatus\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\tR\x06taskId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\x03R\x06status\x12.\n" +
+	"\x04time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x1a\n" +
+	"\bexecutor\x18\x05 \x01(\tR\bexecutor\x124\n" +
+	"\targuments\x18\x06 \x01(\v2\x16.google.protobuf.ValueR\targuments\x12\x18\n" +
+	"\aoutputs\x18\a \x03(\tR\aoutputs\x12-\n" +
+	"\x05error\x18\b \x01(\v2\x17.bonk.v0.ExecutionErrorR\x05error\x12\x18\n" +
+	"\aattempt\x18\t \x01(\x03R\aattempt\x1a9\n" +
+	"\bFinished\x12-\n" +
+	"\x05error\x18\x01 \x01(\v2\x17.bonk.v0.ExecutionErrorR\x05errorB\a\n" +
+	"\x05event\"/\n" +
+	"\x12CancelBuildRequest\x12\x19\n" +
+	"\bbuild_id\x18\x01 \x01(\tR\abuildId\"1\n" +
+	"\x13CancelBuildResponse\x12\x1a\n" +
+	"\bcanceled\x18\x01 \x01(\bR\bcanceled\"\xb4\n" +
+	"\n" +
+	"\rWorkspaceCall\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12.\n" +
+	"\x03ack\x18\x02 \x01(\v2\x1a.bonk.v0.WorkspaceCall.AckH\x00R\x03ack\x121\n" +
+	"\x04stat\x18\x03 \x01(\v2\x1b.bonk.v0.WorkspaceCall.StatH\x00R\x04stat\x12;\n" +
+	"\bread_dir\x18\x04 \x01(\v2\x1e.bonk.v0.WorkspaceCall.ReadDirH\x00R\areadDir\x12>\n" +
+	"\tread_file\x18\x05 \x01(\v2\x1f.bonk.v0.WorkspaceCall.ReadFileH\x00R\breadFile\x12A\n" +
+	"\n" +
+	"write_file\x18\x06 \x01(\v2 .bonk.v0.WorkspaceCall.WriteFileH\x00R\twriteFile\x124\n" +
+	"\x05mkdir\x18\a \x01(\v2\x1c.bonk.v0.WorkspaceCall.MkdirH\x00R\x05mkdir\x127\n" +
+	"\x06remove\x18\b \x01(\v2\x1d.bonk.v0.WorkspaceCall.RemoveH\x00R\x06remove\x127\n" +
+	"\x06rename\x18\t \x01(\v2\x1d.bonk.v0.WorkspaceCall.RenameH\x00R\x06rename\x1a\x05\n" +
+	"\x03Ack\x1aK\n" +
+	"\x04Stat\x12/\n" +
+	"\x04root\x18\x01 \x01(\x0e2\x1b.bonk.v0.WorkspaceCall.RootR\x04root\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x1aN\n" +
+	"\aReadDir\x12/\n" +
+	"\x04root\x18\x01 \x01(\x0e2\x1b.bonk.v0.WorkspaceCall.RootR\x04root\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x1aO\n" +
+	"\bReadFile\x12/\n" +
+	"\x04root\x18\x01 \x01(\x0e2\x1b.bonk.v0.WorkspaceCall.RootR\x04root\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x1a\xc4\x01\n" +
+	"\tWriteFile\x12/\n" +
+	"\x04root\x18\x01 \x01(\x0e2\x1b.bonk.v0.WorkspaceCall.RootR\x04root\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x03R\x06offset\x12\x12\n" +
+	"\x04data\x18\x04 \x01(\fR\x04data\x12\x16\n" +
+	"\x06create\x18\x05 \x01(\bR\x06create\x12\x1a\n" +
+	"\btruncate\x18\x06 \x01(\bR\btruncate\x12\x12\n" +
+	"\x04mode\x18\a \x01(\rR\x04mode\x1ar\n" +
+	"\x05Mkdir\x12/\n" +
+	"\x04root\x18\x01 \x01(\x0e2\x1b.bonk.v0.WorkspaceCall.RootR\x04root\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x12\n" +
+	"\x04mode\x18\x03 \x01(\rR\x04mode\x12\x10\n" +
+	"\x03all\x18\x04 \x01(\bR\x03all\x1a_\n" +
+	"\x06Remove\x12/\n" +
+	"\x04root\x18\x01 \x01(\x0e2\x1b.bonk.v0.WorkspaceCall.RootR\x04root\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x10\n" +
+	"\x03all\x18\x03 \x01(\bR\x03all\x1ao\n" +
+	"\x06Rename\x12/\n" +
+	"\x04root\x18\x01 \x01(\x0e2\x1b.bonk.v0.WorkspaceCall.RootR\x04root\x12\x19\n" +
+	"\bold_path\x18\x02 \x01(\tR\aoldPath\x12\x19\n" +
+	"\bnew_path\x18\x03 \x01(\tR\anewPath\">\n" +
+	"\x04Root\x12\x14\n" +
+	"\x10ROOT_UNSPECIFIED\x10\x00\x12\x0f\n" +
+	"\vROOT_SOURCE\x10\x01\x12\x0f\n" +
+	"\vROOT_OUTPUT\x10\x02B\x06\n" +
+	"\x04call\"\xfa\x06\n" +
+	"\x0eWorkspaceReply\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x128\n" +
+	"\x06attach\x18\x02 \x01(\v2\x1e.bonk.v0.WorkspaceReply.AttachH\x00R\x06attach\x126\n" +
+	"\x04info\x18\x03 \x01(\v2 .bonk.v0.WorkspaceReply.FileInfoH\x00R\x04info\x12>\n" +
+	"\aentries\x18\x04 \x01(\v2\".bonk.v0.WorkspaceReply.DirEntriesH\x00R\aentries\x12;\n" +
+	"\acontent\x18\x05 \x01(\v2\x1f.bonk.v0.WorkspaceReply.ContentH\x00R\acontent\x122\n" +
+	"\x04done\x18\x06 \x01(\v2\x1c.bonk.v0.WorkspaceReply.DoneH\x00R\x04done\x125\n" +
+	"\x05error\x18\a \x01(\v2\x1d.bonk.v0.WorkspaceReply.ErrorH\x00R\x05error\x1a'\n" +
+	"\x06Attach\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x1a\x95\x01\n" +
+	"\bFileInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x12\x12\n" +
+	"\x04mode\x18\x03 \x01(\rR\x04mode\x125\n" +
+	"\bmod_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\amodTime\x12\x16\n" +
+	"\x06digest\x18\x05 \x01(\tR\x06digest\x1aH\n" +
+	"\n" +
+	"DirEntries\x12:\n" +
+	"\aentries\x18\x01 \x03(\v2 .bonk.v0.WorkspaceReply.FileInfoR\aentries\x1a/\n" +
+	"\aContent\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12\x10\n" +
+	"\x03eof\x18\x02 \x01(\bR\x03eof\x1a\x06\n" +
+	"\x04Done\x1a\xb0\x01\n" +
+	"\x05Error\x126\n" +
+	"\x04kind\x18\x01 \x01(\x0e2\".bonk.v0.WorkspaceReply.Error.KindR\x04kind\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"U\n" +
+	"\x04Kind\x12\x14\n" +
+	"\x10KIND_UNSPECIFIED\x10\x00\x12\x12\n" +
+	"\x0eKIND_NOT_EXIST\x10\x01\x12\x0e\n" +
+	"\n" +
+	"KIND_EXIST\x10\x02\x12\x13\n" +
+	"\x0fKIND_PERMISSION\x10\x03B\a\n" +
+	"\x05reply2\xbe\x03\n" +
 	"\x0fExecutorService\x12J\n" +
 	"\vOpenSession\x12\x1b.bonk.v0.OpenSessionRequest\x1a\x1c.bonk.v0.OpenSessionResponse0\x01\x12K\n" +
 	"\fCloseSession\x12\x1c.bonk.v0.CloseSessionRequest\x1a\x1d.bonk.v0.CloseSessionResponse\x12H\n" +
-	"\vExecuteTask\x12\x1b.bonk.v0.ExecuteTaskRequest\x1a\x1c.bonk.v0.ExecuteTaskResponseBs\n" +
+	"\vExecuteTask\x12\x1b.bonk.v0.ExecuteTaskRequest\x1a\x1c.bonk.v0.ExecuteTaskResponse\x12E\n" +
+	"\n" +
+	"CancelTask\x12\x1a.bonk.v0.CancelTaskRequest\x1a\x1b.bonk.v0.CancelTaskResponse\x12?\n" +
+	"\bDescribe\x12\x18.bonk.v0.DescribeRequest\x1a\x19.bonk.v0.DescribeResponse\x12@\n" +
+	"\tWorkspace\x12\x17.bonk.v0.WorkspaceReply\x1a\x16.bonk.v0.WorkspaceCall(\x010\x012\x9b\x01\n" +
+	"\fBuildService\x12A\n" +
+	"\vSubmitBuild\x12\x1b.bonk.v0.SubmitBuildRequest\x1a\x13.bonk.v0.BuildEvent0\x01\x12H\n" +
+	"\vCancelBuild\x12\x1b.bonk.v0.CancelBuildRequest\x1a\x1c.bonk.v0.CancelBuildResponseBs\n" +
 	"\vcom.bonk.v0B\tBonkProtoP\x01Z\x1cgo.bonk.build/api/go/bonk/v0\xa2\x02\x03BVX\xaa\x02\aBonk.V0\xca\x02\aBonk\\V0\xe2\x02\x13Bonk\\V0\\GPBMetadata\xea\x02\bBonk::V0b\beditionsp\xe8\a"
 
-var file_bonk_v0_bonk_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_bonk_v0_bonk_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_bonk_v0_bonk_proto_msgTypes = make([]protoimpl.MessageInfo, 56)
 var file_bonk_v0_bonk_proto_goTypes = []any{
-	(*OpenSessionRequest)(nil),                           // 0: bonk.v0.OpenSessionRequest
-	(*OpenSessionResponse)(nil),                          // 1: bonk.v0.OpenSessionResponse
-	(*CloseSessionRequest)(nil),                          // 2: bonk.v0.CloseSessionRequest
-	(*CloseSessionResponse)(nil),                         // 3: bonk.v0.CloseSessionResponse
-	(*ExecuteTaskRequest)(nil),                           // 4: bonk.v0.ExecuteTaskRequest
-	(*ExecuteTaskResponse)(nil),                          // 5: bonk.v0.ExecuteTaskResponse
-	(*OpenSessionRequest_LogStreamingOptions)(nil),       // 6: bonk.v0.OpenSessionRequest.LogStreamingOptions
-	(*OpenSessionRequest_WorkspaceDescriptionLocal)(nil), // 7: bonk.v0.OpenSessionRequest.WorkspaceDescriptionLocal
-	(*OpenSessionRequest_WorkspaceDescriptionTest)(nil),  // 8: bonk.v0.OpenSessionRequest.WorkspaceDescriptionTest
-	(*OpenSessionResponse_Ack)(nil),                      // 9: bonk.v0.OpenSessionResponse.Ack
-	(*OpenSessionResponse_LogRecord)(nil),                // 10: bonk.v0.OpenSessionResponse.LogRecord
-	nil,                                                  // 11: bonk.v0.OpenSessionResponse.LogRecord.AttrsEntry
-	(*ExecuteTaskResponse_FollowupTask)(nil),             // 12: bonk.v0.ExecuteTaskResponse.FollowupTask
-	(*structpb.Value)(nil),                               // 13: google.protobuf.Value
-	(*timestamppb.Timestamp)(nil),                        // 14: google.protobuf.Timestamp
+	(WorkspaceCall_Root)(0),                               // 0: bonk.v0.WorkspaceCall.Root
+	(WorkspaceReply_Error_Kind)(0),                        // 1: bonk.v0.WorkspaceReply.Error.Kind
+	(*OpenSessionRequest)(nil),                            // 2: bonk.v0.OpenSessionRequest
+	(*OpenSessionResponse)(nil),                           // 3: bonk.v0.OpenSessionResponse
+	(*CloseSessionRequest)(nil),                           // 4: bonk.v0.CloseSessionRequest
+	(*CloseSessionResponse)(nil),                          // 5: bonk.v0.CloseSessionResponse
+	(*ExecuteTaskRequest)(nil),                            // 6: bonk.v0.ExecuteTaskRequest
+	(*ExecuteTaskResponse)(nil),                           // 7: bonk.v0.ExecuteTaskResponse
+	(*DescribeRequest)(nil),                               // 8: bonk.v0.DescribeRequest
+	(*DescribeResponse)(nil),                              // 9: bonk.v0.DescribeResponse
+	(*CancelTaskRequest)(nil),                             // 10: bonk.v0.CancelTaskRequest
+	(*CancelTaskResponse)(nil),                            // 11: bonk.v0.CancelTaskResponse
+	(*ExecutionError)(nil),                                // 12: bonk.v0.ExecutionError
+	(*BuildTask)(nil),                                     // 13: bonk.v0.BuildTask
+	(*Condition)(nil),                                     // 14: bonk.v0.Condition
+	(*OutputPatterns)(nil),                                // 15: bonk.v0.OutputPatterns
+	(*MatrixValues)(nil),                                  // 16: bonk.v0.MatrixValues
+	(*RetryPolicy)(nil),                                   // 17: bonk.v0.RetryPolicy
+	(*SubmitBuildRequest)(nil),                            // 18: bonk.v0.SubmitBuildRequest
+	(*Facts)(nil),                                         // 19: bonk.v0.Facts
+	(*BuildEvent)(nil),                                    // 20: bonk.v0.BuildEvent
+	(*CancelBuildRequest)(nil),                            // 21: bonk.v0.CancelBuildRequest
+	(*CancelBuildResponse)(nil),                           // 22: bonk.v0.CancelBuildResponse
+	(*WorkspaceCall)(nil),                                 // 23: bonk.v0.WorkspaceCall
+	(*WorkspaceReply)(nil),                                // 24: bonk.v0.WorkspaceReply
+	(*OpenSessionRequest_LogStreamingOptions)(nil),        // 25: bonk.v0.OpenSessionRequest.LogStreamingOptions
+	(*OpenSessionRequest_WorkspaceDescriptionLocal)(nil),  // 26: bonk.v0.OpenSessionRequest.WorkspaceDescriptionLocal
+	(*OpenSessionRequest_WorkspaceDescriptionRemote)(nil), // 27: bonk.v0.OpenSessionRequest.WorkspaceDescriptionRemote
+	(*OpenSessionRequest_WorkspaceDescriptionTest)(nil),   // 28: bonk.v0.OpenSessionRequest.WorkspaceDescriptionTest
+	(*OpenSessionResponse_Ack)(nil),                       // 29: bonk.v0.OpenSessionResponse.Ack
+	(*OpenSessionResponse_LogRecord)(nil),                 // 30: bonk.v0.OpenSessionResponse.LogRecord
+	nil,                                                   // 31: bonk.v0.OpenSessionResponse.LogRecord.AttrsEntry
+	(*DescribeResponse_Executor)(nil),                     // 32: bonk.v0.DescribeResponse.Executor
+	(*ExecutionError_Position)(nil),                       // 33: bonk.v0.ExecutionError.Position
+	nil,                                                   // 34: bonk.v0.BuildTask.ResourcesEntry
+	nil,                                                   // 35: bonk.v0.BuildTask.MatrixEntry
+	nil,                                                   // 36: bonk.v0.Condition.EnvEntry
+	nil,                                                   // 37: bonk.v0.Condition.ResultsEntry
+	nil,                                                   // 38: bonk.v0.Condition.OutputsEntry
+	(*SubmitBuildRequest_Session)(nil),                    // 39: bonk.v0.SubmitBuildRequest.Session
+	nil,                                                   // 40: bonk.v0.Facts.EnvEntry
+	(*BuildEvent_Started)(nil),                            // 41: bonk.v0.BuildEvent.Started
+	(*BuildEvent_TaskStatus)(nil),                         // 42: bonk.v0.BuildEvent.TaskStatus
+	(*BuildEvent_Finished)(nil),                           // 43: bonk.v0.BuildEvent.Finished
+	(*WorkspaceCall_Ack)(nil),                             // 44: bonk.v0.WorkspaceCall.Ack
+	(*WorkspaceCall_Stat)(nil),                            // 45: bonk.v0.WorkspaceCall.Stat
+	(*WorkspaceCall_ReadDir)(nil),                         // 46: bonk.v0.WorkspaceCall.ReadDir
+	(*WorkspaceCall_ReadFile)(nil),                        // 47: bonk.v0.WorkspaceCall.ReadFile
+	(*WorkspaceCall_WriteFile)(nil),                       // 48: bonk.v0.WorkspaceCall.WriteFile
+	(*WorkspaceCall_Mkdir)(nil),                           // 49: bonk.v0.WorkspaceCall.Mkdir
+	(*WorkspaceCall_Remove)(nil),                          // 50: bonk.v0.WorkspaceCall.Remove
+	(*WorkspaceCall_Rename)(nil),                          // 51: bonk.v0.WorkspaceCall.Rename
+	(*WorkspaceReply_Attach)(nil),                         // 52: bonk.v0.WorkspaceReply.Attach
+	(*WorkspaceReply_FileInfo)(nil),                       // 53: bonk.v0.WorkspaceReply.FileInfo
+	(*WorkspaceReply_DirEntries)(nil),                     // 54: bonk.v0.WorkspaceReply.DirEntries
+	(*WorkspaceReply_Content)(nil),                        // 55: bonk.v0.WorkspaceReply.Content
+	(*WorkspaceReply_Done)(nil),                           // 56: bonk.v0.WorkspaceReply.Done
+	(*WorkspaceReply_Error)(nil),                          // 57: bonk.v0.WorkspaceReply.Error
+	(*structpb.Value)(nil),                                // 58: google.protobuf.Value
+	(*durationpb.Duration)(nil),                           // 59: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),                         // 60: google.protobuf.Timestamp
 }
 var file_bonk_v0_bonk_proto_depIdxs = []int32{
-	6,  // 0: bonk.v0.OpenSessionRequest.log_streaming:type_name -> bonk.v0.OpenSessionRequest.LogStreamingOptions
-	7,  // 1: bonk.v0.OpenSessionRequest.local:type_name -> bonk.v0.OpenSessionRequest.WorkspaceDescriptionLocal
-	8,  // 2: bonk.v0.OpenSessionRequest.test:type_name -> bonk.v0.OpenSessionRequest.WorkspaceDescriptionTest
-	9,  // 3: bonk.v0.OpenSessionResponse.ack:type_name -> bonk.v0.OpenSessionResponse.Ack
-	10, // 4: bonk.v0.OpenSessionResponse.log_record:type_name -> bonk.v0.OpenSessionResponse.LogRecord
-	13, // 5: bonk.v0.ExecuteTaskRequest.arguments:type_name -> google.protobuf.Value
-	12, // 6: bonk.v0.ExecuteTaskResponse.followup_tasks:type_name -> bonk.v0.ExecuteTaskResponse.FollowupTask
-	14, // 7: bonk.v0.OpenSessionResponse.LogRecord.time:type_name -> google.protobuf.Timestamp
-	11, // 8: bonk.v0.OpenSessionResponse.LogRecord.attrs:type_name -> bonk.v0.OpenSessionResponse.LogRecord.AttrsEntry
-	13, // 9: bonk.v0.OpenSessionResponse.LogRecord.AttrsEntry.value:type_name -> google.protobuf.Value
-	13, // 10: bonk.v0.ExecuteTaskResponse.FollowupTask.arguments:type_name -> google.protobuf.Value
-	0,  // 11: bonk.v0.ExecutorService.OpenSession:input_type -> bonk.v0.OpenSessionRequest
-	2,  // 12: bonk.v0.ExecutorService.CloseSession:input_type -> bonk.v0.CloseSessionRequest
-	4,  // 13: bonk.v0.ExecutorService.ExecuteTask:input_type -> bonk.v0.ExecuteTaskRequest
-	1,  // 14: bonk.v0.ExecutorService.OpenSession:output_type -> bonk.v0.OpenSessionResponse
-	3,  // 15: bonk.v0.ExecutorService.CloseSession:output_type -> bonk.v0.CloseSessionResponse
-	5,  // 16: bonk.v0.ExecutorService.ExecuteTask:output_type -> bonk.v0.ExecuteTaskResponse
-	14, // [14:17] is the sub-list for method output_type
-	11, // [11:14] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	25, // 0: bonk.v0.OpenSessionRequest.log_streaming:type_name -> bonk.v0.OpenSessionRequest.LogStreamingOptions
+	26, // 1: bonk.v0.OpenSessionRequest.local:type_name -> bonk.v0.OpenSessionRequest.WorkspaceDescriptionLocal
+	27, // 2: bonk.v0.OpenSessionRequest.remote:type_name -> bonk.v0.OpenSessionRequest.WorkspaceDescriptionRemote
+	28, // 3: bonk.v0.OpenSessionRequest.test:type_name -> bonk.v0.OpenSessionRequest.WorkspaceDescriptionTest
+	29, // 4: bonk.v0.OpenSessionResponse.ack:type_name -> bonk.v0.OpenSessionResponse.Ack
+	30, // 5: bonk.v0.OpenSessionResponse.log_record:type_name -> bonk.v0.OpenSessionResponse.LogRecord
+	58, // 6: bonk.v0.ExecuteTaskRequest.arguments:type_name -> google.protobuf.Value
+	13, // 7: bonk.v0.ExecuteTaskResponse.followup_tasks:type_name -> bonk.v0.BuildTask
+	32, // 8: bonk.v0.DescribeResponse.executors:type_name -> bonk.v0.DescribeResponse.Executor
+	33, // 9: bonk.v0.ExecutionError.positions:type_name -> bonk.v0.ExecutionError.Position
+	12, // 10: bonk.v0.ExecutionError.causes:type_name -> bonk.v0.ExecutionError
+	58, // 11: bonk.v0.BuildTask.arguments:type_name -> google.protobuf.Value
+	34, // 12: bonk.v0.BuildTask.resources:type_name -> bonk.v0.BuildTask.ResourcesEntry
+	59, // 13: bonk.v0.BuildTask.timeout:type_name -> google.protobuf.Duration
+	17, // 14: bonk.v0.BuildTask.retry:type_name -> bonk.v0.RetryPolicy
+	35, // 15: bonk.v0.BuildTask.matrix:type_name -> bonk.v0.BuildTask.MatrixEntry
+	14, // 16: bonk.v0.BuildTask.when:type_name -> bonk.v0.Condition
+	36, // 17: bonk.v0.Condition.env:type_name -> bonk.v0.Condition.EnvEntry
+	37, // 18: bonk.v0.Condition.results:type_name -> bonk.v0.Condition.ResultsEntry
+	14, // 19: bonk.v0.Condition.not:type_name -> bonk.v0.Condition
+	38, // 20: bonk.v0.Condition.outputs:type_name -> bonk.v0.Condition.OutputsEntry
+	59, // 21: bonk.v0.RetryPolicy.backoff:type_name -> google.protobuf.Duration
+	59, // 22: bonk.v0.RetryPolicy.max_backoff:type_name -> google.protobuf.Duration
+	39, // 23: bonk.v0.SubmitBuildRequest.sessions:type_name -> bonk.v0.SubmitBuildRequest.Session
+	19, // 24: bonk.v0.SubmitBuildRequest.facts:type_name -> bonk.v0.Facts
+	40, // 25: bonk.v0.Facts.env:type_name -> bonk.v0.Facts.EnvEntry
+	41, // 26: bonk.v0.BuildEvent.started:type_name -> bonk.v0.BuildEvent.Started
+	42, // 27: bonk.v0.BuildEvent.task_status:type_name -> bonk.v0.BuildEvent.TaskStatus
+	43, // 28: bonk.v0.BuildEvent.finished:type_name -> bonk.v0.BuildEvent.Finished
+	44, // 29: bonk.v0.WorkspaceCall.ack:type_name -> bonk.v0.WorkspaceCall.Ack
+	45, // 30: bonk.v0.WorkspaceCall.stat:type_name -> bonk.v0.WorkspaceCall.Stat
+	46, // 31: bonk.v0.WorkspaceCall.read_dir:type_name -> bonk.v0.WorkspaceCall.ReadDir
+	47, // 32: bonk.v0.WorkspaceCall.read_file:type_name -> bonk.v0.WorkspaceCall.ReadFile
+	48, // 33: bonk.v0.WorkspaceCall.write_file:type_name -> bonk.v0.WorkspaceCall.WriteFile
+	49, // 34: bonk.v0.WorkspaceCall.mkdir:type_name -> bonk.v0.WorkspaceCall.Mkdir
+	50, // 35: bonk.v0.WorkspaceCall.remove:type_name -> bonk.v0.WorkspaceCall.Remove
+	51, // 36: bonk.v0.WorkspaceCall.rename:type_name -> bonk.v0.WorkspaceCall.Rename
+	52, // 37: bonk.v0.WorkspaceReply.attach:type_name -> bonk.v0.WorkspaceReply.Attach
+	53, // 38: bonk.v0.WorkspaceReply.info:type_name -> bonk.v0.WorkspaceReply.FileInfo
+	54, // 39: bonk.v0.WorkspaceReply.entries:type_name -> bonk.v0.WorkspaceReply.DirEntries
+	55, // 40: bonk.v0.WorkspaceReply.content:type_name -> bonk.v0.WorkspaceReply.Content
+	56, // 41: bonk.v0.WorkspaceReply.done:type_name -> bonk.v0.WorkspaceReply.Done
+	57, // 42: bonk.v0.WorkspaceReply.error:type_name -> bonk.v0.WorkspaceReply.Error
+	60, // 43: bonk.v0.OpenSessionResponse.LogRecord.time:type_name -> google.protobuf.Timestamp
+	31, // 44: bonk.v0.OpenSessionResponse.LogRecord.attrs:type_name -> bonk.v0.OpenSessionResponse.LogRecord.AttrsEntry
+	58, // 45: bonk.v0.OpenSessionResponse.LogRecord.AttrsEntry.value:type_name -> google.protobuf.Value
+	16, // 46: bonk.v0.BuildTask.MatrixEntry.value:type_name -> bonk.v0.MatrixValues
+	15, // 47: bonk.v0.Condition.OutputsEntry.value:type_name -> bonk.v0.OutputPatterns
+	13, // 48: bonk.v0.SubmitBuildRequest.Session.tasks:type_name -> bonk.v0.BuildTask
+	60, // 49: bonk.v0.BuildEvent.TaskStatus.time:type_name -> google.protobuf.Timestamp
+	58, // 50: bonk.v0.BuildEvent.TaskStatus.arguments:type_name -> google.protobuf.Value
+	12, // 51: bonk.v0.BuildEvent.TaskStatus.error:type_name -> bonk.v0.ExecutionError
+	12, // 52: bonk.v0.BuildEvent.Finished.error:type_name -> bonk.v0.ExecutionError
+	0,  // 53: bonk.v0.WorkspaceCall.Stat.root:type_name -> bonk.v0.WorkspaceCall.Root
+	0,  // 54: bonk.v0.WorkspaceCall.ReadDir.root:type_name -> bonk.v0.WorkspaceCall.Root
+	0,  // 55: bonk.v0.WorkspaceCall.ReadFile.root:type_name -> bonk.v0.WorkspaceCall.Root
+	0,  // 56: bonk.v0.WorkspaceCall.WriteFile.root:type_name -> bonk.v0.WorkspaceCall.Root
+	0,  // 57: bonk.v0.WorkspaceCall.Mkdir.root:type_name -> bonk.v0.WorkspaceCall.Root
+	0,  // 58: bonk.v0.WorkspaceCall.Remove.root:type_name -> bonk.v0.WorkspaceCall.Root
+	0,  // 59: bonk.v0.WorkspaceCall.Rename.root:type_name -> bonk.v0.WorkspaceCall.Root
+	60, // 60: bonk.v0.WorkspaceReply.FileInfo.mod_time:type_name -> google.protobuf.Timestamp
+	53, // 61: bonk.v0.WorkspaceReply.DirEntries.entries:type_name -> bonk.v0.WorkspaceReply.FileInfo
+	1,  // 62: bonk.v0.WorkspaceReply.Error.kind:type_name -> bonk.v0.WorkspaceReply.Error.Kind
+	2,  // 63: bonk.v0.ExecutorService.OpenSession:input_type -> bonk.v0.OpenSessionRequest
+	4,  // 64: bonk.v0.ExecutorService.CloseSession:input_type -> bonk.v0.CloseSessionRequest
+	6,  // 65: bonk.v0.ExecutorService.ExecuteTask:input_type -> bonk.v0.ExecuteTaskRequest
+	10, // 66: bonk.v0.ExecutorService.CancelTask:input_type -> bonk.v0.CancelTaskRequest
+	8,  // 67: bonk.v0.ExecutorService.Describe:input_type -> bonk.v0.DescribeRequest
+	24, // 68: bonk.v0.ExecutorService.Workspace:input_type -> bonk.v0.WorkspaceReply
+	18, // 69: bonk.v0.BuildService.SubmitBuild:input_type -> bonk.v0.SubmitBuildRequest
+	21, // 70: bonk.v0.BuildService.CancelBuild:input_type -> bonk.v0.CancelBuildRequest
+	3,  // 71: bonk.v0.ExecutorService.OpenSession:output_type -> bonk.v0.OpenSessionResponse
+	5,  // 72: bonk.v0.ExecutorService.CloseSession:output_type -> bonk.v0.CloseSessionResponse
+	7,  // 73: bonk.v0.ExecutorService.ExecuteTask:output_type -> bonk.v0.ExecuteTaskResponse
+	11, // 74: bonk.v0.ExecutorService.CancelTask:output_type -> bonk.v0.CancelTaskResponse
+	9,  // 75: bonk.v0.ExecutorService.Describe:output_type -> bonk.v0.DescribeResponse
+	23, // 76: bonk.v0.ExecutorService.Workspace:output_type -> bonk.v0.WorkspaceCall
+	20, // 77: bonk.v0.BuildService.SubmitBuild:output_type -> bonk.v0.BuildEvent
+	22, // 78: bonk.v0.BuildService.CancelBuild:output_type -> bonk.v0.CancelBuildResponse
+	71, // [71:79] is the sub-list for method output_type
+	63, // [63:71] is the sub-list for method input_type
+	63, // [63:63] is the sub-list for extension type_name
+	63, // [63:63] is the sub-list for extension extendee
+	0,  // [0:63] is the sub-list for field type_name
 }
 
 func init() { file_bonk_v0_bonk_proto_init() }
@@ -1479,24 +6971,49 @@ func file_bonk_v0_bonk_proto_init() {
 	}
 	file_bonk_v0_bonk_proto_msgTypes[0].OneofWrappers = []any{
 		(*openSessionRequest_Local)(nil),
+		(*openSessionRequest_Remote)(nil),
 		(*openSessionRequest_Test)(nil),
 	}
 	file_bonk_v0_bonk_proto_msgTypes[1].OneofWrappers = []any{
 		(*openSessionResponse_Ack_)(nil),
 		(*openSessionResponse_LogRecord_)(nil),
 	}
+	file_bonk_v0_bonk_proto_msgTypes[18].OneofWrappers = []any{
+		(*buildEvent_Started_)(nil),
+		(*buildEvent_TaskStatus_)(nil),
+		(*buildEvent_Finished_)(nil),
+	}
+	file_bonk_v0_bonk_proto_msgTypes[21].OneofWrappers = []any{
+		(*workspaceCall_Ack_)(nil),
+		(*workspaceCall_Stat_)(nil),
+		(*workspaceCall_ReadDir_)(nil),
+		(*workspaceCall_ReadFile_)(nil),
+		(*workspaceCall_WriteFile_)(nil),
+		(*workspaceCall_Mkdir_)(nil),
+		(*workspaceCall_Remove_)(nil),
+		(*workspaceCall_Rename_)(nil),
+	}
+	file_bonk_v0_bonk_proto_msgTypes[22].OneofWrappers = []any{
+		(*workspaceReply_Attach_)(nil),
+		(*workspaceReply_Info)(nil),
+		(*workspaceReply_Entries)(nil),
+		(*workspaceReply_Content_)(nil),
+		(*workspaceReply_Done_)(nil),
+		(*workspaceReply_Error_)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bonk_v0_bonk_proto_rawDesc), len(file_bonk_v0_bonk_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   13,
+			NumEnums:      2,
+			NumMessages:   56,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_bonk_v0_bonk_proto_goTypes,
 		DependencyIndexes: file_bonk_v0_bonk_proto_depIdxs,
+		EnumInfos:         file_bonk_v0_bonk_proto_enumTypes,
 		MessageInfos:      file_bonk_v0_bonk_proto_msgTypes,
 	}.Build()
 	File_bonk_v0_bonk_proto = out.File
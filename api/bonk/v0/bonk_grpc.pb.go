@@ -25,6 +25,9 @@ const (
 	ExecutorService_OpenSession_FullMethodName  = "/bonk.v0.ExecutorService/OpenSession"
 	ExecutorService_CloseSession_FullMethodName = "/bonk.v0.ExecutorService/CloseSession"
 	ExecutorService_ExecuteTask_FullMethodName  = "/bonk.v0.ExecutorService/ExecuteTask"
+	ExecutorService_CancelTask_FullMethodName   = "/bonk.v0.ExecutorService/CancelTask"
+	ExecutorService_Describe_FullMethodName     = "/bonk.v0.ExecutorService/Describe"
+	ExecutorService_Workspace_FullMethodName    = "/bonk.v0.ExecutorService/Workspace"
 )
 
 // ExecutorServiceClient is the client API for ExecutorService service.
@@ -36,6 +39,12 @@ type ExecutorServiceClient interface {
 	CloseSession(ctx context.Context, in *CloseSessionRequest, opts ...grpc.CallOption) (*CloseSessionResponse, error)
 	// Executor interface
 	ExecuteTask(ctx context.Context, in *ExecuteTaskRequest, opts ...grpc.CallOption) (*ExecuteTaskResponse, error)
+	// Cancels the context of a running ExecuteTask call, leaving the call to return once the executor stops.
+	CancelTask(ctx context.Context, in *CancelTaskRequest, opts ...grpc.CallOption) (*CancelTaskResponse, error)
+	// Introspection
+	Describe(ctx context.Context, in *DescribeRequest, opts ...grpc.CallOption) (*DescribeResponse, error)
+	// Serves a remote workspace to the executor, which sends calls for the client to reply to.
+	Workspace(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[WorkspaceReply, WorkspaceCall], error)
 }
 
 type executorServiceClient struct {
@@ -85,6 +94,39 @@ func (c *executorServiceClient) ExecuteTask(ctx context.Context, in *ExecuteTask
 	return out, nil
 }
 
+func (c *executorServiceClient) CancelTask(ctx context.Context, in *CancelTaskRequest, opts ...grpc.CallOption) (*CancelTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelTaskResponse)
+	err := c.cc.Invoke(ctx, ExecutorService_CancelTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executorServiceClient) Describe(ctx context.Context, in *DescribeRequest, opts ...grpc.CallOption) (*DescribeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DescribeResponse)
+	err := c.cc.Invoke(ctx, ExecutorService_Describe_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executorServiceClient) Workspace(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[WorkspaceReply, WorkspaceCall], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ExecutorService_ServiceDesc.Streams[1], ExecutorService_Workspace_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WorkspaceReply, WorkspaceCall]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ExecutorService_WorkspaceClient = grpc.BidiStreamingClient[WorkspaceReply, WorkspaceCall]
+
 // ExecutorServiceServer is the server API for ExecutorService service.
 // All implementations must embed UnimplementedExecutorServiceServer
 // for forward compatibility.
@@ -94,6 +136,12 @@ type ExecutorServiceServer interface {
 	CloseSession(context.Context, *CloseSessionRequest) (*CloseSessionResponse, error)
 	// Executor interface
 	ExecuteTask(context.Context, *ExecuteTaskRequest) (*ExecuteTaskResponse, error)
+	// Cancels the context of a running ExecuteTask call, leaving the call to return once the executor stops.
+	CancelTask(context.Context, *CancelTaskRequest) (*CancelTaskResponse, error)
+	// Introspection
+	Describe(context.Context, *DescribeRequest) (*DescribeResponse, error)
+	// Serves a remote workspace to the executor, which sends calls for the client to reply to.
+	Workspace(grpc.BidiStreamingServer[WorkspaceReply, WorkspaceCall]) error
 	mustEmbedUnimplementedExecutorServiceServer()
 }
 
@@ -113,6 +161,15 @@ func (UnimplementedExecutorServiceServer) CloseSession(context.Context, *CloseSe
 func (UnimplementedExecutorServiceServer) ExecuteTask(context.Context, *ExecuteTaskRequest) (*ExecuteTaskResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ExecuteTask not implemented")
 }
+func (UnimplementedExecutorServiceServer) CancelTask(context.Context, *CancelTaskRequest) (*CancelTaskResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelTask not implemented")
+}
+func (UnimplementedExecutorServiceServer) Describe(context.Context, *DescribeRequest) (*DescribeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Describe not implemented")
+}
+func (UnimplementedExecutorServiceServer) Workspace(grpc.BidiStreamingServer[WorkspaceReply, WorkspaceCall]) error {
+	return status.Error(codes.Unimplemented, "method Workspace not implemented")
+}
 func (UnimplementedExecutorServiceServer) mustEmbedUnimplementedExecutorServiceServer() {}
 func (UnimplementedExecutorServiceServer) testEmbeddedByValue()                         {}
 
@@ -181,6 +238,49 @@ func _ExecutorService_ExecuteTask_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ExecutorService_CancelTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutorServiceServer).CancelTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExecutorService_CancelTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutorServiceServer).CancelTask(ctx, req.(*CancelTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExecutorService_Describe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DescribeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutorServiceServer).Describe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExecutorService_Describe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutorServiceServer).Describe(ctx, req.(*DescribeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExecutorService_Workspace_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ExecutorServiceServer).Workspace(&grpc.GenericServerStream[WorkspaceReply, WorkspaceCall]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ExecutorService_WorkspaceServer = grpc.BidiStreamingServer[WorkspaceReply, WorkspaceCall]
+
 // ExecutorService_ServiceDesc is the grpc.ServiceDesc for ExecutorService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -196,6 +296,14 @@ var ExecutorService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ExecuteTask",
 			Handler:    _ExecutorService_ExecuteTask_Handler,
 		},
+		{
+			MethodName: "CancelTask",
+			Handler:    _ExecutorService_CancelTask_Handler,
+		},
+		{
+			MethodName: "Describe",
+			Handler:    _ExecutorService_Describe_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -203,6 +311,160 @@ var ExecutorService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _ExecutorService_OpenSession_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Workspace",
+			Handler:       _ExecutorService_Workspace_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "bonk/v0/bonk.proto",
+}
+
+const (
+	BuildService_SubmitBuild_FullMethodName = "/bonk.v0.BuildService/SubmitBuild"
+	BuildService_CancelBuild_FullMethodName = "/bonk.v0.BuildService/CancelBuild"
+)
+
+// BuildServiceClient is the client API for BuildService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BuildServiceClient interface {
+	// Executes the tasks of each session, streaming their statuses until the build finishes.
+	SubmitBuild(ctx context.Context, in *SubmitBuildRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BuildEvent], error)
+	// Cancels a running build, which keeps streaming while its tasks stop.
+	CancelBuild(ctx context.Context, in *CancelBuildRequest, opts ...grpc.CallOption) (*CancelBuildResponse, error)
+}
+
+type buildServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBuildServiceClient(cc grpc.ClientConnInterface) BuildServiceClient {
+	return &buildServiceClient{cc}
+}
+
+func (c *buildServiceClient) SubmitBuild(ctx context.Context, in *SubmitBuildRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BuildEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BuildService_ServiceDesc.Streams[0], BuildService_SubmitBuild_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubmitBuildRequest, BuildEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BuildService_SubmitBuildClient = grpc.ServerStreamingClient[BuildEvent]
+
+func (c *buildServiceClient) CancelBuild(ctx context.Context, in *CancelBuildRequest, opts ...grpc.CallOption) (*CancelBuildResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelBuildResponse)
+	err := c.cc.Invoke(ctx, BuildService_CancelBuild_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BuildServiceServer is the server API for BuildService service.
+// All implementations must embed UnimplementedBuildServiceServer
+// for forward compatibility.
+type BuildServiceServer interface {
+	// Executes the tasks of each session, streaming their statuses until the build finishes.
+	SubmitBuild(*SubmitBuildRequest, grpc.ServerStreamingServer[BuildEvent]) error
+	// Cancels a running build, which keeps streaming while its tasks stop.
+	CancelBuild(context.Context, *CancelBuildRequest) (*CancelBuildResponse, error)
+	mustEmbedUnimplementedBuildServiceServer()
+}
+
+// UnimplementedBuildServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBuildServiceServer struct{}
+
+func (UnimplementedBuildServiceServer) SubmitBuild(*SubmitBuildRequest, grpc.ServerStreamingServer[BuildEvent]) error {
+	return status.Error(codes.Unimplemented, "method SubmitBuild not implemented")
+}
+func (UnimplementedBuildServiceServer) CancelBuild(context.Context, *CancelBuildRequest) (*CancelBuildResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelBuild not implemented")
+}
+func (UnimplementedBuildServiceServer) mustEmbedUnimplementedBuildServiceServer() {}
+func (UnimplementedBuildServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeBuildServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BuildServiceServer will
+// result in compilation errors.
+type UnsafeBuildServiceServer interface {
+	mustEmbedUnimplementedBuildServiceServer()
+}
+
+func RegisterBuildServiceServer(s grpc.ServiceRegistrar, srv BuildServiceServer) {
+	// If the following call panics, it indicates UnimplementedBuildServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&BuildService_ServiceDesc, srv)
+}
+
+func _BuildService_SubmitBuild_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubmitBuildRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BuildServiceServer).SubmitBuild(m, &grpc.GenericServerStream[SubmitBuildRequest, BuildEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BuildService_SubmitBuildServer = grpc.ServerStreamingServer[BuildEvent]
+
+func _BuildService_CancelBuild_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelBuildRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuildServiceServer).CancelBuild(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BuildService_CancelBuild_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuildServiceServer).CancelBuild(ctx, req.(*CancelBuildRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BuildService_ServiceDesc is the grpc.ServiceDesc for BuildService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BuildService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "bonk.v0.BuildService",
+	HandlerType: (*BuildServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CancelBuild",
+			Handler:    _BuildService_CancelBuild_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubmitBuild",
+			Handler:       _BuildService_SubmitBuild_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "bonk/v0/bonk.proto",
 }
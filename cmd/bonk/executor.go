@@ -0,0 +1,151 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"text/tabwriter"
+
+	"go.uber.org/multierr"
+
+	"github.com/spf13/cobra"
+
+	"go.bonk.build/pkg/driver"
+	"go.bonk.build/pkg/executor/plugin"
+	"go.bonk.build/pkg/executor/remote"
+)
+
+var (
+	listenAddress string
+	tlsCert       string
+	tlsKey        string
+	tlsCA         string
+)
+
+// executorCmd represents the executor command.
+var executorCmd = &cobra.Command{
+	Use:   "executor",
+	Short: "Inspect executors, and serve them to builds on other machines",
+}
+
+// executorRoutesCmd represents the executor routes command.
+var executorRoutesCmd = &cobra.Command{
+	Use:   "routes",
+	Short: "List the routes tasks are routed to executors by",
+	Long: `List the routes tasks are routed to executors by.
+
+Routes are listed in the order they're tried, so each task's executor is routed by the first route matching it.
+Aliases are configured under aliases in the config file, such as:
+
+  aliases:
+    k8s.kustomize: kustomize`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		options, err := buildOptions()
+		if err != nil {
+			return err
+		}
+
+		// Plugins aren't started until a task is executed, so listing routes is cheap
+		engine, err := driver.NewEngine(options)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+		defer engine.Shutdown(context.WithoutCancel(cmd.Context()))
+
+		writer := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0) //nolint:mnd
+		fmt.Fprintln(writer, "PRECEDENCE\tKIND\tROUTE\tTARGET")
+		for _, route := range engine.Routes() {
+			fmt.Fprintf(writer, "%d\t%s\t%s\t%s\n", route.Precedence, route.Kind, route.Pattern, route.Target)
+		}
+
+		return writer.Flush() //nolint:wrapcheck
+	},
+}
+
+// executorServeCmd represents the executor serve command.
+var executorServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the executors of the configured plugins over the network",
+	Long: `Serve the executors of the configured plugins over the network.
+
+Builds route tasks to the served executors by listing them under remotes in their config file, such as:
+
+  remotes:
+    kustomize:
+      address: tcp://build-box:7100
+      tls:
+        cert: client.pem
+        key: client-key.pem
+        ca: ca.pem
+
+Alternatively, the tasks of a route may be distributed across many servers by listing them under workers:
+
+  workers:
+    - address: tcp://worker-1:7100
+      routes: [kustomize]
+      capacity: 4
+    - address: tcp://worker-2:7100
+      routes: [kustomize]
+      capacity: 2
+
+The build's files are served to the executors over the connection, unless shared-workspace is set
+because this machine sees the workspace at the same path.
+Setting --tls-ca requires builds to present a certificate signed by it.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		options, err := buildOptions()
+		if err != nil {
+			return err
+		}
+
+		pcm := plugin.NewPluginClientManager(plugin.NewStore(resolvedPluginDir()))
+		defer pcm.Shutdown(context.WithoutCancel(cmd.Context()))
+
+		// Plugins are started once a build executes a task on them
+		for _, pluginRef := range options.Plugins {
+			prefix := plugin.RefName(pluginRef)
+			multierr.AppendInto(&err, pcm.RegisterPlugin(prefix, pluginRef, options.PluginPools[prefix]))
+		}
+		for prefix, pluginRef := range options.PluginRoutes {
+			multierr.AppendInto(&err, pcm.RegisterPlugin(prefix, pluginRef, options.PluginPools[prefix]))
+		}
+		if err != nil {
+			return err
+		}
+
+		serveOptions := remote.Options{
+			Address: listenAddress,
+		}
+		if tlsCert != "" || tlsKey != "" || tlsCA != "" {
+			serveOptions.TLS = &remote.TLSConfig{
+				CertFile: tlsCert,
+				KeyFile:  tlsKey,
+				CAFile:   tlsCA,
+			}
+		}
+
+		ctx, cancel := driver.NotifyContext(cmd.Context())
+		defer cancel()
+
+		return remote.Serve(ctx, pcm, serveOptions) //nolint:wrapcheck
+	},
+}
+
+func init() {
+	executorServeCmd.Flags().
+		StringVar(&listenAddress, "listen", "tcp://127.0.0.1:7100",
+			"The address to serve executors on, either tcp://host:port or unix:///path/to/socket")
+	executorServeCmd.Flags().
+		StringVar(&tlsCert, "tls-cert", "", "The PEM encoded certificate to serve with TLS")
+	executorServeCmd.Flags().
+		StringVar(&tlsKey, "tls-key", "", "The PEM encoded key of the TLS certificate")
+	executorServeCmd.Flags().
+		StringVar(&tlsCA, "tls-ca", "", "The PEM encoded certificate authority which builds' certificates must be signed by")
+	executorServeCmd.MarkFlagsRequiredTogether("tls-cert", "tls-key")
+
+	executorCmd.AddCommand(executorRoutesCmd, executorServeCmd)
+	rootCmd.AddCommand(executorCmd)
+}
@@ -6,23 +6,52 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
 	"os"
 	"path"
+	"strings"
+	"time"
 
 	"charm.land/fang/v2"
+	"go.uber.org/multierr"
 
+	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	"go.bonk.build/pkg/driver"
+	"go.bonk.build/pkg/executor/middleware"
+	"go.bonk.build/pkg/executor/plugin"
+	"go.bonk.build/pkg/executor/policy"
+	"go.bonk.build/pkg/executor/remote"
+	"go.bonk.build/pkg/executor/scheduler"
 	"go.bonk.build/pkg/observer/bubbletea"
+	"go.bonk.build/pkg/observer/report"
 	"go.bonk.build/pkg/task"
+	"go.bonk.build/pkg/tracing"
 )
 
 var (
 	cfgFile     string
 	concurrency int
+	gracePeriod time.Duration
+	keepOpen    bool
+
+	traceEndpoint string
+	traceFile     string
+	profileFile   string
+
+	profiles []string
+
+	pluginProcesses map[string]int
+	isolatedPlugins []string
+
+	reportJSON  string
+	reportJUnit string
+	summary     bool
+	slowest     int
 )
 
 // rootCmd represents the base command when called without any subcommands.
@@ -31,76 +60,174 @@ var rootCmd = &cobra.Command{
 	Short: "A cue-based configuration build system.",
 
 	RunE: func(cmd *cobra.Command, _ []string) error {
-		cwd, err := os.Getwd()
+		options, err := buildOptions()
 		if err != nil {
-			return err //nolint:wrapcheck
+			return err
 		}
 
-		bubble := bubbletea.New(cmd.Context(), true)
-
-		err = driver.Run(cmd.Context(), nil, driver.MakeDefaultOptions().
-			WithConcurrency(concurrency).
-			WithObservers(bubble.OnTaskStatusMsg).
-			WithPlugins(
-				"go.bonk.build/plugins/test",
-				"go.bonk.build/plugins/k8s/resources",
-				"go.bonk.build/plugins/k8s/kustomize",
-			).
-			WithLocalSession(path.Join(cwd, "testdata"),
-				task.New(
-					task.NewID("Test", "Test"),
-					"test.Test",
-					map[string]any{
-						"value": 3,
-					},
-				),
-				task.New(
-					task.NewID("Test", "Resources"),
-					"resources.Resources",
-					map[string]any{
-						"resources": []map[string]any{
-							{
-								"apiVersion": "v1",
-								"kind":       "Namespace",
-								"metadata": map[string]any{
-									"name": "Testing",
-								},
-							},
-						},
-					},
-				),
-				task.New(
-					task.NewID("Test", "Kustomize"),
-					"kustomize.Kustomize",
-					nil,
-					task.WithInputs(
-						".bonk/Test.Resources/resources.yaml",
-					),
-				),
-			))
-		if err != nil {
-			return err
+		// The UI isn't given the canceled context, so that it can show tasks stopping
+		ctx, cancel := driver.NotifyContext(cmd.Context())
+		defer cancel()
+
+		bubble := bubbletea.New(cmd.Context(), true, cancel)
+		reporter := report.New()
+
+		err = submitBuild(ctx, options, bubble.OnTaskStatusMsg, reporter.OnTaskStatusMsg)
+		if errors.Is(err, errNotSubmitted) {
+			err = driver.Run(ctx, nil, options.WithObservers(bubble.OnTaskStatusMsg, reporter.OnTaskStatusMsg))
+		}
+
+		// The UI has to exit before anything else is printed
+		if keepOpen {
+			bubble.Wait()
+		} else {
+			bubble.Quit()
 		}
 
-		bubble.Quit()
+		// Reports are written even if the build failed, since that's when they're most useful
+		fs := afero.NewOsFs()
+		if reportJSON != "" {
+			multierr.AppendInto(&err, reporter.WriteFile(fs, reportJSON, report.FormatJSON))
+		}
+		if reportJUnit != "" {
+			multierr.AppendInto(&err, reporter.WriteFile(fs, reportJUnit, report.FormatJUnit))
+		}
+		if summary {
+			multierr.AppendInto(&err, reporter.WriteSummary(cmd.OutOrStdout(), slowest))
+		}
 
-		return nil
+		return err
 	},
 }
 
+// buildOptions returns the driver options for the build described by the flags and config file.
+func buildOptions() (driver.Options, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return driver.Options{}, err //nolint:wrapcheck
+	}
+
+	options, err := withConfig(driver.MakeDefaultOptions())
+	if err != nil {
+		return options, err
+	}
+
+	for prefix, size := range pluginProcesses {
+		options = options.WithPluginPool(prefix, plugin.PoolOptions{Size: size})
+	}
+	for _, prefix := range isolatedPlugins {
+		options = options.WithPluginPool(prefix, plugin.PoolOptions{Isolated: true})
+	}
+
+	// Tasks' conditions may check any of the environment variables bonk was run with
+	env := make(map[string]string)
+	for _, variable := range os.Environ() {
+		if name, value, ok := strings.Cut(variable, "="); ok {
+			env[name] = value
+		}
+	}
+
+	return options.
+		WithConcurrency(concurrency).
+		WithFacts(task.Facts{
+			Profiles: profiles,
+			Env:      env,
+		}).
+		WithGracePeriod(gracePeriod).
+		WithPluginDir(resolvedPluginDir()).
+		WithTracing(tracing.Config{
+			ServiceName: "bonk",
+			Endpoint:    traceEndpoint,
+			File:        traceFile,
+		}).
+		WithProfile(profileFile).
+		WithPlugins(
+			"go.bonk.build/plugins/test",
+			"go.bonk.build/plugins/k8s/resources",
+			"go.bonk.build/plugins/k8s/kustomize",
+		).
+		WithLocalSession(path.Join(cwd, "testdata"),
+			task.New(
+				task.NewID("Test", "Test"),
+				"test.Test",
+				map[string]any{
+					"value": 3,
+				},
+			),
+			task.New(
+				task.NewID("Test", "Resources"),
+				"resources.Resources",
+				map[string]any{
+					"resources": []map[string]any{
+						{
+							"apiVersion": "v1",
+							"kind":       "Namespace",
+							"metadata": map[string]any{
+								"name": "Testing",
+							},
+						},
+					},
+				},
+			),
+			task.New(
+				task.NewID("Test", "Kustomize"),
+				"kustomize.Kustomize",
+				nil,
+				task.WithInputs(
+					".bonk/Test.Resources/resources.yaml",
+				),
+			),
+		), nil
+}
+
 func init() {
 	rootCmd.PersistentFlags().
 		StringVarP(&cfgFile, "config", "c", "", "config file (default is .bonk.yaml)")
 	rootCmd.PersistentFlags().
-		IntVarP(&concurrency, "concurrency", "j", 100, "The max number of goroutines to run (negative for no limit)")
+		IntVarP(&concurrency, "concurrency", "j", 100,
+			"The max number of tasks to execute at once (0 or negative for no limit)")
+	rootCmd.PersistentFlags().
+		DurationVar(&gracePeriod, "grace-period", driver.DefaultGracePeriod,
+			"How long tasks have to stop once the build is canceled, before plugins are killed")
+	rootCmd.PersistentFlags().
+		BoolVarP(&keepOpen, "keep-open", "k", false, "Keep the UI open after the build to browse results")
+	rootCmd.PersistentFlags().
+		StringVar(&traceEndpoint, "trace-endpoint", "", "OTLP gRPC endpoint to export traces to (e.g. http://localhost:4317)")
+	rootCmd.PersistentFlags().
+		StringVar(&traceFile, "trace-file", "", "File to write traces to as JSON")
+	rootCmd.MarkFlagsMutuallyExclusive("trace-endpoint", "trace-file")
+	rootCmd.PersistentFlags().
+		StringVar(&profileFile, "profile", "", "File to write a Chrome trace-event profile of the build to")
+	rootCmd.PersistentFlags().
+		StringSliceVar(&profiles, "profiles", nil, "Profiles to select, which tasks' conditions may check (e.g. ci)")
+	rootCmd.PersistentFlags().
+		StringToIntVar(&pluginProcesses, "plugin-processes", nil,
+			"The number of processes to spread a plugin's tasks across, by plugin (e.g. resources=4)")
+	rootCmd.PersistentFlags().
+		StringSliceVar(&isolatedPlugins, "isolated-plugins", nil,
+			"Plugins which run each task in a new process, for untrusted or leaky executors")
+	rootCmd.PersistentFlags().
+		StringVar(&reportJSON, "report-json", "", "File to write a JSON report of task results to")
+	rootCmd.PersistentFlags().
+		StringVar(&reportJUnit, "report-junit", "", "File to write a JUnit XML report of task results to")
+	rootCmd.PersistentFlags().
+		BoolVar(&summary, "summary", true, "Print a summary of the build once it finishes")
+	rootCmd.PersistentFlags().
+		IntVar(&slowest, "slowest", report.DefaultSlowest, "The number of slowest tasks to list in the summary")
+
+	cobra.OnInitialize(initConfig)
+}
 
+// initConfig reads the config file once the flags have been parsed.
+func initConfig() {
 	if cfgFile != "" {
 		// Use config file from the flag.
 		viper.SetConfigFile(cfgFile)
 	} else {
 		// Search config in current directory with name ".bonk.yaml".
 		viper.AddConfigPath(".")
-		viper.SetConfigName(".bonk.yaml")
+		viper.SetConfigName(".bonk")
+		viper.SetConfigType("yaml")
 	}
 
 	viper.AutomaticEnv()
@@ -114,6 +241,100 @@ func init() {
 	}
 }
 
+// executorConfig configures the tasks routed to an executor route.
+type executorConfig struct {
+	scheduler.ExecutorLimits `mapstructure:",squash"`
+	policy.Policy            `mapstructure:",squash"`
+
+	Middleware *middleware.RouteConfig `mapstructure:"middleware"`
+}
+
+// withConfig applies the resource limits, executor limits, policies and middleware, remote executors, aliases
+// and workers from the config file, such as:
+//
+//	resources:
+//	  cpu: 8
+//	  memory: 8
+//	  kube-api: 1
+//	executors:
+//	  kustomize:
+//	    concurrency: 2
+//	    resources:
+//	      cpu: 2
+//	      memory: 4 # heavy, so only two run at once
+//	    timeout: 5m
+//	    retry:
+//	      max-attempts: 3
+//	      backoff: 1s
+//	      retry-on: [deadline-exceeded, unavailable]
+//	  test:
+//	    middleware:
+//	      disable: [statecheck]
+//	remotes:
+//	  resources:
+//	    address: tcp://build-box:7100
+//	aliases:
+//	  k8s.kustomize: kustomize@v2
+//	workers:
+//	  - address: tcp://worker-1:7100
+//	    routes: [kustomize]
+//	    capacity: 4
+func withConfig(options driver.Options) (driver.Options, error) {
+	var (
+		resources map[string]int
+		executors map[string]executorConfig
+		remotes   map[string]remote.Options
+		aliases   map[string]string
+		workers   []driver.WorkerOptions
+	)
+
+	err := viper.UnmarshalKey("resources", &resources)
+	if err != nil {
+		return options, fmt.Errorf("invalid resources in config: %w", err)
+	}
+
+	err = viper.UnmarshalKey("executors", &executors)
+	if err != nil {
+		return options, fmt.Errorf("invalid executors in config: %w", err)
+	}
+
+	err = viper.UnmarshalKey("remotes", &remotes)
+	if err != nil {
+		return options, fmt.Errorf("invalid remotes in config: %w", err)
+	}
+	err = viper.UnmarshalKey("aliases", &aliases)
+	if err != nil {
+		return options, fmt.Errorf("invalid aliases in config: %w", err)
+	}
+	err = viper.UnmarshalKey("workers", &workers)
+	if err != nil {
+		return options, fmt.Errorf("invalid workers in config: %w", err)
+	}
+
+	for name, capacity := range resources {
+		options = options.WithResourceLimit(name, capacity)
+	}
+	for route, executor := range executors {
+		options = options.
+			WithExecutorLimits(route, executor.ExecutorLimits).
+			WithExecutorPolicy(route, executor.Policy)
+		if executor.Middleware != nil {
+			options = options.WithRouteMiddleware(route, *executor.Middleware)
+		}
+	}
+	for prefix, remoteOptions := range remotes {
+		options = options.WithRemoteExecutor(prefix, remoteOptions)
+	}
+	for alias, target := range aliases {
+		options = options.WithAlias(alias, target)
+	}
+	for _, worker := range workers {
+		options = options.WithWorker(worker)
+	}
+
+	return options, nil
+}
+
 func main() {
 	err := fang.Execute(context.Background(), rootCmd)
 	if err != nil {
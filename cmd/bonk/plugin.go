@@ -0,0 +1,140 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"go.bonk.build/pkg/executor/plugin"
+)
+
+var pluginDir string
+
+// pluginCmd represents the plugin command.
+var pluginCmd = &cobra.Command{
+	Use:   "plugin",
+	Short: "Manage installed plugins",
+}
+
+// pluginInstallCmd represents the plugin install command.
+var pluginInstallCmd = &cobra.Command{
+	Use:   "install <package>[@version]...",
+	Short: "Build Go packages into installed plugins",
+	Long: `Build Go packages into installed plugins.
+
+Installed plugins can be referred to by name, or by the package they were installed from,
+and are run without rebuilding or a Go toolchain. Install a plugin again to update it.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store := plugin.NewStore(resolvedPluginDir())
+
+		for _, pkg := range args {
+			installed, err := store.Install(cmd.Context(), pkg)
+			if err != nil {
+				return err //nolint:wrapcheck
+			}
+
+			cmd.Printf("installed %s %s to %s\n", installed.Name, installed.Version, installed.Path)
+		}
+
+		return nil
+	},
+}
+
+// pluginListCmd represents the plugin list command.
+var pluginListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List installed plugins",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		plugins, err := plugin.NewStore(resolvedPluginDir()).List()
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		writer := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0) //nolint:mnd
+		fmt.Fprintln(writer, "NAME\tVERSION\tPACKAGE\tPATH")
+		for _, installed := range plugins {
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", installed.Name, installed.Version, installed.Package, installed.Path)
+		}
+
+		return writer.Flush() //nolint:wrapcheck
+	},
+}
+
+// pluginDescribeCmd represents the plugin describe command.
+var pluginDescribeCmd = &cobra.Command{
+	Use:   "describe <plugin>...",
+	Short: "Describe the executors provided by plugins",
+	Long: `Describe the executors provided by plugins.
+
+Plugins may be referred to by installed name, path or Go package, and are started to ask them for their executors.
+Each executor is printed with the CUE schema of its arguments.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store := plugin.NewStore(resolvedPluginDir())
+
+		for _, ref := range args {
+			name, binary, err := store.Resolve(cmd.Context(), ref)
+			if err != nil {
+				return err //nolint:wrapcheck
+			}
+
+			client, err := plugin.NewPluginClient(cmd.Context(), name, binary)
+			if err != nil {
+				return err //nolint:wrapcheck
+			}
+
+			desc, err := client.Describe(cmd.Context())
+			client.Shutdown()
+
+			if err != nil {
+				return err //nolint:wrapcheck
+			}
+
+			cmd.Printf("%s %s\n", desc.Name, desc.Version)
+			for _, exec := range desc.Executors {
+				cmd.Printf("\n%s.%s\n", name, exec.Name)
+				if exec.Schema != "" {
+					cmd.Println(indent(exec.Schema, "  "))
+				}
+			}
+		}
+
+		return nil
+	},
+}
+
+// indent prefixes each line of text with prefix.
+func indent(text string, prefix string) string {
+	return prefix + strings.ReplaceAll(strings.TrimRight(text, "\n"), "\n", "\n"+prefix)
+}
+
+// resolvedPluginDir returns the directory plugins are installed to, defaulting to the user's cache directory.
+func resolvedPluginDir() string {
+	if pluginDir != "" {
+		return pluginDir
+	}
+
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(cacheDir, "bonk", "plugins")
+}
+
+func init() {
+	rootCmd.PersistentFlags().
+		StringVar(&pluginDir, "plugin-dir", "", "The directory plugins are installed and cached in (default is in the user cache directory)")
+
+	pluginCmd.AddCommand(pluginInstallCmd, pluginListCmd, pluginDescribeCmd)
+	rootCmd.AddCommand(pluginCmd)
+}
@@ -0,0 +1,146 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/spf13/cobra"
+
+	"go.bonk.build/pkg/driver"
+	"go.bonk.build/pkg/executor/observable"
+	"go.bonk.build/pkg/executor/rpc"
+	"go.bonk.build/pkg/server"
+	"go.bonk.build/pkg/task"
+)
+
+var (
+	socketPath  string
+	useServer   bool
+	idleTimeout time.Duration
+)
+
+// serverCmd represents the server command.
+var serverCmd = &cobra.Command{
+	Use:   "server",
+	Short: "Run a build server which keeps plugins and caches warm between builds",
+	Long: `Run a build server which keeps plugins and caches warm between builds.
+
+While a server is listening on the socket, bonk submits builds to it instead of building itself,
+as long as it was started with the same plugins, limits and policies. Otherwise bonk builds locally.
+Each build's profiles and environment are sent with it, and the server shuts down once it's been idle
+for the idle timeout.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		options, err := buildOptions()
+		if err != nil {
+			return err
+		}
+
+		engine, err := driver.NewEngine(options)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		configuration, err := options.Configuration()
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		ctx, cancel := driver.NotifyContext(cmd.Context())
+		defer cancel()
+		defer engine.Shutdown(context.WithoutCancel(ctx))
+
+		return server.Serve(ctx, engine, server.Options{ //nolint:wrapcheck
+			Socket:        resolvedSocketPath(),
+			IdleTimeout:   idleTimeout,
+			Configuration: configuration,
+		})
+	},
+}
+
+// errNotSubmitted is returned by submitBuild when the build should be executed locally instead.
+var errNotSubmitted = errors.New("build not submitted to build server")
+
+// submitBuild submits the build described by options to the running build server, if there's one which
+// builds with the same configuration. Otherwise, it returns errNotSubmitted before any task is executed.
+func submitBuild(ctx context.Context, options driver.Options, observers ...observable.Observer) error {
+	conn := dialServer(ctx)
+	if conn == nil {
+		return errNotSubmitted
+	}
+	defer conn.Close()
+
+	configuration, err := options.Configuration()
+	if err != nil {
+		slog.WarnContext(ctx, "failed to describe build configuration, building locally", "error", err)
+
+		return errNotSubmitted
+	}
+
+	slog.DebugContext(ctx, "submitting build to build server")
+	err = rpc.NewBuildClient(conn, configuration).
+		Build(task.ContextWithFacts(ctx, options.Facts), options.Sessions, nil, observers...)
+	if errors.Is(err, rpc.ErrConfigurationMismatch) {
+		slog.InfoContext(ctx, "build server was started with different options, building locally")
+
+		return errNotSubmitted
+	}
+
+	return err //nolint:wrapcheck
+}
+
+// dialServer connects to the running build server, returning nil if builds shouldn't be submitted to one.
+func dialServer(ctx context.Context) *grpc.ClientConn {
+	// Traces and profiles are recorded by the process running the build
+	if !useServer || traceEndpoint != "" || traceFile != "" || profileFile != "" {
+		return nil
+	}
+
+	conn, err := server.Dial(ctx, resolvedSocketPath())
+	if err != nil {
+		if !errors.Is(err, server.ErrNotRunning) {
+			slog.WarnContext(ctx, "failed to connect to build server, building locally", "error", err)
+		}
+
+		return nil
+	}
+
+	return conn
+}
+
+func resolvedSocketPath() string {
+	if socketPath != "" {
+		return socketPath
+	}
+
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return filepath.Join(os.TempDir(), "bonk", "server.sock")
+	}
+
+	return filepath.Join(cacheDir, "bonk", "server.sock")
+}
+
+func init() {
+	rootCmd.PersistentFlags().
+		StringVar(&socketPath, "socket", "",
+			"The unix socket the build server listens on (default is in the user cache directory)")
+	rootCmd.PersistentFlags().
+		BoolVar(&useServer, "use-server", true,
+			"Submit builds to the build server if one is running, unless tracing or profiling")
+
+	serverCmd.Flags().
+		DurationVar(&idleTimeout, "idle-timeout", server.DefaultIdleTimeout,
+			"How long to wait for a build before shutting down (0 to never shut down)")
+
+	rootCmd.AddCommand(serverCmd)
+}
@@ -0,0 +1,109 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"go.bonk.build/pkg/driver"
+	"go.bonk.build/pkg/observer/bubbletea"
+	"go.bonk.build/pkg/task"
+)
+
+var (
+	watchInterval time.Duration
+	watchDebounce time.Duration
+)
+
+// watchCmd represents the watch command.
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Build, then rebuild whenever source files change",
+	Long: `Build, then rebuild whenever source files change.
+
+Plugins and caches stay warm between builds. Only the tasks whose inputs changed are rebuilt,
+along with the tasks depending on them, while changing a CUE file reloads the tasks and rebuilds everything.
+Changes to plugins, limits and policies only apply once bonk watch is restarted.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		options, err := buildOptions()
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := driver.NotifyContext(cmd.Context())
+		defer cancel()
+
+		bubble := bubbletea.New(cmd.Context(), true, cancel)
+
+		watchOptions := driver.MakeDefaultWatchOptions()
+		watchOptions.Interval = watchInterval
+		watchOptions.Debounce = watchDebounce
+		watchOptions.Reload = reloadSession(options)
+
+		err = driver.Watch(ctx, options.WithObservers(bubble.OnTaskStatusMsg), watchOptions)
+
+		bubble.Quit()
+
+		return err
+	},
+}
+
+// errSessionRemoved is returned when reloading a session which the config no longer describes.
+var errSessionRemoved = errors.New("session is no longer configured")
+
+// reloadSession returns a function which reloads the config, returning the tasks of the session
+// with the same workspace. The plugins and limits of the running build can't change.
+func reloadSession(
+	options driver.Options,
+) func(ctx context.Context, session task.Session) ([]*task.Task, error) {
+	configuration, _ := options.Configuration()
+
+	return func(ctx context.Context, session task.Session) ([]*task.Task, error) {
+		err := viper.ReadInConfig()
+		if err != nil && !errors.As(err, &viper.ConfigFileNotFoundError{}) {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+
+		reloaded, err := buildOptions()
+		if err != nil {
+			return nil, err
+		}
+
+		if reloadedConfiguration, _ := reloaded.Configuration(); reloadedConfiguration != configuration {
+			slog.WarnContext(ctx, "plugins, limits or policies changed, restart bonk watch to apply them")
+		}
+
+		localSession, ok := session.(task.LocalSession)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", errSessionRemoved, session.ID())
+		}
+
+		for reloadedSession, tasks := range reloaded.Sessions {
+			if reloadedLocal, ok := reloadedSession.(task.LocalSession); ok &&
+				reloadedLocal.LocalPath() == localSession.LocalPath() {
+				return tasks, nil
+			}
+		}
+
+		return nil, fmt.Errorf("%w: %s", errSessionRemoved, localSession.LocalPath())
+	}
+}
+
+func init() {
+	watchCmd.Flags().
+		DurationVar(&watchInterval, "interval", driver.DefaultWatchInterval, "How often to check source files for changes")
+	watchCmd.Flags().
+		DurationVar(&watchDebounce, "debounce", driver.DefaultWatchDebounce,
+			"How long files must stop changing before rebuilding")
+
+	rootCmd.AddCommand(watchCmd)
+}
@@ -7,6 +7,7 @@ import (
 	"log/slog"
 	"os"
 	"path"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -19,6 +20,8 @@ import (
 var (
 	platform    string
 	concurrency int
+	gracePeriod time.Duration
+	keepOpen    bool
 )
 
 // rootCmd represents the base command when called without any subcommands.
@@ -33,11 +36,16 @@ var rootCmd = &cobra.Command{
 			sessionDir = path.Join(sessionDir, args[0])
 		}
 
-		bubble := bubbletea.New(cmd.Context(), true)
+		// The UI isn't given the canceled context, so that it can show tasks stopping
+		ctx, cancel := driver.NotifyContext(cmd.Context())
+		defer cancel()
+
+		bubble := bubbletea.New(cmd.Context(), true, cancel)
 
 		var result task.Result
-		err := driver.Run(cmd.Context(), &result, driver.MakeDefaultOptions().
+		err := driver.Run(ctx, &result, driver.MakeDefaultOptions().
 			WithConcurrency(concurrency).
+			WithGracePeriod(gracePeriod).
 			WithObservers(bubble.OnTaskStatusMsg).
 			WithExecutor(holos.Plugin.Name(), holos.Plugin).
 			WithPlugins(
@@ -58,7 +66,11 @@ var rootCmd = &cobra.Command{
 			return err
 		}
 
-		bubble.Quit()
+		if keepOpen {
+			bubble.Wait()
+		} else {
+			bubble.Quit()
+		}
 
 		return nil
 	},
@@ -68,7 +80,12 @@ func init() {
 	rootCmd.PersistentFlags().
 		StringVarP(&platform, "platform", "p", "platform", "The default platform directory to use")
 	rootCmd.PersistentFlags().
-		IntVarP(&concurrency, "concurrency", "j", 100, "The number of goroutines to run") //nolint:mnd
+		IntVarP(&concurrency, "concurrency", "j", 100, "The max number of tasks to execute at once") //nolint:mnd
+	rootCmd.PersistentFlags().
+		DurationVar(&gracePeriod, "grace-period", driver.DefaultGracePeriod,
+			"How long tasks have to stop once the build is canceled, before plugins are killed")
+	rootCmd.PersistentFlags().
+		BoolVarP(&keepOpen, "keep-open", "k", false, "Keep the UI open after the build to browse results")
 }
 
 func main() {
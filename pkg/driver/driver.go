@@ -6,68 +6,92 @@ package driver
 
 import (
 	"fmt"
+	"log/slog"
+	"os/signal"
+	"syscall"
+	"time"
 
-	"go.uber.org/multierr"
+	"github.com/spf13/afero"
 
 	context "context"
 
-	"go.bonk.build/pkg/executor"
-	"go.bonk.build/pkg/executor/observable"
-	"go.bonk.build/pkg/executor/plugin"
-	"go.bonk.build/pkg/executor/scheduler"
-	"go.bonk.build/pkg/executor/statecheck"
+	"go.bonk.build/pkg/profile"
 	"go.bonk.build/pkg/task"
+	"go.bonk.build/pkg/tracing"
 )
 
+// NotifyContext returns a copy of ctx which is canceled when the process is interrupted or terminated.
+// Once canceled, the default signal behavior is restored so that a second signal kills the process.
+func NotifyContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	context.AfterFunc(ctx, stop)
+
+	return ctx, stop
+}
+
 func Run(ctx context.Context, result *task.Result, options Options) error {
-	pcm := plugin.NewPluginClientManager()
-	err := pcm.StartPlugins(ctx, options.Plugins...)
-	if err != nil {
-		return fmt.Errorf("failed to initialize plugins: %w", err)
-	}
+	return run(ctx, options, func(ctx context.Context, engine *Engine, sessions map[task.Session][]*task.Task) error {
+		return engine.executeSessions(ctx, sessions, result)
+	})
+}
 
-	for name, exec := range options.Executors {
-		multierr.AppendInto(&err, pcm.RegisterExecutor(name, exec))
-	}
+// run creates an [Engine] from options, opens the sessions and calls build with them.
+// Everything is torn down once build returns.
+func run(
+	ctx context.Context,
+	options Options,
+	build func(ctx context.Context, engine *Engine, sessions map[task.Session][]*task.Task) error,
+) error {
+	shutdownTracing, err := tracing.Setup(ctx, options.Tracing)
 	if err != nil {
-		return fmt.Errorf("failed to register executors: %w", err)
+		return fmt.Errorf("failed to initialize tracing: %w", err)
 	}
-
-	// This is the root of the executable tree
-	var exec executor.Executor = pcm
-
-	// Wrap the pcm in common executors
-	exec = statecheck.New(exec)
-
-	if len(options.Observers) > 0 {
-		obs := observable.New(exec)
-
-		for _, observer := range options.Observers {
-			multierr.AppendInto(&err, obs.Listen(observer))
-		}
+	defer func() {
+		err := shutdownTracing(context.WithoutCancel(ctx))
 		if err != nil {
-			return fmt.Errorf("failed to register observers: %w", err)
+			slog.WarnContext(ctx, "failed to flush traces", "error", err)
 		}
+	}()
 
-		exec = obs
-	}
+	ctx, span := tracing.Tracer().Start(ctx, "bonk")
+	defer span.End()
 
-	sched := scheduler.New(exec, options.Concurrency)
+	if options.Profile != "" {
+		prof := profile.New()
+		ctx = profile.NewContext(ctx, prof)
 
-	for session, tasks := range options.Sessions {
-		if multierr.AppendInto(&err, sched.OpenSession(ctx, session)) {
-			continue
-		}
-		multierr.AppendInto(&err, sched.ExecuteMany(ctx, session, tasks, result))
+		defer func() {
+			err := prof.WriteFile(afero.NewOsFs(), options.Profile)
+			if err != nil {
+				slog.WarnContext(ctx, "failed to write profile", "error", err)
+			}
+		}()
 	}
 
+	engine, err := NewEngine(options)
 	if err != nil {
-		return fmt.Errorf("failed to register tasks with scheduler: %w", err)
+		return err
 	}
+	defer engine.Shutdown(context.WithoutCancel(ctx))
 
-	for session := range options.Sessions {
-		exec.CloseSession(ctx, session.ID())
-	}
+	// Once canceled, give executing tasks a chance to stop before killing the plugins out from under them
+	finished := make(chan struct{})
+	defer close(finished)
+
+	stopGracePeriod := context.AfterFunc(ctx, func() {
+		slog.WarnContext(ctx, "run canceled, waiting for tasks to stop", "grace-period", options.GracePeriod)
+
+		select {
+		case <-finished:
+		case <-time.After(options.GracePeriod):
+			slog.WarnContext(ctx, "grace period expired, killing plugins")
+			engine.Shutdown(context.WithoutCancel(ctx))
+		}
+	})
+	defer stopGracePeriod()
 
-	return nil
+	return engine.withSessions(ctx, options.Sessions, nil,
+		func(ctx context.Context, sessions map[task.Session][]*task.Task) error {
+			return build(ctx, engine, sessions)
+		})
 }
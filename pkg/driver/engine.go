@@ -0,0 +1,391 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package driver
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"go.uber.org/multierr"
+
+	"github.com/spf13/afero"
+
+	"go.bonk.build/pkg/executor"
+	"go.bonk.build/pkg/executor/distributed"
+	"go.bonk.build/pkg/executor/middleware"
+	"go.bonk.build/pkg/executor/observable"
+	"go.bonk.build/pkg/executor/plugin"
+	"go.bonk.build/pkg/executor/policy"
+	"go.bonk.build/pkg/executor/remote"
+	"go.bonk.build/pkg/executor/router"
+	"go.bonk.build/pkg/executor/scheduler"
+	"go.bonk.build/pkg/executor/statecheck"
+	"go.bonk.build/pkg/executor/traced"
+	"go.bonk.build/pkg/task"
+)
+
+// ErrNoWorkerRoutes is returned for workers which don't serve any routes.
+var ErrNoWorkerRoutes = errors.New("worker has no routes")
+
+// Names of the middleware every engine's executor stack starts with, which routes may disable.
+const (
+	// MiddlewareStatecheck skips tasks which are up to date, reusing their previous results.
+	MiddlewareStatecheck = "statecheck"
+	// MiddlewarePolicy enforces the timeouts and retry policies of tasks, as configured by [Options.ExecutorPolicies].
+	MiddlewarePolicy = "policy"
+)
+
+// Orders of the built-in middleware, which other middleware may be ordered around.
+// Policies only apply to tasks which are executed, rather than restored from the cache.
+const (
+	MiddlewareStatecheckOrder = 200
+	MiddlewarePolicyOrder     = 100
+)
+
+// Engine owns the long-lived parts of a build: the plugins, the executor tree wrapping them and the scheduler.
+// An engine may execute many builds, one after another or at once, keeping plugins and caches warm between them.
+type Engine struct {
+	pcm     plugin.PluginClientManager
+	sched   *scheduler.Scheduler
+	remotes []*remote.Client
+	workers *distributed.Coordinator
+
+	observers []observable.Observer
+
+	sessionObservers   map[task.SessionID][]observable.Observer
+	sessionObserversMu sync.RWMutex
+
+	shutdown sync.Once
+}
+
+// NewEngine registers the plugins and executors described by options, without starting any plugins.
+// [Options.Sessions], [Options.Tracing] and [Options.Profile] are ignored, as they describe a single run.
+// The engine must be shut down once it's no longer needed.
+func NewEngine(options Options) (*Engine, error) {
+	var err error
+
+	pluginDir := options.PluginDir
+	if pluginDir == "" {
+		pluginDir = afero.GetTempDir(afero.NewOsFs(), "bonk-plugins")
+	}
+
+	pcm := plugin.NewPluginClientManager(plugin.NewStore(pluginDir))
+
+	// Plugins are only started once a task needs them
+	for _, pluginRef := range options.Plugins {
+		prefix := plugin.RefName(pluginRef)
+		multierr.AppendInto(&err, pcm.RegisterPlugin(prefix, pluginRef, options.PluginPools[prefix]))
+	}
+	for prefix, pluginRef := range options.PluginRoutes {
+		multierr.AppendInto(&err, pcm.RegisterPlugin(prefix, pluginRef, options.PluginPools[prefix]))
+	}
+	if err != nil {
+		pcm.Shutdown(context.Background())
+
+		return nil, fmt.Errorf("failed to initialize plugins: %w", err)
+	}
+
+	engine := &Engine{
+		pcm:              pcm,
+		observers:        options.Observers,
+		sessionObservers: make(map[task.SessionID][]observable.Observer),
+	}
+
+	for name, exec := range options.Executors {
+		multierr.AppendInto(&err, pcm.RegisterExecutor(name, exec))
+	}
+	for prefix, remoteOptions := range options.RemoteExecutors {
+		client, dialErr := remote.Dial(remoteOptions)
+		if multierr.AppendInto(&err, dialErr) {
+			continue
+		}
+		engine.remotes = append(engine.remotes, client)
+
+		multierr.AppendInto(&err, pcm.RegisterExecutor(prefix, mountedExecutor{
+			Executor: client,
+			prefix:   prefix,
+		}))
+	}
+	for alias, target := range options.Aliases {
+		multierr.AppendInto(&err, pcm.RegisterAlias(alias, target))
+	}
+	if len(options.Workers) > 0 {
+		multierr.AppendInto(&err, engine.registerWorkers(options.Workers))
+	}
+	if err != nil {
+		engine.Shutdown(context.Background())
+
+		return nil, fmt.Errorf("failed to register executors: %w", err)
+	}
+
+	// This is the root of the executable tree
+	var exec executor.Executor = pcm
+
+	// Wrap the pcm in the middleware of each route, then in common executors
+	// Settings keyed by route apply to the executors the pcm routes there
+	matcher := pcm.Matcher()
+	stack, err := middleware.New(exec, stackMiddleware(options, matcher), options.RouteMiddleware)
+	if err != nil {
+		engine.Shutdown(context.Background())
+
+		return nil, fmt.Errorf("failed to build executor stack: %w", err)
+	}
+	stack.SetMatcher(matcher)
+	exec = traced.New(stack)
+
+	obs := observable.New(exec)
+	err = obs.Listen(engine.notify)
+	if err != nil {
+		engine.Shutdown(context.Background())
+
+		return nil, fmt.Errorf("failed to register observers: %w", err)
+	}
+
+	engine.sched = scheduler.New(obs, options.Concurrency)
+	engine.sched.SetMatcher(matcher)
+	engine.sched.SetEstimator(statecheck.EstimateDuration)
+	engine.sched.SetFacts(options.Facts)
+	engine.sched.SetSkipHandler(func(session task.Session, tsk *task.Task) {
+		engine.notify(observable.TaskSkippedMsg(session, tsk))
+	})
+	for name, capacity := range options.ResourceLimits {
+		engine.sched.SetResourceLimit(name, capacity)
+	}
+	for route, limits := range options.ExecutorLimits {
+		engine.sched.SetExecutorLimits(route, limits)
+	}
+
+	return engine, nil
+}
+
+// stackMiddleware returns the built-in middleware, followed by those in options.
+func stackMiddleware(options Options, matcher router.Matcher) []middleware.Middleware {
+	builtin := []middleware.Middleware{
+		{
+			Name:  MiddlewareStatecheck,
+			Order: MiddlewareStatecheckOrder,
+			Wrap:  statecheck.New,
+		},
+		{
+			Name:  MiddlewarePolicy,
+			Order: MiddlewarePolicyOrder,
+			Wrap: func(next executor.Executor) executor.Executor {
+				enforcer := policy.New(next)
+				enforcer.SetMatcher(matcher)
+				for route, routePolicy := range options.ExecutorPolicies {
+					enforcer.SetExecutorPolicy(route, routePolicy)
+				}
+
+				return enforcer
+			},
+		},
+	}
+
+	return append(builtin, options.Middleware...)
+}
+
+// Build executes the tasks of each session, calling observers with the statuses of the build's tasks.
+// The outputs of every task are added to result, if it isn't nil.
+func (e *Engine) Build(
+	ctx context.Context,
+	sessions map[task.Session][]*task.Task,
+	result *task.Result,
+	observers ...observable.Observer,
+) error {
+	return e.withSessions(ctx, sessions, observers,
+		func(ctx context.Context, sessions map[task.Session][]*task.Task) error {
+			return e.executeSessions(ctx, sessions, result)
+		})
+}
+
+// Routes lists the routes of the engine's executors and aliases, in the order they're tried.
+func (e *Engine) Routes() []router.Route {
+	return e.pcm.Routes()
+}
+
+// WorkerUtilization reports the work done by each of the engine's workers.
+func (e *Engine) WorkerUtilization() []distributed.Utilization {
+	if e.workers == nil {
+		return nil
+	}
+
+	return e.workers.Utilization()
+}
+
+// Shutdown stops the engine's plugins and disconnects from remote executors.
+// It's safe to call more than once, including concurrently: later calls wait for the first to finish.
+func (e *Engine) Shutdown(ctx context.Context) {
+	e.shutdown.Do(func() { e.shutdownOnce(ctx) })
+}
+
+func (e *Engine) shutdownOnce(ctx context.Context) {
+	e.pcm.Shutdown(ctx)
+
+	if e.workers != nil {
+		e.workers.Close()
+	}
+	for _, usage := range e.WorkerUtilization() {
+		slog.InfoContext(ctx, "worker utilization",
+			"worker", usage.Name,
+			"capacity", usage.Capacity,
+			"executed", usage.Executed,
+			"failed", usage.Failed,
+			"busy", usage.Busy,
+			"lost", usage.Lost)
+	}
+
+	for _, client := range e.remotes {
+		err := client.Close()
+		if err != nil {
+			slog.DebugContext(ctx, "failed to close remote executor connection", "error", err)
+		}
+	}
+	e.remotes = nil
+}
+
+// registerWorkers dials the workers, distributing the tasks of their routes across them.
+func (e *Engine) registerWorkers(workers []WorkerOptions) error {
+	var err error
+
+	e.workers = distributed.New(distributed.MakeDefaultOptions())
+
+	routes := make(map[string]struct{})
+	for _, workerOptions := range workers {
+		name := cmp.Or(workerOptions.Name, workerOptions.Address)
+		if len(workerOptions.Routes) == 0 {
+			multierr.AppendInto(&err, fmt.Errorf("%w: %s", ErrNoWorkerRoutes, name))
+
+			continue
+		}
+
+		client, dialErr := remote.Dial(workerOptions.Options)
+		if multierr.AppendInto(&err, dialErr) {
+			continue
+		}
+		e.remotes = append(e.remotes, client)
+
+		multierr.AppendInto(&err, e.workers.Register(distributed.Worker{
+			Name:     name,
+			Executor: client,
+			Routes:   workerOptions.Routes,
+			Capacity: workerOptions.Capacity,
+		}))
+		for _, route := range workerOptions.Routes {
+			routes[route] = struct{}{}
+		}
+	}
+
+	// Each route is mounted once, however many workers serve it
+	for route := range routes {
+		multierr.AppendInto(&err, e.pcm.RegisterExecutor(route, mountedExecutor{
+			Executor: e.workers,
+			prefix:   route,
+		}))
+	}
+
+	return err
+}
+
+// withSessions opens sessions, calls build with the ones which opened and closes them once it returns.
+// The matrices of each session's tasks are expanded before it's opened.
+func (e *Engine) withSessions(
+	ctx context.Context,
+	sessions map[task.Session][]*task.Task,
+	observers []observable.Observer,
+	build func(ctx context.Context, sessions map[task.Session][]*task.Task) error,
+) error {
+	var err error
+
+	opened := make(map[task.Session][]*task.Task, len(sessions))
+	for session, tasks := range sessions {
+		tasks, expandErr := task.ExpandMatrices(tasks)
+		if multierr.AppendInto(&err, expandErr) {
+			continue
+		}
+
+		if len(observers) > 0 {
+			e.sessionObserversMu.Lock()
+			e.sessionObservers[session.ID()] = observers
+			e.sessionObserversMu.Unlock()
+		}
+
+		if multierr.AppendInto(&err, e.sched.OpenSession(ctx, session)) {
+			e.forgetObservers(session.ID())
+
+			continue
+		}
+		opened[session] = tasks
+	}
+
+	multierr.AppendInto(&err, build(ctx, opened))
+
+	// Sessions are closed even if the run failed or was canceled, so that observers are flushed
+	for session := range opened {
+		e.sched.CloseSession(context.WithoutCancel(ctx), session.ID())
+		e.forgetObservers(session.ID())
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to register tasks with scheduler: %w", err)
+	}
+
+	return nil
+}
+
+func (e *Engine) executeSessions(
+	ctx context.Context,
+	sessions map[task.Session][]*task.Task,
+	result *task.Result,
+) error {
+	var err error
+	for session, tasks := range sessions {
+		multierr.AppendInto(&err, e.sched.ExecuteMany(ctx, session, tasks, result))
+	}
+
+	return err
+}
+
+// notify passes msg to the engine's observers, and to those of the build executing its session.
+func (e *Engine) notify(msg observable.TaskStatusMsg) {
+	for _, observer := range e.observers {
+		observer(msg)
+	}
+
+	e.sessionObserversMu.RLock()
+	observers := e.sessionObservers[msg.Session.ID()]
+	e.sessionObserversMu.RUnlock()
+
+	for _, observer := range observers {
+		observer(msg)
+	}
+}
+
+func (e *Engine) forgetObservers(sessionID task.SessionID) {
+	e.sessionObserversMu.Lock()
+	delete(e.sessionObservers, sessionID)
+	e.sessionObserversMu.Unlock()
+}
+
+// mountedExecutor forwards the tasks routed to prefix with their full executor names,
+// so that a remote executor routes them the same way.
+type mountedExecutor struct {
+	executor.Executor
+
+	prefix string
+}
+
+func (m mountedExecutor) Execute(ctx context.Context, session task.Session, tsk *task.Task, result *task.Result) error {
+	mounted := *tsk
+	if mounted.Executor == "" {
+		mounted.Executor = m.prefix
+	} else {
+		mounted.Executor = m.prefix + task.TaskIDSep + mounted.Executor
+	}
+
+	return m.Executor.Execute(ctx, session, &mounted, result) //nolint:wrapcheck
+}
@@ -0,0 +1,369 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package driver_test
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go.bonk.build/pkg/driver"
+	"go.bonk.build/pkg/executor"
+	"go.bonk.build/pkg/executor/middleware"
+	"go.bonk.build/pkg/executor/mockexec"
+	"go.bonk.build/pkg/executor/observable"
+	"go.bonk.build/pkg/executor/policy"
+	"go.bonk.build/pkg/executor/remote"
+	"go.bonk.build/pkg/task"
+)
+
+func TestEngine_Build(t *testing.T) {
+	t.Parallel()
+
+	exec := mockexec.NewMockExecutor(t)
+	exec.EXPECT().OpenSession(mock.Anything, mock.Anything).Return(nil)
+	exec.EXPECT().CloseSession(mock.Anything, mock.Anything)
+	exec.EXPECT().Execute(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	engine, err := driver.NewEngine(driver.MakeDefaultOptions().WithExecutor("exec", exec))
+	require.NoError(t, err)
+	defer engine.Shutdown(t.Context())
+
+	// Each build only observes the tasks of its own sessions
+	var (
+		observed   = make(map[task.SessionID][]observable.TaskStatus)
+		observedMu sync.Mutex
+	)
+	build := func(session task.Session) {
+		err := engine.Build(t.Context(), map[task.Session][]*task.Task{
+			session: {task.New("Task", "exec", nil)},
+		}, nil, func(msg observable.TaskStatusMsg) {
+			observedMu.Lock()
+			defer observedMu.Unlock()
+
+			assert.Equal(t, session.ID(), msg.Session.ID())
+			observed[session.ID()] = append(observed[session.ID()], msg.Status)
+		})
+		require.NoError(t, err)
+	}
+
+	first := task.NewTestSession()
+	second := task.NewLocalSession(task.NewSessionID(), t.TempDir())
+
+	build(first)
+	build(second)
+
+	require.ElementsMatch(t, []observable.TaskStatus{observable.StatusRunning, observable.StatusSuccess},
+		observed[first.ID()])
+	require.ElementsMatch(t, []observable.TaskStatus{observable.StatusRunning, observable.StatusSuccess},
+		observed[second.ID()])
+}
+
+func TestEngine_ExecutorPolicy(t *testing.T) {
+	t.Parallel()
+
+	exec := mockexec.NewMockExecutor(t)
+	exec.EXPECT().OpenSession(mock.Anything, mock.Anything).Return(nil)
+	exec.EXPECT().CloseSession(mock.Anything, mock.Anything)
+	exec.EXPECT().Execute(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(executor.Retryable(assert.AnError)).Once()
+	exec.EXPECT().Execute(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+	engine, err := driver.NewEngine(driver.MakeDefaultOptions().
+		WithExecutor("exec", exec).
+		WithExecutorPolicy("exec", policy.Policy{Retry: &task.RetryPolicy{MaxAttempts: 2}}))
+	require.NoError(t, err)
+	defer engine.Shutdown(t.Context())
+
+	var (
+		observed   []observable.TaskStatusMsg
+		observedMu sync.Mutex
+	)
+	err = engine.Build(t.Context(), map[task.Session][]*task.Task{
+		task.NewTestSession(): {task.New("Task", "exec", nil)},
+	}, nil, func(msg observable.TaskStatusMsg) {
+		observedMu.Lock()
+		defer observedMu.Unlock()
+
+		observed = append(observed, msg)
+	})
+	require.NoError(t, err)
+
+	// The failed attempt is visible to observers
+	attempts := make(map[observable.TaskStatus]int)
+	for _, msg := range observed {
+		attempts[msg.Status] = msg.Attempt
+	}
+	assert.Equal(t, map[observable.TaskStatus]int{
+		observable.StatusRunning:  1,
+		observable.StatusRetrying: 1,
+		observable.StatusSuccess:  2,
+	}, attempts)
+}
+
+func TestEngine_RouteMiddleware(t *testing.T) {
+	t.Parallel()
+
+	exec := mockexec.NewMockExecutor(t)
+	exec.EXPECT().OpenSession(mock.Anything, mock.Anything).Return(nil)
+	exec.EXPECT().CloseSession(mock.Anything, mock.Anything)
+	exec.EXPECT().Execute(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	var (
+		wrapped   []task.ID
+		wrappedMu sync.Mutex
+	)
+	engine, err := driver.NewEngine(driver.MakeDefaultOptions().
+		WithExecutor("exec", exec).
+		WithMiddleware(middleware.Middleware{
+			Name:     "record",
+			Optional: true,
+			Wrap: func(next executor.Executor) executor.Executor {
+				return recordingExecutor{Executor: next, record: func(tsk *task.Task) {
+					wrappedMu.Lock()
+					defer wrappedMu.Unlock()
+
+					wrapped = append(wrapped, tsk.ID)
+				}}
+			},
+		}).
+		WithRouteMiddleware("exec.wrapped", middleware.RouteConfig{Enable: []string{"record"}}))
+	require.NoError(t, err)
+	defer engine.Shutdown(t.Context())
+
+	err = engine.Build(t.Context(), map[task.Session][]*task.Task{
+		task.NewTestSession(): {
+			task.New("Wrapped", "exec.wrapped", nil),
+			task.New("Unwrapped", "exec.unwrapped", nil),
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []task.ID{"Wrapped"}, wrapped)
+}
+
+func TestEngine_Matrix(t *testing.T) {
+	t.Parallel()
+
+	var (
+		executed   = make(map[task.ID]any)
+		executedMu sync.Mutex
+	)
+	exec := mockexec.NewMockExecutor(t)
+	exec.EXPECT().OpenSession(mock.Anything, mock.Anything).Return(nil)
+	exec.EXPECT().CloseSession(mock.Anything, mock.Anything)
+	exec.EXPECT().Execute(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ task.Session, tsk *task.Task, _ *task.Result) error {
+			executedMu.Lock()
+			defer executedMu.Unlock()
+
+			executed[tsk.ID] = tsk.Args
+
+			return nil
+		})
+
+	engine, err := driver.NewEngine(driver.MakeDefaultOptions().WithExecutor("exec", exec))
+	require.NoError(t, err)
+	defer engine.Shutdown(t.Context())
+
+	err = engine.Build(t.Context(), map[task.Session][]*task.Task{
+		task.NewTestSession(): {
+			task.New("Render", "exec", map[string]any{"env": "${matrix.env}"},
+				task.WithMatrix(task.Matrix{"env": {"dev", "prod"}})),
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[task.ID]any{
+		"Render.dev":  map[string]any{"env": "dev"},
+		"Render.prod": map[string]any{"env": "prod"},
+	}, executed)
+
+	err = engine.Build(t.Context(), map[task.Session][]*task.Task{
+		task.NewTestSession(): {
+			task.New("Render", "exec", nil, task.WithMatrix(task.Matrix{"env": {}})),
+		},
+	}, nil)
+	require.ErrorIs(t, err, task.ErrInvalidMatrix)
+}
+
+func TestEngine_Conditions(t *testing.T) {
+	t.Parallel()
+
+	exec := mockexec.NewMockExecutor(t)
+	exec.EXPECT().OpenSession(mock.Anything, mock.Anything).Return(nil)
+	exec.EXPECT().CloseSession(mock.Anything, mock.Anything)
+	exec.EXPECT().Execute(mock.Anything, mock.Anything, task.TaskIDMatches("Validate"), mock.Anything).Return(nil)
+
+	engine, err := driver.NewEngine(driver.MakeDefaultOptions().
+		WithExecutor("exec", exec).
+		WithFacts(task.Facts{Env: map[string]string{"CI": "true"}}))
+	require.NoError(t, err)
+	defer engine.Shutdown(t.Context())
+
+	var (
+		statuses   = make(map[task.ID]observable.TaskStatus)
+		statusesMu sync.Mutex
+	)
+	err = engine.Build(t.Context(), map[task.Session][]*task.Task{
+		task.NewTestSession(): {
+			task.New("Validate", "exec", nil, task.WithCondition(task.Condition{Env: map[string]string{"CI": "*"}})),
+			task.New("Helm", "exec", nil, task.WithCondition(task.Condition{Exists: []string{"charts/*"}})),
+		},
+	}, nil, func(msg observable.TaskStatusMsg) {
+		statusesMu.Lock()
+		defer statusesMu.Unlock()
+
+		// Observers are notified concurrently, so the running message may arrive last
+		statuses[msg.TaskID] = max(statuses[msg.TaskID], msg.Status)
+	})
+	require.NoError(t, err)
+
+	// Skipped tasks are never executed, but are still observed
+	assert.Equal(t, map[task.ID]observable.TaskStatus{
+		"Validate": observable.StatusSuccess,
+		"Helm":     observable.StatusSkipped,
+	}, statuses)
+}
+
+type recordingExecutor struct {
+	executor.Executor
+
+	record func(*task.Task)
+}
+
+func (r recordingExecutor) Execute(
+	ctx context.Context,
+	session task.Session,
+	tsk *task.Task,
+	result *task.Result,
+) error {
+	r.record(tsk)
+
+	return r.Executor.Execute(ctx, session, tsk, result) //nolint:wrapcheck
+}
+
+// serveRemote serves exec on a unix socket, returning its address once it's listened on.
+func serveRemote(t *testing.T, exec *mockexec.MockExecutor) string {
+	t.Helper()
+
+	socket := filepath.Join(t.TempDir(), "executor.sock")
+	address := "unix://" + socket
+
+	go func() {
+		assert.NoError(t, remote.Serve(t.Context(), exec, remote.Options{Address: address}))
+	}()
+	require.Eventually(t, func() bool {
+		conn, err := (&net.Dialer{}).DialContext(t.Context(), "unix", socket)
+		if err != nil {
+			return false
+		}
+
+		return conn.Close() == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	return address
+}
+
+func TestEngine_RemoteExecutor(t *testing.T) {
+	t.Parallel()
+
+	exec := mockexec.NewMockExecutor(t)
+	exec.EXPECT().OpenSession(mock.Anything, mock.Anything).Return(nil)
+	exec.EXPECT().CloseSession(mock.Anything, mock.Anything)
+	exec.EXPECT().Execute(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(_ context.Context, _ task.Session, tsk *task.Task, _ *task.Result) {
+			// The remote is sent the full executor name, so it can route it to its own executors
+			assert.Equal(t, "remote.exec", tsk.Executor)
+		}).
+		Return(nil)
+
+	engine, err := driver.NewEngine(driver.MakeDefaultOptions().
+		WithRemoteExecutor("remote", remote.Options{Address: serveRemote(t, exec)}))
+	require.NoError(t, err)
+	defer engine.Shutdown(t.Context())
+
+	err = engine.Build(t.Context(), map[task.Session][]*task.Task{
+		task.NewLocalSession(task.NewSessionID(), t.TempDir()): {task.New("Task", "remote.exec", nil)},
+	}, nil)
+	require.NoError(t, err)
+}
+
+func TestEngine_Workers(t *testing.T) {
+	t.Parallel()
+
+	options := driver.MakeDefaultOptions()
+	for _, name := range []string{"first", "second"} {
+		exec := mockexec.NewMockExecutor(t)
+		exec.EXPECT().OpenSession(mock.Anything, mock.Anything).Return(nil).Maybe()
+		exec.EXPECT().CloseSession(mock.Anything, mock.Anything).Maybe()
+		exec.EXPECT().Execute(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Run(func(_ context.Context, _ task.Session, tsk *task.Task, _ *task.Result) {
+				assert.Equal(t, "remote.exec", tsk.Executor)
+			}).
+			Return(nil).
+			Maybe()
+
+		options = options.WithWorker(driver.WorkerOptions{
+			Options:  remote.Options{Address: serveRemote(t, exec)},
+			Name:     name,
+			Routes:   []string{"remote"},
+			Capacity: 2,
+		})
+	}
+
+	engine, err := driver.NewEngine(options)
+	require.NoError(t, err)
+	defer engine.Shutdown(t.Context())
+
+	err = engine.Build(t.Context(), map[task.Session][]*task.Task{
+		task.NewLocalSession(task.NewSessionID(), t.TempDir()): {
+			task.New("First", "remote.exec", nil),
+			task.New("Second", "remote.exec", nil),
+			task.New("Third", "remote.exec", nil),
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	usage := engine.WorkerUtilization()
+	require.Len(t, usage, 2)
+	assert.Equal(t, 3, usage[0].Executed+usage[1].Executed)
+}
+
+func TestEngine_WorkerWithoutRoutes(t *testing.T) {
+	t.Parallel()
+
+	_, err := driver.NewEngine(driver.MakeDefaultOptions().WithWorker(driver.WorkerOptions{
+		Options: remote.Options{Address: "worker:7100"},
+	}))
+	require.ErrorIs(t, err, driver.ErrNoWorkerRoutes)
+}
+
+func TestEngine_ConcurrentShutdown(t *testing.T) {
+	t.Parallel()
+
+	engine, err := driver.NewEngine(driver.MakeDefaultOptions().
+		WithRemoteExecutor("remote", remote.Options{Address: serveRemote(t, mockexec.NewMockExecutor(t))}).
+		WithWorker(driver.WorkerOptions{
+			Options: remote.Options{Address: serveRemote(t, mockexec.NewMockExecutor(t))},
+			Routes:  []string{"worker"},
+		}))
+	require.NoError(t, err)
+
+	// Like a run's deferred shutdown racing the one at the end of its grace period
+	var wg sync.WaitGroup
+	for range 2 {
+		wg.Go(func() { engine.Shutdown(t.Context()) })
+	}
+	wg.Wait()
+
+	engine.Shutdown(t.Context())
+}
@@ -4,25 +4,98 @@
 package driver
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
 	"go.bonk.build/pkg/executor"
+	"go.bonk.build/pkg/executor/middleware"
 	"go.bonk.build/pkg/executor/observable"
+	"go.bonk.build/pkg/executor/plugin"
+	"go.bonk.build/pkg/executor/policy"
+	"go.bonk.build/pkg/executor/remote"
+	"go.bonk.build/pkg/executor/scheduler"
 	"go.bonk.build/pkg/task"
+	"go.bonk.build/pkg/tracing"
 )
 
 type Options struct {
+	// Concurrency is the most tasks executed at once, or no limit if it isn't positive.
 	Concurrency int
-	Plugins     []string
+	// Plugins are started when the first task for an executor prefixed by their [plugin.RefName] is executed.
+	Plugins []string
+	// PluginRoutes maps executor prefixes to the plugins which are started to handle them.
+	PluginRoutes map[string]string
+	// PluginPools configures the processes run for the plugins with each executor prefix.
+	// Plugins without a pool run as a single process.
+	PluginPools map[string]plugin.PoolOptions
 	Executors   map[string]executor.Executor
 	Sessions    map[task.Session][]*task.Task
 	Observers   []observable.Observer
+	Tracing     tracing.Config
+	// Profile is the path to write a Chrome trace-event profile to, if set.
+	Profile string
+	// PluginDir is the directory plugins are installed and cached in.
+	// If empty, a directory in the system's temp directory is used.
+	PluginDir string
+	// RemoteExecutors maps executor prefixes to the remote executors which handle them.
+	// Tasks are forwarded with their full executor names, including the prefix.
+	RemoteExecutors map[string]remote.Options
+	// Aliases route the executors beneath each alias to those beneath its target instead.
+	Aliases map[string]string
+	// Workers are remote executors which the tasks of their routes are distributed across.
+	Workers []WorkerOptions
+	// ResourceLimits are the amounts of named resources available to the tasks executing at once.
+	ResourceLimits map[string]int
+	// ExecutorLimits constrain the tasks routed to each executor route.
+	ExecutorLimits map[string]scheduler.ExecutorLimits
+	// ExecutorPolicies are the default timeout and retry policies of the tasks routed to each executor route.
+	ExecutorPolicies map[string]policy.Policy
+	// Middleware are added to the executor stack alongside [MiddlewarePolicy] and [MiddlewareStatecheck].
+	Middleware []middleware.Middleware
+	// RouteMiddleware selects the middleware used for the tasks routed to each executor route.
+	RouteMiddleware map[string]middleware.RouteConfig
+	// Facts are what the conditions of tasks are evaluated against, such as the selected profiles.
+	Facts task.Facts
+	// GracePeriod is how long executing tasks have to stop after the run is canceled,
+	// before plugin processes are killed.
+	GracePeriod time.Duration
 }
 
+// WorkerOptions describes a remote worker, such as one run by `bonk executor serve`.
+type WorkerOptions struct {
+	remote.Options `mapstructure:",squash"`
+
+	// Name identifies the worker, and defaults to its address.
+	Name string `json:"name,omitempty" mapstructure:"name"`
+	// Routes are the executor routes whose tasks may be dispatched to the worker.
+	Routes []string `json:"routes" mapstructure:"routes"`
+	// Capacity is the most tasks executed on the worker at once, which defaults to 1.
+	Capacity int `json:"capacity,omitempty" mapstructure:"capacity"`
+}
+
+// DefaultGracePeriod is the default value of [Options.GracePeriod].
+const DefaultGracePeriod = 10 * time.Second
+
 func MakeDefaultOptions() Options {
 	return Options{
-		Plugins:   make([]string, 0, 3), //nolint:mnd
-		Executors: make(map[string]executor.Executor),
-		Sessions:  make(map[task.Session][]*task.Task),
-		Observers: make([]observable.Observer, 0),
+		GracePeriod:  DefaultGracePeriod,
+		Plugins:      make([]string, 0, 3), //nolint:mnd
+		PluginRoutes: make(map[string]string),
+		PluginPools:  make(map[string]plugin.PoolOptions),
+
+		RemoteExecutors: make(map[string]remote.Options),
+		Aliases:         make(map[string]string),
+
+		ResourceLimits:   make(map[string]int),
+		ExecutorLimits:   make(map[string]scheduler.ExecutorLimits),
+		ExecutorPolicies: make(map[string]policy.Policy),
+		RouteMiddleware:  make(map[string]middleware.RouteConfig),
+		Executors:        make(map[string]executor.Executor),
+		Sessions:         make(map[task.Session][]*task.Task),
+		Observers:        make([]observable.Observer, 0),
 	}
 }
 
@@ -40,12 +113,96 @@ func (opts Options) WithExecutor(name string, exec executor.Executor) Options {
 }
 
 // WithPlugins loads the specified plugins.
+// Plugins may be paths to binaries, names of installed plugins or Go packages.
 func (opts Options) WithPlugins(plugins ...string) Options {
 	opts.Plugins = append(opts.Plugins, plugins...)
 
 	return opts
 }
 
+// WithPluginRoute routes tasks for executors beneath prefix to the plugin.
+// Plugins may be paths to binaries, names of installed plugins or Go packages.
+func (opts Options) WithPluginRoute(prefix string, plugin string) Options {
+	opts.PluginRoutes[prefix] = plugin
+
+	return opts
+}
+
+// WithPluginPool sets how many processes are run for the plugin with the executor prefix,
+// or whether each of its tasks is isolated in its own process.
+func (opts Options) WithPluginPool(prefix string, pool plugin.PoolOptions) Options {
+	opts.PluginPools[prefix] = pool
+
+	return opts
+}
+
+// WithRemoteExecutor routes tasks for executors beneath prefix to the executor served remotely,
+// such as by `bonk executor serve`.
+func (opts Options) WithRemoteExecutor(prefix string, remote remote.Options) Options {
+	opts.RemoteExecutors[prefix] = remote
+
+	return opts
+}
+
+// WithAlias routes the executors beneath alias to those beneath target instead,
+// such as "k8s.kustomize.Build" to "kustomize.Build" for the alias "k8s.kustomize" of "kustomize".
+func (opts Options) WithAlias(alias string, target string) Options {
+	opts.Aliases[alias] = target
+
+	return opts
+}
+
+// WithWorker distributes the tasks of the worker's routes across it and the other workers serving them.
+func (opts Options) WithWorker(worker WorkerOptions) Options {
+	opts.Workers = append(opts.Workers, worker)
+
+	return opts
+}
+
+// WithResourceLimit sets the amount of the named resource available to the tasks executing at once.
+func (opts Options) WithResourceLimit(name string, capacity int) Options {
+	opts.ResourceLimits[name] = capacity
+
+	return opts
+}
+
+// WithExecutorLimits constrains the tasks routed to the executor route, and the executors beneath it.
+func (opts Options) WithExecutorLimits(route string, limits scheduler.ExecutorLimits) Options {
+	opts.ExecutorLimits[route] = limits
+
+	return opts
+}
+
+// WithExecutorPolicy sets the default timeout and retry policy of the tasks routed to the executor route,
+// and the executors beneath it.
+func (opts Options) WithExecutorPolicy(route string, policy policy.Policy) Options {
+	opts.ExecutorPolicies[route] = policy
+
+	return opts
+}
+
+// WithMiddleware adds middleware to the executor stack.
+func (opts Options) WithMiddleware(middleware ...middleware.Middleware) Options {
+	opts.Middleware = append(opts.Middleware, middleware...)
+
+	return opts
+}
+
+// WithRouteMiddleware selects the middleware used for the tasks routed to the executor route,
+// and the executors beneath it.
+func (opts Options) WithRouteMiddleware(route string, config middleware.RouteConfig) Options {
+	opts.RouteMiddleware[route] = config
+
+	return opts
+}
+
+// WithFacts sets the facts which the conditions of tasks are evaluated against.
+func (opts Options) WithFacts(facts task.Facts) Options {
+	opts.Facts = facts
+
+	return opts
+}
+
 // SessionOption is a functor for modifying a [task.Session].
 type SessionOption = func(Options, task.Session)
 
@@ -63,3 +220,73 @@ func (opts Options) WithObservers(observers ...observable.Observer) Options {
 
 	return opts
 }
+
+// WithTracing exports a trace of the run to the destination described by cfg.
+func (opts Options) WithTracing(cfg tracing.Config) Options {
+	opts.Tracing = cfg
+
+	return opts
+}
+
+// WithProfile writes a Chrome trace-event profile of the run to path.
+func (opts Options) WithProfile(path string) Options {
+	opts.Profile = path
+
+	return opts
+}
+
+// WithGracePeriod sets how long tasks have to stop after the run is canceled.
+func (opts Options) WithGracePeriod(gracePeriod time.Duration) Options {
+	opts.GracePeriod = gracePeriod
+
+	return opts
+}
+
+// WithPluginDir sets the directory plugins are installed and cached in.
+func (opts Options) WithPluginDir(dir string) Options {
+	opts.PluginDir = dir
+
+	return opts
+}
+
+// Configuration returns a digest of the options which are shared by every build an [Engine] executes,
+// such as its plugins, limits and policies, so that engines created with the same options can be recognized.
+// [Options.Executors] and [Options.Middleware] can't be compared, and aren't included.
+func (opts Options) Configuration() (string, error) {
+	encoded, err := json.Marshal(struct {
+		Concurrency      int
+		Plugins          []string
+		PluginRoutes     map[string]string
+		PluginPools      map[string]plugin.PoolOptions
+		PluginDir        string
+		RemoteExecutors  map[string]remote.Options
+		Aliases          map[string]string
+		Workers          []WorkerOptions
+		ResourceLimits   map[string]int
+		ExecutorLimits   map[string]scheduler.ExecutorLimits
+		ExecutorPolicies map[string]policy.Policy
+		RouteMiddleware  map[string]middleware.RouteConfig
+		GracePeriod      time.Duration
+	}{
+		Concurrency:      opts.Concurrency,
+		Plugins:          opts.Plugins,
+		PluginRoutes:     opts.PluginRoutes,
+		PluginPools:      opts.PluginPools,
+		PluginDir:        opts.PluginDir,
+		RemoteExecutors:  opts.RemoteExecutors,
+		Aliases:          opts.Aliases,
+		Workers:          opts.Workers,
+		ResourceLimits:   opts.ResourceLimits,
+		ExecutorLimits:   opts.ExecutorLimits,
+		ExecutorPolicies: opts.ExecutorPolicies,
+		RouteMiddleware:  opts.RouteMiddleware,
+		GracePeriod:      opts.GracePeriod,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode configuration: %w", err)
+	}
+
+	digest := sha256.Sum256(encoded)
+
+	return hex.EncodeToString(digest[:]), nil
+}
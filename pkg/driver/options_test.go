@@ -5,6 +5,7 @@ package driver_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -12,6 +13,7 @@ import (
 	"go.bonk.build/pkg/executor/argconv"
 	"go.bonk.build/pkg/executor/mockexec"
 	"go.bonk.build/pkg/executor/observable"
+	"go.bonk.build/pkg/executor/scheduler"
 	"go.bonk.build/pkg/task"
 )
 
@@ -25,6 +27,16 @@ func TestWithConcurrency(t *testing.T) {
 	require.Equal(t, concurrency, options.Concurrency)
 }
 
+func TestWithGracePeriod(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, driver.DefaultGracePeriod, driver.MakeDefaultOptions().GracePeriod)
+
+	options := driver.MakeDefaultOptions().
+		WithGracePeriod(time.Second)
+	require.Equal(t, time.Second, options.GracePeriod)
+}
+
 func TestWithExecutor(t *testing.T) {
 	t.Parallel()
 
@@ -67,6 +79,15 @@ func TestWithPlugins(t *testing.T) {
 	require.ElementsMatch(t, options.Plugins, plugins)
 }
 
+func TestWithPluginDir(t *testing.T) {
+	t.Parallel()
+
+	options := driver.MakeDefaultOptions().
+		WithPluginDir("plugins")
+
+	require.Equal(t, "plugins", options.PluginDir)
+}
+
 func TestWithLocalSession(t *testing.T) {
 	t.Parallel()
 
@@ -92,3 +113,53 @@ func TestWithObservers(t *testing.T) {
 
 	require.Len(t, options.Observers, 1)
 }
+
+func TestWithPluginRoute(t *testing.T) {
+	t.Parallel()
+
+	options := driver.MakeDefaultOptions().
+		WithPluginRoute("k8s", "go.bonk.build/plugins/k8s/resources")
+
+	require.Equal(t, map[string]string{"k8s": "go.bonk.build/plugins/k8s/resources"}, options.PluginRoutes)
+}
+
+func TestWithResourceLimits(t *testing.T) {
+	t.Parallel()
+
+	limits := scheduler.ExecutorLimits{
+		Concurrency: 2,
+		Resources:   task.Resources{"cpu": 2},
+	}
+
+	options := driver.MakeDefaultOptions().
+		WithResourceLimit("cpu", 8).
+		WithExecutorLimits("kustomize", limits)
+
+	require.Equal(t, map[string]int{"cpu": 8}, options.ResourceLimits)
+	require.Equal(t, map[string]scheduler.ExecutorLimits{"kustomize": limits}, options.ExecutorLimits)
+}
+
+func TestConfiguration(t *testing.T) {
+	t.Parallel()
+
+	configuration := func(options driver.Options) string {
+		t.Helper()
+
+		digest, err := options.Configuration()
+		require.NoError(t, err)
+
+		return digest
+	}
+
+	options := driver.MakeDefaultOptions().
+		WithPlugins("go.bonk.build/plugins/test").
+		WithResourceLimit("memory", 2)
+
+	// Facts and sessions differ between builds, so they don't change the configuration
+	require.Equal(t, configuration(options), configuration(options.
+		WithFacts(task.Facts{Profiles: []string{"ci"}}).
+		WithLocalSession(t.TempDir())))
+
+	require.NotEqual(t, configuration(options), configuration(options.WithConcurrency(4)))
+	require.NotEqual(t, configuration(options), configuration(options.WithGracePeriod(time.Minute)))
+}
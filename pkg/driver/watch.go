@@ -0,0 +1,446 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package driver
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"log/slog"
+	"maps"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+
+	"go.bonk.build/pkg/executor/scheduler"
+	"go.bonk.build/pkg/task"
+)
+
+const (
+	// DefaultWatchInterval is the default value of [WatchOptions.Interval].
+	DefaultWatchInterval = 500 * time.Millisecond
+	// DefaultWatchDebounce is the default value of [WatchOptions.Debounce].
+	DefaultWatchDebounce = 200 * time.Millisecond
+)
+
+// WatchOptions configures [Watch].
+type WatchOptions struct {
+	// Interval is how often source files are checked for changes.
+	Interval time.Duration
+	// Debounce is how long files must go unchanged before rebuilding, so that a burst of edits causes one rebuild.
+	Debounce time.Duration
+	// ConfigPatterns match the names of config files anywhere in the source tree, such as "*.cue".
+	// Changing a config file reloads the tasks of each session, and rebuilds every task.
+	ConfigPatterns []string
+	// Reload is called for each session when a config file changes, and returns the tasks the changed config
+	// describes for it. If it's nil or fails, the session's previous tasks are rebuilt.
+	Reload func(ctx context.Context, session task.Session) ([]*task.Task, error)
+	// OnBuild is called after each build, with the tasks which were built and the error, if any.
+	OnBuild func(tasks []*task.Task, err error)
+}
+
+// MakeDefaultWatchOptions returns the default [WatchOptions], which treat CUE files as config.
+func MakeDefaultWatchOptions() WatchOptions {
+	return WatchOptions{
+		Interval:       DefaultWatchInterval,
+		Debounce:       DefaultWatchDebounce,
+		ConfigPatterns: []string{"*.cue"},
+	}
+}
+
+// Watch builds every session like [Run], then keeps plugins and sessions open and rebuilds whenever files change,
+// until ctx is canceled. Only the tasks whose inputs changed are rebuilt, along with the tasks depending on them.
+// The inputs of the followups each task returned are watched too, rebuilding the task which returned them.
+// Files in the output directory aren't watched, as every build writes to it.
+// Build failures don't stop watching, they're reported to [WatchOptions.OnBuild].
+func Watch(ctx context.Context, options Options, watch WatchOptions) error {
+	return run(ctx, options, func(ctx context.Context, engine *Engine, sessions map[task.Session][]*task.Task) error {
+		watchers := make([]*sessionWatcher, 0, len(sessions))
+		bySession := make(map[task.SessionID]*sessionWatcher, len(sessions))
+		for session, tasks := range sessions {
+			watcher := &sessionWatcher{
+				session:   session,
+				tasks:     tasks,
+				options:   watch,
+				followups: make(map[task.ID]*task.Task),
+			}
+			watchers = append(watchers, watcher)
+			bySession[session.ID()] = watcher
+		}
+
+		engine.sched.SetFollowupHandler(func(session task.Session, _ *task.Task, followups []*task.Task) {
+			if watcher, ok := bySession[session.ID()]; ok {
+				watcher.addFollowups(followups)
+			}
+		})
+
+		// Snapshots are taken before building, so that edits made during a build aren't missed
+		for _, watcher := range watchers {
+			watcher.snapshot = watcher.takeSnapshot(ctx)
+			watcher.build(ctx, engine.sched, watcher.tasks)
+		}
+
+		ticker := time.NewTicker(watch.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+
+			for _, watcher := range watchers {
+				changed := watcher.waitForChanges(ctx)
+				if len(changed) == 0 {
+					continue
+				}
+
+				if slices.ContainsFunc(changed, watcher.isConfig) {
+					watcher.reload(ctx)
+				}
+
+				affected := watcher.affectedTasks(changed)
+				slog.InfoContext(ctx, "files changed, rebuilding", "files", len(changed), "tasks", len(affected))
+
+				if len(affected) > 0 {
+					watcher.build(ctx, engine.sched, affected)
+				}
+			}
+		}
+	})
+}
+
+// fileStamp identifies a version of a file.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// sessionWatcher tracks the source files of a session.
+type sessionWatcher struct {
+	session  task.Session
+	tasks    []*task.Task
+	options  WatchOptions
+	snapshot map[string]fileStamp
+
+	// followups are the followups returned during the last build of each task, by ID.
+	// They're added while building, from the scheduler's goroutines.
+	followupsMu sync.Mutex
+	followups   map[task.ID]*task.Task
+}
+
+func (w *sessionWatcher) build(ctx context.Context, sched *scheduler.Scheduler, tasks []*task.Task) {
+	w.forgetFollowups(tasks)
+
+	// Tasks built previously must be forgotten so that they aren't coalesced with their last build
+	sched.ResetSession(w.session.ID())
+
+	err := sched.ExecuteMany(ctx, w.session, tasks, &task.Result{})
+	if err != nil && ctx.Err() == nil {
+		slog.WarnContext(ctx, "build failed, waiting for changes", "error", err)
+	}
+
+	// Followups are only known once they've been returned, so their new inputs are stamped after building
+	for name, stamp := range w.stampInputs(ctx, slices.Collect(maps.Values(w.currentFollowups()))) {
+		if _, ok := w.snapshot[name]; !ok {
+			w.snapshot[name] = stamp
+		}
+	}
+
+	if w.options.OnBuild != nil {
+		w.options.OnBuild(tasks, err)
+	}
+}
+
+// reload replaces the session's tasks with those described by its changed config.
+func (w *sessionWatcher) reload(ctx context.Context) {
+	if w.options.Reload == nil {
+		return
+	}
+
+	tasks, err := w.options.Reload(ctx, w.session)
+	if err == nil {
+		tasks, err = task.ExpandMatrices(tasks)
+	}
+	if err != nil {
+		slog.WarnContext(ctx, "failed to reload config, rebuilding previous tasks", "error", err)
+
+		return
+	}
+
+	w.tasks = tasks
+	w.forgetFollowups(nil)
+
+	// The inputs of new tasks are stamped now, so that they aren't seen as changed by the next check
+	w.snapshot = w.takeSnapshot(ctx)
+}
+
+// waitForChanges returns the files which have changed since the last snapshot, once they've stopped changing.
+func (w *sessionWatcher) waitForChanges(ctx context.Context) []string {
+	current := w.takeSnapshot(ctx)
+	if maps.Equal(current, w.snapshot) {
+		return nil
+	}
+
+	// Wait for the burst of changes to settle
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(w.options.Debounce):
+		}
+
+		settled := w.takeSnapshot(ctx)
+		if maps.Equal(settled, current) {
+			break
+		}
+
+		current = settled
+	}
+
+	changed := diffSnapshots(w.snapshot, current)
+	w.snapshot = current
+
+	return changed
+}
+
+// addFollowups records followups returned by a task during a build, so that their inputs are watched.
+func (w *sessionWatcher) addFollowups(followups []*task.Task) {
+	w.followupsMu.Lock()
+	defer w.followupsMu.Unlock()
+
+	for _, followup := range followups {
+		w.followups[followup.ID] = followup
+	}
+}
+
+// forgetFollowups forgets the followups of tasks, which are about to be rebuilt, or of every task if tasks is nil.
+func (w *sessionWatcher) forgetFollowups(tasks []*task.Task) {
+	w.followupsMu.Lock()
+	defer w.followupsMu.Unlock()
+
+	if tasks == nil {
+		clear(w.followups)
+
+		return
+	}
+
+	for id := range w.followups {
+		if slices.ContainsFunc(tasks, func(tsk *task.Task) bool { return isAncestor(tsk.ID, id) }) {
+			delete(w.followups, id)
+		}
+	}
+}
+
+func (w *sessionWatcher) currentFollowups() map[task.ID]*task.Task {
+	w.followupsMu.Lock()
+	defer w.followupsMu.Unlock()
+
+	return maps.Clone(w.followups)
+}
+
+// takeSnapshot stamps every file matching the inputs of the tasks and their followups, and every config file.
+func (w *sessionWatcher) takeSnapshot(ctx context.Context) map[string]fileStamp {
+	sourceFS := w.session.SourceFS()
+	snapshot := w.stampInputs(ctx, slices.Concat(w.tasks, slices.Collect(maps.Values(w.currentFollowups()))))
+
+	if len(w.options.ConfigPatterns) == 0 {
+		return snapshot
+	}
+
+	stamp := func(name string, info fs.FileInfo) {
+		if info.Mode().IsRegular() {
+			snapshot[filepath.ToSlash(name)] = fileStamp{
+				modTime: info.ModTime(),
+				size:    info.Size(),
+			}
+		}
+	}
+
+	err := afero.Walk(sourceFS, ".", func(name string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return nil //nolint:nilerr // Files may disappear while walking
+		}
+
+		// Hidden directories, including the output directory, aren't config
+		if info.IsDir() && name != "." && strings.HasPrefix(info.Name(), ".") {
+			return filepath.SkipDir
+		}
+
+		if w.isConfig(name) {
+			stamp(name, info)
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		slog.DebugContext(ctx, "failed to walk source files", "error", err)
+	}
+
+	return snapshot
+}
+
+// stampInputs stamps every file matching the inputs of tasks, except those in the output directory.
+func (w *sessionWatcher) stampInputs(ctx context.Context, tasks []*task.Task) map[string]fileStamp {
+	sourceFS := w.session.SourceFS()
+	snapshot := make(map[string]fileStamp)
+
+	for _, tsk := range tasks {
+		for _, pattern := range tsk.Inputs {
+			matches, err := afero.Glob(sourceFS, pattern)
+			if err != nil {
+				slog.DebugContext(ctx, "invalid input pattern", "task", tsk.ID, "pattern", pattern, "error", err)
+
+				continue
+			}
+
+			for _, match := range matches {
+				name := filepath.ToSlash(match)
+				if name == task.OutputDir || strings.HasPrefix(name, task.OutputDir+"/") {
+					continue
+				}
+
+				if info, err := sourceFS.Stat(match); err == nil && info.Mode().IsRegular() {
+					snapshot[name] = fileStamp{
+						modTime: info.ModTime(),
+						size:    info.Size(),
+					}
+				}
+			}
+		}
+	}
+
+	return snapshot
+}
+
+// affectedTasks returns the tasks with inputs matching the changed files, and the tasks depending on them.
+// Tasks are also affected by changes to the inputs of their followups.
+func (w *sessionWatcher) affectedTasks(changed []string) []*task.Task {
+	affected := make(map[task.ID]bool)
+	followups := w.currentFollowups()
+
+	for _, name := range changed {
+		if w.isConfig(name) {
+			return w.tasks
+		}
+
+		for _, tsk := range w.tasks {
+			if matchesInputs(tsk, name) {
+				affected[tsk.ID] = true
+			}
+		}
+
+		for _, followup := range followups {
+			if !matchesInputs(followup, name) {
+				continue
+			}
+
+			for _, tsk := range w.tasks {
+				if isAncestor(tsk.ID, followup.ID) {
+					affected[tsk.ID] = true
+				}
+			}
+		}
+	}
+
+	// Dependents are rebuilt until no more are found
+	for grew := true; grew; {
+		grew = false
+
+		for _, tsk := range w.tasks {
+			if affected[tsk.ID] {
+				continue
+			}
+
+			// Tasks whose conditions check the outcomes of affected tasks depend on them too
+			for _, dependency := range slices.Concat(tsk.Dependencies, tsk.When.Upstream()) {
+				if affected[dependency] {
+					affected[tsk.ID] = true
+					grew = true
+
+					break
+				}
+			}
+		}
+	}
+
+	// The tasks whose outcomes the conditions of affected tasks check are rebuilt with them,
+	// since the scheduler forgets the outcomes of the previous build
+	for grew := true; grew; {
+		grew = false
+
+		for _, tsk := range w.tasks {
+			if !affected[tsk.ID] {
+				continue
+			}
+
+			for _, upstream := range tsk.When.Upstream() {
+				if !affected[upstream] {
+					affected[upstream] = true
+					grew = true
+				}
+			}
+		}
+	}
+
+	tasks := make([]*task.Task, 0, len(affected))
+	for _, tsk := range w.tasks {
+		if affected[tsk.ID] {
+			tasks = append(tasks, tsk)
+		}
+	}
+
+	return tasks
+}
+
+// matchesInputs reports whether the file name matches any of the task's inputs.
+func matchesInputs(tsk *task.Task, name string) bool {
+	for _, pattern := range tsk.Inputs {
+		if matched, _ := path.Match(filepath.ToSlash(path.Clean(pattern)), name); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isAncestor reports whether followup was returned by tsk, or by one of its followups.
+func isAncestor(tsk task.ID, followup task.ID) bool {
+	return strings.HasPrefix(followup.String(), tsk.String()+task.TaskIDSep)
+}
+
+func (w *sessionWatcher) isConfig(name string) bool {
+	for _, pattern := range w.options.ConfigPatterns {
+		if matched, _ := path.Match(pattern, path.Base(filepath.ToSlash(name))); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// diffSnapshots returns the files which were added, removed or modified between before and after.
+func diffSnapshots(before, after map[string]fileStamp) []string {
+	var changed []string
+
+	for name, stamp := range after {
+		if previous, ok := before[name]; !ok || previous != stamp {
+			changed = append(changed, name)
+		}
+	}
+
+	for name := range before {
+		if _, ok := after[name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+
+	return changed
+}
@@ -0,0 +1,176 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package driver_test
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go.bonk.build/pkg/driver"
+	"go.bonk.build/pkg/executor/mockexec"
+	"go.bonk.build/pkg/task"
+)
+
+func TestMakeDefaultWatchOptions(t *testing.T) {
+	t.Parallel()
+
+	options := driver.MakeDefaultWatchOptions()
+
+	require.Equal(t, driver.DefaultWatchInterval, options.Interval)
+	require.Equal(t, driver.DefaultWatchDebounce, options.Debounce)
+	require.Equal(t, []string{"*.cue"}, options.ConfigPatterns)
+}
+
+func TestWatch(t *testing.T) {
+	t.Parallel()
+
+	session := task.NewTestSession()
+	sourceFS := session.SourceFS()
+	require.NoError(t, afero.WriteFile(sourceFS, "src/a.txt", []byte("a"), 0o600))
+	require.NoError(t, afero.WriteFile(sourceFS, "src/b.txt", []byte("b"), 0o600))
+	require.NoError(t, afero.WriteFile(sourceFS, "bonk.cue", []byte("{}"), 0o600))
+
+	tasks := []*task.Task{
+		task.New("A", "exec", nil, task.WithInputs("src/a.txt")),
+		task.New("B", "exec", nil, task.WithInputs("src/b.txt")),
+		task.New("C", "exec", nil, task.WithDependencies("A")),
+	}
+
+	exec := mockexec.NewMockExecutor(t)
+	exec.EXPECT().OpenSession(mock.Anything, mock.Anything).Return(nil)
+	exec.EXPECT().CloseSession(mock.Anything, mock.Anything)
+	exec.EXPECT().Execute(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	options := driver.MakeDefaultOptions().WithExecutor("exec", exec)
+	options.Sessions[session] = tasks
+
+	builds := make(chan []task.ID)
+	watchOptions := driver.MakeDefaultWatchOptions()
+	watchOptions.Interval = 10 * time.Millisecond
+	watchOptions.Debounce = 10 * time.Millisecond
+	watchOptions.OnBuild = func(tsks []*task.Task, err error) {
+		assert.NoError(t, err)
+
+		ids := make([]task.ID, 0, len(tsks))
+		for _, tsk := range tsks {
+			ids = append(ids, tsk.ID)
+		}
+		builds <- ids
+	}
+	// The config describes one more task once it's changed
+	watchOptions.Reload = func(_ context.Context, reloaded task.Session) ([]*task.Task, error) {
+		assert.Equal(t, session.ID(), reloaded.ID())
+
+		return append(slices.Clone(tasks), task.New("D", "exec", nil, task.WithInputs("src/b.txt"))), nil
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error)
+	go func() {
+		done <- driver.Watch(ctx, options, watchOptions)
+	}()
+
+	receive := func() []task.ID {
+		select {
+		case ids := <-builds:
+			return ids
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "timed out waiting for build")
+
+			return nil
+		}
+	}
+
+	require.ElementsMatch(t, []task.ID{"A", "B", "C"}, receive(), "everything is built first")
+
+	require.NoError(t, afero.WriteFile(sourceFS, "src/a.txt", []byte("changed"), 0o600))
+	require.ElementsMatch(t, []task.ID{"A", "C"}, receive(), "changed inputs rebuild dependents")
+
+	require.NoError(t, afero.WriteFile(sourceFS, "bonk.cue", []byte("{a: 1}"), 0o600))
+	require.ElementsMatch(t, []task.ID{"A", "B", "C", "D"}, receive(), "changed config reloads and rebuilds everything")
+
+	require.NoError(t, afero.WriteFile(sourceFS, "src/b.txt", []byte("changed"), 0o600))
+	require.ElementsMatch(t, []task.ID{"B", "D"}, receive(), "reloaded tasks are watched")
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestWatch_Followups(t *testing.T) {
+	t.Parallel()
+
+	session := task.NewTestSession()
+	sourceFS := session.SourceFS()
+	require.NoError(t, afero.WriteFile(sourceFS, "src/resources.txt", []byte("a"), 0o600))
+
+	var applied atomic.Int32
+	exec := mockexec.NewMockExecutor(t)
+	exec.EXPECT().OpenSession(mock.Anything, mock.Anything).Return(nil)
+	exec.EXPECT().CloseSession(mock.Anything, mock.Anything)
+	exec.EXPECT().Execute(mock.Anything, mock.Anything, task.TaskIDMatches("Render"), mock.Anything).
+		RunAndReturn(func(_ context.Context, _ task.Session, _ *task.Task, res *task.Result) error {
+			res.AddFollowupTasks(task.New("Apply", "exec", nil,
+				task.WithInputs("src/resources.txt", ".bonk/Render.Apply/*.yaml")))
+
+			return nil
+		})
+	exec.EXPECT().Execute(mock.Anything, mock.Anything, task.TaskIDMatches("Render.Apply"), mock.Anything).
+		RunAndReturn(func(context.Context, task.Session, *task.Task, *task.Result) error {
+			// Every execution rewrites the outputs, which match the task's inputs
+			execution := applied.Add(1)
+
+			return afero.WriteFile(sourceFS, ".bonk/Render.Apply/out.yaml", []byte(strconv.Itoa(int(execution))), 0o600)
+		})
+
+	options := driver.MakeDefaultOptions().WithExecutor("exec", exec)
+	options.Sessions[session] = []*task.Task{task.New("Render", "exec", nil)}
+
+	builds := make(chan []*task.Task, 1)
+	watchOptions := driver.MakeDefaultWatchOptions()
+	watchOptions.Interval = 10 * time.Millisecond
+	watchOptions.Debounce = 10 * time.Millisecond
+	watchOptions.OnBuild = func(tsks []*task.Task, err error) {
+		assert.NoError(t, err)
+		builds <- tsks
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error)
+	go func() {
+		done <- driver.Watch(ctx, options, watchOptions)
+	}()
+
+	<-builds
+	require.Equal(t, int32(1), applied.Load())
+
+	// Changing the inputs of the followup rebuilds the task which returned it, which may be restored from the cache
+	require.NoError(t, afero.WriteFile(sourceFS, "src/resources.txt", []byte("changed"), 0o600))
+	select {
+	case tsks := <-builds:
+		require.Len(t, tsks, 1)
+		assert.Equal(t, task.ID("Render"), tsks[0].ID)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for build")
+	}
+	assert.Equal(t, int32(2), applied.Load())
+
+	// Rewriting the output directory doesn't cause another build
+	select {
+	case <-builds:
+		require.FailNow(t, "rebuilt after writing outputs")
+	case <-time.After(20 * watchOptions.Interval):
+	}
+
+	cancel()
+	require.NoError(t, <-done)
+}
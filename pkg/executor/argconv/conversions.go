@@ -6,9 +6,13 @@ package argconv
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
 
+	"cuelang.org/go/cue/cuecontext"
+	"cuelang.org/go/cue/format"
 	"cuelang.org/go/cuego"
 
 	"github.com/go-viper/mapstructure/v2"
@@ -17,6 +21,9 @@ import (
 	"go.bonk.build/pkg/task"
 )
 
+// ErrInvalidArgs is returned when a task's arguments don't match the schema of its executor.
+var ErrInvalidArgs = errors.New("invalid arguments")
+
 // TypedExecutor is like [executor.Executor] but with unboxed arguments.
 type TypedExecutor[Params any] interface {
 	OpenSession(ctx context.Context, session task.Session) error
@@ -105,6 +112,69 @@ func BoxExecutor[Params any](
 	}
 }
 
+// Schema returns the CUE schema of Params, as used to validate task arguments.
+func Schema[Params any]() (string, error) {
+	paramsT := reflect.TypeFor[Params]()
+	if paramsT.Kind() == reflect.Interface {
+		// Anything goes
+		return "_", nil
+	}
+
+	value := cuecontext.New().EncodeType(reflect.Zero(paramsT).Interface())
+	if value.Err() != nil {
+		return "", fmt.Errorf("failed to encode schema of %s: %w", paramsT, value.Err())
+	}
+
+	schema, err := format.Node(value.Syntax())
+	if err != nil {
+		return "", fmt.Errorf("failed to format schema of %s: %w", paramsT, err)
+	}
+
+	return string(schema), nil
+}
+
+// ArgsSchema returns the CUE schema of the arguments accepted by exec,
+// or an empty string if it wasn't created by [BoxExecutor].
+func ArgsSchema(exec executor.Executor) (string, error) {
+	wrapped, ok := exec.(interface{ argsSchema() (string, error) })
+	if !ok {
+		return "", nil
+	}
+
+	return wrapped.argsSchema()
+}
+
+// ValidateArgs checks args against a schema returned by [Schema], failing with [ErrInvalidArgs] if they don't match.
+// Empty schemas and nil args are always valid, since [UnboxArgs] accepts them.
+func ValidateArgs(schema string, args any) error {
+	if schema == "" || args == nil {
+		return nil
+	}
+
+	cuectx := cuecontext.New()
+	schemaV := cuectx.CompileString(schema)
+	if schemaV.Err() != nil {
+		return fmt.Errorf("failed to compile schema: %w", schemaV.Err())
+	}
+
+	// Args are compared as JSON, as they're sent to plugins, so whole floats decoded from JSON still match ints
+	encoded, err := json.Marshal(args)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidArgs, err)
+	}
+
+	err = schemaV.Unify(cuectx.CompileBytes(encoded)).Validate()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidArgs, err)
+	}
+
+	return nil
+}
+
+func (wrapped wrappedExecutor[Params]) argsSchema() (string, error) {
+	return Schema[Params]()
+}
+
 func (wrapped wrappedExecutor[Params]) Execute(
 	ctx context.Context,
 	session task.Session,
@@ -113,7 +183,7 @@ func (wrapped wrappedExecutor[Params]) Execute(
 ) error {
 	unboxed, err := UnboxArgs[Params](tsk)
 	if err != nil {
-		return err
+		return executor.NewError(executor.KindInvalidArgument, err)
 	}
 
 	return wrapped.TypedExecutor.Execute(
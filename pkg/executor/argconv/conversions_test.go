@@ -100,3 +100,40 @@ func Test_Nil(t *testing.T) {
 	err := boxed.Execute(t.Context(), nil, typed, nil)
 	require.NoError(t, err)
 }
+
+func Test_Schema(t *testing.T) {
+	t.Parallel()
+
+	schema, err := argconv.ArgsSchema(argconv.BoxExecutor(argconv.NewMockTypedExecutor[Args](t)))
+
+	require.NoError(t, err)
+	require.Contains(t, schema, "val1: string")
+	require.Contains(t, schema, "<70000")
+}
+
+func Test_SchemaAny(t *testing.T) {
+	t.Parallel()
+
+	schema, err := argconv.Schema[any]()
+
+	require.NoError(t, err)
+	require.Equal(t, "_", schema)
+}
+
+func Test_ValidateArgs(t *testing.T) {
+	t.Parallel()
+
+	schema, err := argconv.Schema[Args]()
+	require.NoError(t, err)
+
+	require.NoError(t, argconv.ValidateArgs(schema, defaultArgs))
+	require.NoError(t, argconv.ValidateArgs(schema, map[string]any{"val1": "test string", "val2": 3.0}))
+	require.NoError(t, argconv.ValidateArgs(schema, nil))
+	require.NoError(t, argconv.ValidateArgs("", map[string]any{"val2": "string"}))
+
+	err = argconv.ValidateArgs(schema, map[string]any{"val2": "string"})
+	require.ErrorIs(t, err, argconv.ErrInvalidArgs)
+
+	err = argconv.ValidateArgs(schema, map[string]any{"val2": 70001})
+	require.ErrorIs(t, err, argconv.ErrInvalidArgs)
+}
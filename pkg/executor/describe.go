@@ -0,0 +1,61 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package executor
+
+import (
+	"context"
+	"strings"
+
+	"go.bonk.build/pkg/task"
+)
+
+// Description describes the executors provided by a plugin or other executor tree.
+type Description struct {
+	Name      string
+	Version   string
+	Executors []ExecutorDescription
+}
+
+// ExecutorDescription describes a single executor within a [Description].
+type ExecutorDescription struct {
+	// Name is the full path of the executor, relative to the executor which described it.
+	Name string
+	// Schema is the CUE schema of the executor's arguments, or empty if unknown.
+	Schema string
+}
+
+// Describer is implemented by executors which can describe the executors they provide.
+type Describer interface {
+	Describe(ctx context.Context) (Description, error)
+}
+
+// Executor returns the description of the executor which tasks for the executor name are routed to.
+// As with routers, an executor also handles names nested beneath its own, and unnamed or wildcard executors handle any name.
+func (d Description) Executor(name string) (ExecutorDescription, bool) {
+	var (
+		found ExecutorDescription
+		ok    bool
+	)
+
+	for _, exec := range d.Executors {
+		matches := exec.Name == name ||
+			strings.HasPrefix(name, exec.Name+task.TaskIDSep) ||
+			exec.Name == "" || exec.Name == "*"
+		if matches && len(exec.Name) >= len(found.Name) {
+			found, ok = exec, true
+		}
+	}
+
+	return found, ok
+}
+
+// ExecutorNames returns the names of the described executors.
+func (d Description) ExecutorNames() []string {
+	names := make([]string, len(d.Executors))
+	for idx, exec := range d.Executors {
+		names[idx] = exec.Name
+	}
+
+	return names
+}
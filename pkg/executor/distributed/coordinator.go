@@ -0,0 +1,456 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+// Package distributed provides [Coordinator], which executes tasks across a pool of worker executors.
+package distributed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"go.bonk.build/pkg/executor"
+	"go.bonk.build/pkg/executor/router"
+	"go.bonk.build/pkg/task"
+)
+
+const (
+	// DefaultMaxAttempts is how many workers a task is attempted on before losing them fails the task.
+	DefaultMaxAttempts = 3
+	// DefaultProbeInterval is how often lost workers are probed.
+	DefaultProbeInterval = 10 * time.Second
+)
+
+var (
+	// ErrDuplicateWorker is returned when registering a worker with the name of another.
+	ErrDuplicateWorker = errors.New("duplicate worker name")
+	// ErrNoWorker is returned for tasks whose executor isn't served by any worker.
+	ErrNoWorker = errors.New("no worker serves executor")
+)
+
+var (
+	// errUnavailable matches the errors of workers which couldn't be reached.
+	errUnavailable = &executor.Error{Kind: executor.KindUnavailable}
+
+	errSessionClosed = errors.New("session closed")
+)
+
+// Worker is an executor which executes tasks on behalf of a [Coordinator].
+type Worker struct {
+	// Name identifies the worker, such as in its [Utilization].
+	Name string
+	// Executor executes the tasks dispatched to the worker.
+	Executor executor.Executor
+	// Routes are the executor routes the worker serves, each matching the executors beneath it.
+	// A worker without routes serves every executor.
+	Routes []string
+	// Capacity is the most tasks the worker executes at once, which defaults to 1.
+	Capacity int
+}
+
+// Prober is implemented by worker executors which can check whether they're reachable, such as remote executors.
+// Lost workers are re-admitted once they're reachable again.
+type Prober interface {
+	Probe(ctx context.Context) error
+}
+
+// Utilization describes the work done by a worker.
+type Utilization struct {
+	Name     string
+	Capacity int
+	// Running is the number of tasks executing on the worker.
+	Running int
+	// Executed is the number of tasks the worker has finished executing, including Failed.
+	Executed int
+	Failed   int
+	// Busy is the total time spent executing tasks, which may exceed the time elapsed for workers with capacity.
+	Busy time.Duration
+	// Lost is set once the worker can't be reached, after which no more tasks are dispatched to it
+	// until it's re-admitted by a successful probe.
+	Lost bool
+}
+
+// Options configures a [Coordinator].
+type Options struct {
+	// MaxAttempts is how many workers a task is attempted on before losing them fails the task.
+	MaxAttempts int
+	// ProbeInterval is how often lost workers which implement [Prober] are probed, each probe timing out after it.
+	ProbeInterval time.Duration
+}
+
+// MakeDefaultOptions creates the default coordinator options.
+func MakeDefaultOptions() Options {
+	return Options{
+		MaxAttempts:   DefaultMaxAttempts,
+		ProbeInterval: DefaultProbeInterval,
+	}
+}
+
+// Coordinator is an executor which dispatches each task to the least loaded worker serving its executor,
+// retrying it on another worker if its worker is lost. Lost workers are probed until they're reachable again.
+// Sessions are opened on a worker once the first of their tasks is dispatched to it.
+type Coordinator struct {
+	options Options
+
+	workers []*worker
+	// changed is closed and replaced whenever a worker may have capacity for waiting tasks
+	changed chan struct{}
+	mu      sync.Mutex
+
+	// probing is canceled by Close, to stop probing lost workers
+	probing     context.Context //nolint:containedctx
+	stopProbing context.CancelFunc
+}
+
+var _ executor.Executor = (*Coordinator)(nil)
+
+type worker struct {
+	Worker
+
+	usage Utilization
+	// probed is set while the worker is lost and being probed
+	probed bool
+
+	sessions   map[task.SessionID]*workerSession
+	sessionsMu sync.Mutex
+}
+
+// workerSession is a session being opened on a worker by the first of its tasks.
+type workerSession struct {
+	once sync.Once
+	err  error
+}
+
+// New creates a coordinator without any workers. It must be closed once it's no longer needed.
+func New(options Options) *Coordinator {
+	probing, stopProbing := context.WithCancel(context.Background())
+
+	return &Coordinator{
+		options:     options,
+		changed:     make(chan struct{}),
+		probing:     probing,
+		stopProbing: stopProbing,
+	}
+}
+
+// Close stops probing lost workers.
+func (c *Coordinator) Close() {
+	c.stopProbing()
+}
+
+// Register adds a worker to the pool, replacing any lost worker of the same name.
+func (c *Coordinator) Register(wrk Worker) error {
+	if wrk.Capacity <= 0 {
+		wrk.Capacity = 1
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for idx, existing := range c.workers {
+		if existing.Name != wrk.Name {
+			continue
+		}
+		if !existing.usage.Lost {
+			return fmt.Errorf("%w: %s", ErrDuplicateWorker, wrk.Name)
+		}
+
+		c.workers = append(c.workers[:idx], c.workers[idx+1:]...)
+
+		break
+	}
+
+	c.workers = append(c.workers, &worker{
+		Worker: wrk,
+		usage: Utilization{
+			Name:     wrk.Name,
+			Capacity: wrk.Capacity,
+		},
+		sessions: make(map[task.SessionID]*workerSession),
+	})
+	c.notify()
+
+	return nil
+}
+
+// Deregister removes a worker from the pool, closing the sessions opened on it.
+// Tasks already executing on the worker are left to finish.
+func (c *Coordinator) Deregister(ctx context.Context, name string) {
+	c.mu.Lock()
+	var removed *worker
+	for idx, wrk := range c.workers {
+		if wrk.Name == name {
+			removed = wrk
+			c.workers = append(c.workers[:idx], c.workers[idx+1:]...)
+
+			break
+		}
+	}
+	c.mu.Unlock()
+
+	if removed != nil {
+		removed.closeSessions(ctx)
+	}
+}
+
+// Utilization reports the work done by each worker, in the order they were registered.
+func (c *Coordinator) Utilization() []Utilization {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	usage := make([]Utilization, len(c.workers))
+	for idx, wrk := range c.workers {
+		usage[idx] = wrk.usage
+	}
+
+	return usage
+}
+
+// OpenSession implements executor.Executor.
+// Sessions are only opened on workers once their tasks are dispatched there.
+func (c *Coordinator) OpenSession(context.Context, task.Session) error {
+	return nil
+}
+
+// CloseSession implements executor.Executor.
+func (c *Coordinator) CloseSession(ctx context.Context, sessionID task.SessionID) {
+	c.mu.Lock()
+	workers := append([]*worker(nil), c.workers...)
+	c.mu.Unlock()
+
+	for _, wrk := range workers {
+		wrk.closeSession(ctx, sessionID)
+	}
+}
+
+// Execute implements executor.Executor.
+func (c *Coordinator) Execute(ctx context.Context, session task.Session, tsk *task.Task, result *task.Result) error {
+	maxAttempts := max(c.options.MaxAttempts, 1)
+
+	var lost error
+	for range maxAttempts {
+		wrk, err := c.acquire(ctx, tsk.Executor)
+		if err != nil {
+			if lost != nil {
+				return fmt.Errorf("%w, after losing workers: %w", err, lost)
+			}
+
+			return err
+		}
+
+		start := time.Now()
+		err = wrk.execute(ctx, session, tsk, result)
+		c.release(wrk, time.Since(start), err)
+
+		if err == nil || !errors.Is(err, errUnavailable) || ctx.Err() != nil {
+			return err
+		}
+
+		slog.WarnContext(ctx, "lost worker, retrying task on another",
+			"worker", wrk.Name,
+			"task", tsk.ID,
+			"error", err)
+		lost = err
+	}
+
+	return fmt.Errorf("task was attempted on %d lost workers: %w", maxAttempts, lost)
+}
+
+// acquire reserves capacity on the least loaded worker serving executor, waiting for one to have capacity.
+func (c *Coordinator) acquire(ctx context.Context, executor string) (*worker, error) {
+	for {
+		c.mu.Lock()
+		var (
+			best     *worker
+			eligible bool
+		)
+		for _, wrk := range c.workers {
+			if wrk.usage.Lost || !wrk.serves(executor) {
+				continue
+			}
+			eligible = true
+
+			if wrk.usage.Running < wrk.Capacity && (best == nil || wrk.load() < best.load()) {
+				best = wrk
+			}
+		}
+		if best != nil {
+			best.usage.Running++
+			c.mu.Unlock()
+
+			return best, nil
+		}
+		changed := c.changed
+		c.mu.Unlock()
+
+		if !eligible {
+			return nil, fmt.Errorf("%w %s", ErrNoWorker, executor)
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("task not started: %w", ctx.Err())
+		}
+	}
+}
+
+// release returns the capacity reserved by acquire, recording how the task went.
+func (c *Coordinator) release(wrk *worker, busy time.Duration, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	wrk.usage.Running--
+	wrk.usage.Executed++
+	wrk.usage.Busy += busy
+	if err != nil {
+		wrk.usage.Failed++
+	}
+	if errors.Is(err, errUnavailable) {
+		wrk.usage.Lost = true
+
+		if prober, ok := wrk.Executor.(Prober); ok && !wrk.probed && c.options.ProbeInterval > 0 {
+			wrk.probed = true
+			go c.probe(wrk, prober)
+		}
+	}
+
+	c.notify()
+}
+
+// probe probes a lost worker every ProbeInterval, re-admitting it once it's reachable.
+// Probing stops if the worker is deregistered or replaced, or the coordinator is closed.
+func (c *Coordinator) probe(wrk *worker, prober Prober) {
+	ticker := time.NewTicker(c.options.ProbeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.probing.Done():
+			return
+		case <-ticker.C:
+		}
+
+		c.mu.Lock()
+		registered := slices.Contains(c.workers, wrk)
+		c.mu.Unlock()
+		if !registered {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.probing, c.options.ProbeInterval)
+		err := prober.Probe(ctx)
+		cancel()
+
+		if err != nil {
+			slog.DebugContext(c.probing, "lost worker is still unreachable", "worker", wrk.Name, "error", err)
+
+			continue
+		}
+
+		slog.InfoContext(c.probing, "lost worker is reachable again", "worker", wrk.Name)
+
+		// The worker may have restarted since, so sessions are opened again by their next tasks
+		wrk.closeSessions(c.probing)
+
+		c.mu.Lock()
+		wrk.usage.Lost = false
+		wrk.probed = false
+		c.notify()
+		c.mu.Unlock()
+
+		return
+	}
+}
+
+// notify wakes the tasks waiting for capacity. It must be called with the lock held.
+func (c *Coordinator) notify() {
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
+
+// serves reports whether the worker serves the executor.
+func (w *worker) serves(executor string) bool {
+	if len(w.Routes) == 0 {
+		return true
+	}
+
+	for _, route := range w.Routes {
+		if router.MatchRoute(route, executor) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// load is the fraction of the worker's capacity in use.
+func (w *worker) load() float64 {
+	return float64(w.usage.Running) / float64(w.Capacity)
+}
+
+func (w *worker) execute(ctx context.Context, session task.Session, tsk *task.Task, result *task.Result) error {
+	err := w.openSession(ctx, session)
+	if err != nil {
+		return err
+	}
+
+	return w.Executor.Execute(ctx, session, tsk, result) //nolint:wrapcheck
+}
+
+// openSession opens the session on the worker, if it hasn't been already.
+func (w *worker) openSession(ctx context.Context, session task.Session) error {
+	w.sessionsMu.Lock()
+	opening, ok := w.sessions[session.ID()]
+	if !ok {
+		opening = &workerSession{}
+		w.sessions[session.ID()] = opening
+	}
+	w.sessionsMu.Unlock()
+
+	// The session outlives the task which opened it, until it's closed
+	opening.once.Do(func() {
+		opening.err = w.Executor.OpenSession(context.WithoutCancel(ctx), session)
+	})
+	if opening.err != nil {
+		return fmt.Errorf("failed to open session on worker %s: %w", w.Name, opening.err)
+	}
+
+	return nil
+}
+
+func (w *worker) closeSession(ctx context.Context, sessionID task.SessionID) {
+	w.sessionsMu.Lock()
+	opening, ok := w.sessions[sessionID]
+	delete(w.sessions, sessionID)
+	w.sessionsMu.Unlock()
+
+	if !ok {
+		return
+	}
+
+	// Waits for the session to finish opening, or stops it from being opened
+	opening.once.Do(func() {
+		opening.err = errSessionClosed
+	})
+	if opening.err == nil {
+		w.Executor.CloseSession(ctx, sessionID)
+	}
+}
+
+func (w *worker) closeSessions(ctx context.Context) {
+	w.sessionsMu.Lock()
+	sessionIDs := make([]task.SessionID, 0, len(w.sessions))
+	for sessionID := range w.sessions {
+		sessionIDs = append(sessionIDs, sessionID)
+	}
+	w.sessionsMu.Unlock()
+
+	for _, sessionID := range sessionIDs {
+		w.closeSession(ctx, sessionID)
+	}
+}
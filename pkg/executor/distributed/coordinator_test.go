@@ -0,0 +1,204 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package distributed_test
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go.bonk.build/pkg/executor"
+	"go.bonk.build/pkg/executor/distributed"
+	"go.bonk.build/pkg/executor/mockexec"
+	"go.bonk.build/pkg/executor/remote"
+	"go.bonk.build/pkg/executor/rpc"
+	"go.bonk.build/pkg/task"
+)
+
+func TestCoordinator_LeastLoaded(t *testing.T) {
+	t.Parallel()
+
+	var (
+		started = make(chan struct{})
+		release = make(chan struct{})
+	)
+	blockingWorker := func() *mockexec.MockExecutor {
+		exec := mockexec.NewMockExecutor(t)
+		exec.EXPECT().OpenSession(mock.Anything, mock.Anything).Return(nil)
+		exec.EXPECT().CloseSession(mock.Anything, mock.Anything)
+		exec.EXPECT().Execute(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Run(func(context.Context, task.Session, *task.Task, *task.Result) {
+				started <- struct{}{}
+				<-release
+			}).
+			Return(nil)
+
+		return exec
+	}
+
+	coord := distributed.New(distributed.MakeDefaultOptions())
+	require.NoError(t, coord.Register(distributed.Worker{Name: "small", Executor: blockingWorker(), Capacity: 1}))
+	require.NoError(t, coord.Register(distributed.Worker{Name: "large", Executor: blockingWorker(), Capacity: 2}))
+
+	session := task.NewTestSession()
+	errs := make(chan error)
+	for range 3 {
+		go func() {
+			errs <- coord.Execute(t.Context(), session, task.New("Task", "exec", nil), &task.Result{})
+		}()
+		<-started
+	}
+
+	// Each task went to the worker with the most of its capacity free
+	usage := coord.Utilization()
+	require.Len(t, usage, 2)
+	assert.Equal(t, 1, usage[0].Running)
+	assert.Equal(t, 2, usage[1].Running)
+
+	close(release)
+	for range 3 {
+		require.NoError(t, <-errs)
+	}
+
+	coord.CloseSession(t.Context(), session.ID())
+
+	usage = coord.Utilization()
+	assert.Equal(t, distributed.Utilization{Name: "small", Capacity: 1, Executed: 1, Busy: usage[0].Busy}, usage[0])
+	assert.Equal(t, distributed.Utilization{Name: "large", Capacity: 2, Executed: 2, Busy: usage[1].Busy}, usage[1])
+}
+
+func TestCoordinator_Routes(t *testing.T) {
+	t.Parallel()
+
+	first := mockexec.NewMockExecutor(t)
+	second := mockexec.NewMockExecutor(t)
+	second.EXPECT().OpenSession(mock.Anything, mock.Anything).Return(nil)
+	second.EXPECT().Execute(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	coord := distributed.New(distributed.MakeDefaultOptions())
+	require.NoError(t, coord.Register(distributed.Worker{Name: "first", Executor: first, Routes: []string{"first"}}))
+	require.NoError(t, coord.Register(distributed.Worker{Name: "second", Executor: second, Routes: []string{"second"}}))
+
+	err := coord.Register(distributed.Worker{Name: "first", Executor: first})
+	require.ErrorIs(t, err, distributed.ErrDuplicateWorker)
+
+	session := task.NewTestSession()
+
+	err = coord.Execute(t.Context(), session, task.New("Task", "second.exec", nil), &task.Result{})
+	require.NoError(t, err)
+
+	err = coord.Execute(t.Context(), session, task.New("Task", "third.exec", nil), &task.Result{})
+	require.ErrorIs(t, err, distributed.ErrNoWorker)
+}
+
+// serveWorker serves exec on localhost, returning a client for it and its server.
+func serveWorker(t *testing.T, exec *mockexec.MockExecutor) (*remote.Client, *grpc.Server) {
+	t.Helper()
+
+	lis, err := (&net.ListenConfig{}).Listen(t.Context(), "tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	rpc.RegisterGRPCServer(server, exec)
+
+	go server.Serve(lis) //nolint:errcheck
+	t.Cleanup(server.Stop)
+
+	client, err := remote.Dial(remote.Options{Address: lis.Addr().String()})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+
+	return client, server
+}
+
+func TestCoordinator_WorkerLoss(t *testing.T) {
+	t.Parallel()
+
+	lostClient, lostServer := serveWorker(t, mockexec.NewMockExecutor(t))
+
+	exec := mockexec.NewMockExecutor(t)
+	exec.EXPECT().OpenSession(mock.Anything, mock.Anything).Return(nil)
+	exec.EXPECT().CloseSession(mock.Anything, mock.Anything)
+	exec.EXPECT().Execute(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	client, _ := serveWorker(t, exec)
+
+	coord := distributed.New(distributed.MakeDefaultOptions())
+	t.Cleanup(coord.Close)
+	require.NoError(t, coord.Register(distributed.Worker{Name: "lost", Executor: lostClient}))
+	require.NoError(t, coord.Register(distributed.Worker{Name: "worker", Executor: client}))
+
+	lostServer.Stop()
+
+	// The task is first dispatched to the lost worker, then retried on the other
+	session := task.NewTestSession()
+	err := coord.Execute(t.Context(), session, task.New("Task", "exec", nil), &task.Result{})
+	require.NoError(t, err)
+
+	coord.CloseSession(t.Context(), session.ID())
+
+	usage := coord.Utilization()
+	require.Len(t, usage, 2)
+	assert.True(t, usage[0].Lost)
+	assert.Equal(t, 1, usage[0].Failed)
+	assert.False(t, usage[1].Lost)
+	assert.Equal(t, 1, usage[1].Executed)
+	assert.Equal(t, 0, usage[1].Failed)
+}
+
+// probedWorker is a worker which is only reachable once reachable is set.
+type probedWorker struct {
+	*mockexec.MockExecutor
+
+	reachable atomic.Bool
+}
+
+func (w *probedWorker) Probe(context.Context) error {
+	if !w.reachable.Load() {
+		return assert.AnError
+	}
+
+	return nil
+}
+
+func TestCoordinator_Readmit(t *testing.T) {
+	t.Parallel()
+
+	session := task.NewTestSession()
+	tsk := task.New("Task", "exec", nil)
+
+	wrk := &probedWorker{MockExecutor: mockexec.NewMockExecutor(t)}
+	wrk.EXPECT().OpenSession(mock.Anything, session).Return(nil).Times(2)
+	wrk.EXPECT().CloseSession(mock.Anything, session.ID()).Times(2)
+	wrk.EXPECT().Execute(mock.Anything, session, tsk, mock.Anything).
+		Return(&executor.Error{Kind: executor.KindUnavailable, Message: "connection refused"}).Once()
+	wrk.EXPECT().Execute(mock.Anything, session, tsk, mock.Anything).Return(nil).Once()
+
+	coord := distributed.New(distributed.Options{MaxAttempts: 1, ProbeInterval: time.Millisecond})
+	t.Cleanup(coord.Close)
+	require.NoError(t, coord.Register(distributed.Worker{Name: "flaky", Executor: wrk}))
+
+	err := coord.Execute(t.Context(), session, tsk, &task.Result{})
+	require.ErrorIs(t, err, &executor.Error{Kind: executor.KindUnavailable})
+	assert.True(t, coord.Utilization()[0].Lost)
+
+	err = coord.Execute(t.Context(), session, tsk, &task.Result{})
+	require.ErrorIs(t, err, distributed.ErrNoWorker)
+
+	// Once the worker is reachable again, it's re-admitted and its sessions are opened again
+	wrk.reachable.Store(true)
+	require.Eventually(t, func() bool { return !coord.Utilization()[0].Lost }, time.Second, time.Millisecond)
+
+	err = coord.Execute(t.Context(), session, tsk, &task.Result{})
+	require.NoError(t, err)
+
+	coord.CloseSession(t.Context(), session.ID())
+}
@@ -0,0 +1,154 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrorKind classifies an [Error].
+type ErrorKind string
+
+const (
+	// KindUnknown is used for errors which haven't been classified.
+	KindUnknown ErrorKind = ""
+	// KindCanceled matches [context.Canceled].
+	KindCanceled ErrorKind = "canceled"
+	// KindDeadlineExceeded matches [context.DeadlineExceeded].
+	KindDeadlineExceeded ErrorKind = "deadline-exceeded"
+	// KindInvalidArgument indicates that the task's arguments couldn't be used.
+	KindInvalidArgument ErrorKind = "invalid-argument"
+	// KindCUE indicates an error evaluating CUE.
+	KindCUE ErrorKind = "cue"
+	// KindUnavailable indicates that the executor couldn't be reached, such as when its process or machine was lost.
+	KindUnavailable ErrorKind = "unavailable"
+)
+
+// Position is a location in a source file that an [Error] refers to.
+type Position struct {
+	Filename string
+	Line     int
+	Column   int
+}
+
+// String formats the position as file:line:column.
+func (p Position) String() string {
+	return fmt.Sprintf("%s:%d:%d", p.Filename, p.Line, p.Column)
+}
+
+// Error is a classified error, which retains its structure when crossing process boundaries.
+type Error struct {
+	Kind      ErrorKind
+	Message   string
+	Positions []Position
+	Retryable bool
+	Causes    []error
+}
+
+var _ error = (*Error)(nil)
+
+// Error implements error.
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the error's causes, for use by [errors.Is] and [errors.As].
+func (e *Error) Unwrap() []error {
+	return e.Causes
+}
+
+// Is reports whether the error is of the same kind as target.
+// [KindCanceled] and [KindDeadlineExceeded] also match their context errors.
+func (e *Error) Is(target error) bool {
+	if e.Kind == KindUnknown {
+		return false
+	}
+
+	switch target {
+	case context.Canceled:
+		return e.Kind == KindCanceled
+	case context.DeadlineExceeded:
+		return e.Kind == KindDeadlineExceeded
+	}
+
+	// Errors with only a kind can be used as targets to match any error of that kind
+	other, ok := target.(*Error) //nolint:errorlint // Only the target itself should be compared
+	if ok {
+		return other.Kind == e.Kind && other.Message == "" && len(other.Causes) == 0
+	}
+
+	return false
+}
+
+// NewError creates an [Error] of the given kind wrapping cause.
+func NewError(kind ErrorKind, cause error) *Error {
+	return &Error{
+		Kind:    kind,
+		Message: cause.Error(),
+		Causes:  []error{cause},
+	}
+}
+
+// Retryable marks err as safe to retry.
+func Retryable(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &Error{
+		Kind:      KindOf(err),
+		Message:   err.Error(),
+		Retryable: true,
+		Causes:    []error{err},
+	}
+}
+
+// IsRetryable reports whether any error in err's tree has been marked as retryable.
+func IsRetryable(err error) bool {
+	return findError(err, func(execErr *Error) bool {
+		return execErr.Retryable
+	}) != nil
+}
+
+// KindOf returns the kind of the first classified error in err's tree.
+func KindOf(err error) ErrorKind {
+	execErr := findError(err, func(execErr *Error) bool {
+		return execErr.Kind != KindUnknown
+	})
+	if execErr != nil {
+		return execErr.Kind
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return KindCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return KindDeadlineExceeded
+	default:
+		return KindUnknown
+	}
+}
+
+// findError does a depth-first search of err's tree for an [Error] matching match.
+func findError(err error, match func(*Error) bool) *Error {
+	if execErr, ok := err.(*Error); ok && match(execErr) { //nolint:errorlint // The tree is walked manually
+		return execErr
+	}
+
+	switch wrapper := err.(type) { //nolint:errorlint // The tree is walked manually
+	case interface{ Unwrap() error }:
+		return findError(wrapper.Unwrap(), match)
+
+	case interface{ Unwrap() []error }:
+		for _, cause := range wrapper.Unwrap() {
+			if found := findError(cause, match); found != nil {
+				return found
+			}
+		}
+	}
+
+	return nil
+}
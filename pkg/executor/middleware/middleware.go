@@ -0,0 +1,191 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+// Package middleware provides [Stack], which wraps an executor in the middleware configured for each task's route.
+package middleware
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"go.uber.org/multierr"
+
+	"go.bonk.build/pkg/executor"
+	"go.bonk.build/pkg/executor/router"
+	"go.bonk.build/pkg/task"
+)
+
+var (
+	// ErrDuplicateMiddleware is returned when several middleware are registered with the same name.
+	ErrDuplicateMiddleware = errors.New("duplicate middleware name")
+	// ErrUnknownMiddleware is returned when a route enables or disables middleware which isn't registered.
+	ErrUnknownMiddleware = errors.New("unknown middleware")
+)
+
+// Func wraps an executor with additional behavior, such as caching or retries.
+type Func = func(next executor.Executor) executor.Executor
+
+// Middleware is a named [Func], which routes may enable or disable.
+type Middleware struct {
+	// Name identifies the middleware in each route's [RouteConfig].
+	Name string
+	// Order positions the middleware in the stack.
+	// Middleware with lower orders are closer to the executor, so see tasks after those with higher orders.
+	// Middleware with the same order are stacked in the order they were registered.
+	Order int
+	// Optional middleware only wrap the routes which enable them, rather than every route which doesn't disable them.
+	Optional bool
+	// Wrap wraps the next executor in the stack. It's called once for each route with its own stack.
+	Wrap Func
+}
+
+// RouteConfig selects the middleware used for the tasks routed to an executor route,
+// and the executors beneath it which don't have their own.
+type RouteConfig struct {
+	// Enable lists optional middleware to use for the route.
+	Enable []string `json:"enable,omitempty" mapstructure:"enable"`
+	// Disable lists middleware not to use for the route.
+	Disable []string `json:"disable,omitempty" mapstructure:"disable"`
+}
+
+// Stack is an executor which executes each task through the middleware configured for its route.
+// Where several routes match a task, the config of the most specific is used.
+type Stack struct {
+	executor.Executor
+
+	// routes maps each configured route to the executor wrapped in its middleware
+	routes map[string]executor.Executor
+	// stacks contains every stack built over the executor, in the order they were built
+	stacks []executor.Executor
+	// routeNames are the keys of routes
+	routeNames []string
+	matcher    router.Matcher
+}
+
+var _ executor.Executor = (*Stack)(nil)
+
+// New wraps exec in the middleware configured for each route.
+// Routes without config use every middleware which isn't optional.
+func New(exec executor.Executor, middleware []Middleware, routes map[string]RouteConfig) (*Stack, error) {
+	byName := make(map[string]Middleware, len(middleware))
+	for _, mw := range middleware {
+		if _, ok := byName[mw.Name]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateMiddleware, mw.Name)
+		}
+		byName[mw.Name] = mw
+	}
+
+	var err error
+	for route, config := range routes {
+		for _, name := range slices.Concat(config.Enable, config.Disable) {
+			if _, ok := byName[name]; !ok {
+				multierr.AppendInto(&err, fmt.Errorf("%w %q in route %s", ErrUnknownMiddleware, name, route))
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Outermost first, so that each middleware wraps those after it
+	ordered := slices.Clone(middleware)
+	slices.SortStableFunc(ordered, func(a, b Middleware) int {
+		return cmp.Compare(b.Order, a.Order)
+	})
+
+	stack := &Stack{
+		Executor: exec,
+		routes:   make(map[string]executor.Executor, len(routes)),
+	}
+	stack.stacks = append(stack.stacks, stack.build(ordered, RouteConfig{}))
+	for route, config := range routes {
+		built := stack.build(ordered, config)
+		stack.routes[route] = built
+		stack.routeNames = append(stack.routeNames, route)
+		stack.stacks = append(stack.stacks, built)
+	}
+
+	return stack, nil
+}
+
+// build wraps the executor in the middleware selected by config.
+func (s *Stack) build(ordered []Middleware, config RouteConfig) executor.Executor {
+	// Sessions are opened on the executor once by the stack, rather than by each route's middleware
+	var exec executor.Executor = sessionless{exec: s.Executor}
+
+	for _, mw := range slices.Backward(ordered) {
+		enabled := !mw.Optional || slices.Contains(config.Enable, mw.Name)
+		if enabled && !slices.Contains(config.Disable, mw.Name) {
+			exec = mw.Wrap(exec)
+		}
+	}
+
+	return exec
+}
+
+// SetMatcher sets how tasks are matched against routes, such as to resolve the aliases of a router.
+// It must be called before any task is executed.
+func (s *Stack) SetMatcher(matcher router.Matcher) {
+	s.matcher = matcher
+}
+
+// OpenSession implements executor.Executor.
+func (s *Stack) OpenSession(ctx context.Context, session task.Session) error {
+	err := s.Executor.OpenSession(ctx, session)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	for idx, stack := range s.stacks {
+		err = stack.OpenSession(ctx, session)
+		if err != nil {
+			for _, opened := range s.stacks[:idx] {
+				opened.CloseSession(ctx, session.ID())
+			}
+			s.Executor.CloseSession(ctx, session.ID())
+
+			return err //nolint:wrapcheck
+		}
+	}
+
+	return nil
+}
+
+// CloseSession implements executor.Executor.
+func (s *Stack) CloseSession(ctx context.Context, sessionID task.SessionID) {
+	for _, stack := range s.stacks {
+		stack.CloseSession(ctx, sessionID)
+	}
+
+	s.Executor.CloseSession(ctx, sessionID)
+}
+
+// Execute implements executor.Executor.
+func (s *Stack) Execute(ctx context.Context, session task.Session, tsk *task.Task, result *task.Result) error {
+	return s.stackFor(tsk.Executor).Execute(ctx, session, tsk, result) //nolint:wrapcheck
+}
+
+// stackFor returns the stack of the most specific route matching the executor.
+func (s *Stack) stackFor(exec string) executor.Executor {
+	route, ok := s.matcher.MostSpecific(s.routeNames, exec)
+	if !ok {
+		return s.stacks[0]
+	}
+
+	return s.routes[route]
+}
+
+// sessionless forwards tasks to an executor whose sessions are managed elsewhere.
+type sessionless struct {
+	executor.NoopSessionManager
+
+	exec executor.Executor
+}
+
+// Execute implements executor.Executor.
+func (s sessionless) Execute(ctx context.Context, session task.Session, tsk *task.Task, result *task.Result) error {
+	return s.exec.Execute(ctx, session, tsk, result) //nolint:wrapcheck
+}
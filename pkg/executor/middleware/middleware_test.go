@@ -0,0 +1,159 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package middleware_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go.bonk.build/pkg/executor"
+	"go.bonk.build/pkg/executor/middleware"
+	"go.bonk.build/pkg/executor/mockexec"
+	"go.bonk.build/pkg/task"
+)
+
+// recorder creates middleware which records the order tasks pass through it.
+type recorder struct {
+	mu     sync.Mutex
+	passed []string
+}
+
+func (r *recorder) middleware(name string, order int, optional bool) middleware.Middleware {
+	return middleware.Middleware{
+		Name:     name,
+		Order:    order,
+		Optional: optional,
+		Wrap: func(next executor.Executor) executor.Executor {
+			return recording{Executor: next, name: name, recorder: r}
+		},
+	}
+}
+
+func (r *recorder) take() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	passed := r.passed
+	r.passed = nil
+
+	return passed
+}
+
+type recording struct {
+	executor.Executor
+
+	name     string
+	recorder *recorder
+}
+
+func (r recording) Execute(ctx context.Context, session task.Session, tsk *task.Task, result *task.Result) error {
+	r.recorder.mu.Lock()
+	r.recorder.passed = append(r.recorder.passed, r.name)
+	r.recorder.mu.Unlock()
+
+	return r.Executor.Execute(ctx, session, tsk, result) //nolint:wrapcheck
+}
+
+func TestStack(t *testing.T) {
+	t.Parallel()
+
+	exec := mockexec.NewMockExecutor(t)
+	exec.EXPECT().Execute(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	rec := &recorder{}
+	stack, err := middleware.New(exec, []middleware.Middleware{
+		rec.middleware("cache", 200, false),
+		rec.middleware("retry", 100, false),
+		rec.middleware("rate-limit", 300, true),
+	}, map[string]middleware.RouteConfig{
+		"test":      {Disable: []string{"cache"}},
+		"kube":      {Enable: []string{"rate-limit"}},
+		"kube.read": {},
+	})
+	require.NoError(t, err)
+
+	session := task.NewTestSession()
+	for name, expected := range map[string][]string{
+		"exec":           {"cache", "retry"},
+		"test.Unit":      {"retry"},
+		"testing":        {"cache", "retry"},
+		"kube.Apply":     {"rate-limit", "cache", "retry"},
+		"kube.read.List": {"cache", "retry"},
+	} {
+		err := stack.Execute(t.Context(), session, task.New("Task", name, nil), &task.Result{})
+		require.NoError(t, err)
+		assert.Equal(t, expected, rec.take(), name)
+	}
+}
+
+func TestStack_Sessions(t *testing.T) {
+	t.Parallel()
+
+	session := task.NewTestSession()
+
+	// The executor's sessions are opened once, however many routes have their own stack
+	exec := mockexec.NewMockExecutor(t)
+	exec.EXPECT().OpenSession(mock.Anything, session).Return(nil).Once()
+	exec.EXPECT().CloseSession(mock.Anything, session.ID()).Once()
+
+	opened := mockexec.NewMockExecutor(t)
+	opened.EXPECT().OpenSession(mock.Anything, session).Return(nil).Times(3)
+	opened.EXPECT().CloseSession(mock.Anything, session.ID()).Times(3)
+
+	stack, err := middleware.New(exec, []middleware.Middleware{{
+		Name: "sessions",
+		Wrap: func(next executor.Executor) executor.Executor {
+			return sessionRecording{Executor: next, opened: opened}
+		},
+	}}, map[string]middleware.RouteConfig{
+		"first":  {},
+		"second": {},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, stack.OpenSession(t.Context(), session))
+	stack.CloseSession(t.Context(), session.ID())
+}
+
+type sessionRecording struct {
+	executor.Executor
+
+	opened *mockexec.MockExecutor
+}
+
+func (s sessionRecording) OpenSession(ctx context.Context, session task.Session) error {
+	err := s.opened.OpenSession(ctx, session)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	return s.Executor.OpenSession(ctx, session) //nolint:wrapcheck
+}
+
+func (s sessionRecording) CloseSession(ctx context.Context, sessionID task.SessionID) {
+	s.opened.CloseSession(ctx, sessionID)
+	s.Executor.CloseSession(ctx, sessionID)
+}
+
+func TestStack_InvalidMiddleware(t *testing.T) {
+	t.Parallel()
+
+	exec := mockexec.NewMockExecutor(t)
+	rec := &recorder{}
+
+	_, err := middleware.New(exec, []middleware.Middleware{
+		rec.middleware("cache", 0, false),
+		rec.middleware("cache", 0, false),
+	}, nil)
+	require.ErrorIs(t, err, middleware.ErrDuplicateMiddleware)
+
+	_, err = middleware.New(exec, []middleware.Middleware{rec.middleware("cache", 0, false)},
+		map[string]middleware.RouteConfig{"test": {Disable: []string{"caching"}}})
+	require.ErrorIs(t, err, middleware.ErrUnknownMiddleware)
+}
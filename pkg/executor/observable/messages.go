@@ -3,7 +3,13 @@
 
 package observable
 
-import "go.bonk.build/pkg/task"
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.bonk.build/pkg/task"
+)
 
 // TaskStatus describes the current status of a task.
 type TaskStatus int
@@ -17,40 +23,139 @@ const (
 	StatusSuccess
 	// StatusError means the task has returned an error.
 	StatusError
+	// StatusCached means the task was up to date, and its previous result was reused.
+	StatusCached
+	// StatusCanceled means the task was stopped because the run was canceled.
+	StatusCanceled
+	// StatusRetrying means an attempt at executing the task failed, and it will be executed again.
+	StatusRetrying
+	// StatusSkipped means the task's condition didn't hold, so it wasn't executed.
+	StatusSkipped
 )
 
+// String returns a human-readable name for the status.
+func (s TaskStatus) String() string {
+	switch s {
+	case StatusNone:
+		return "pending"
+	case StatusRunning:
+		return "running"
+	case StatusSuccess:
+		return "success"
+	case StatusError:
+		return "failed"
+	case StatusCached:
+		return "cached"
+	case StatusCanceled:
+		return "canceled"
+	case StatusRetrying:
+		return "retrying"
+	case StatusSkipped:
+		return "skipped"
+	default:
+		return "unknown"
+	}
+}
+
 // TaskStatusMsg signifies a task's change in status.
 type TaskStatusMsg struct {
 	// TaskID is the task that this event is referring to.
 	TaskID task.ID
 	// Status is the new status for the task.
 	Status TaskStatus
+	// Time is when the status changed.
+	Time time.Time
 
-	// If Status == [StatusError], this will contain the error message returned from the executor chain.
+	// Session is the session the task is being executed in.
+	Session task.Session
+	// Executor is the executor the task was routed to.
+	Executor string
+	// Args contains the arguments the task was invoked with.
+	Args any
+	// Attempt counts the attempts at executing the task, starting from 1.
+	// For [StatusRetrying] it's the attempt which failed, otherwise it's the latest attempt.
+	Attempt int
+
+	// If Status == [StatusSuccess] or [StatusCached], this will contain the outputs of the task.
+	Result *task.Result
+	// If Status == [StatusError], [StatusCanceled] or [StatusRetrying],
+	// this will contain the error returned from the executor chain.
 	Error error
 }
 
 // TaskRunningMsg creates a [TaskStatusMsg] for a task with [StatusRunning].
-func TaskRunningMsg(id task.ID) TaskStatusMsg {
+func TaskRunningMsg(session task.Session, tsk *task.Task) TaskStatusMsg {
 	return TaskStatusMsg{
-		TaskID: id,
-		Status: StatusRunning,
+		TaskID:   tsk.ID,
+		Status:   StatusRunning,
+		Time:     time.Now(),
+		Session:  session,
+		Executor: tsk.Executor,
+		Args:     tsk.Args,
+		Attempt:  1,
 	}
 }
 
-// TaskFinishedMsg creates a [TaskStatusMsg] for a task that has finished executing.
-// Status is set to either [StatusSuccess] or [StatusError] (in which case Error is also set).
-func TaskFinishedMsg(id task.ID, err error) TaskStatusMsg {
-	if err != nil {
-		return TaskStatusMsg{
-			TaskID: id,
-			Status: StatusError,
-			Error:  err,
-		}
+// TaskRetryingMsg creates a [TaskStatusMsg] for a task with [StatusRetrying], whose attempt failed with err.
+func TaskRetryingMsg(session task.Session, tsk *task.Task, attempt int, err error) TaskStatusMsg {
+	return TaskStatusMsg{
+		TaskID:   tsk.ID,
+		Status:   StatusRetrying,
+		Time:     time.Now(),
+		Session:  session,
+		Executor: tsk.Executor,
+		Args:     tsk.Args,
+		Attempt:  attempt,
+		Error:    err,
 	}
+}
 
+// TaskSkippedMsg creates a [TaskStatusMsg] for a task with [StatusSkipped], which was never executed.
+func TaskSkippedMsg(session task.Session, tsk *task.Task) TaskStatusMsg {
 	return TaskStatusMsg{
-		TaskID: id,
-		Status: StatusSuccess,
+		TaskID:   tsk.ID,
+		Status:   StatusSkipped,
+		Time:     time.Now(),
+		Session:  session,
+		Executor: tsk.Executor,
+		Args:     tsk.Args,
 	}
 }
+
+// TaskFinishedMsg creates a [TaskStatusMsg] for a task that has finished executing.
+// Status is set to [StatusSuccess], [StatusCached] if the result was reused,
+// or [StatusError] or [StatusCanceled] (in which case Error is also set).
+func TaskFinishedMsg(
+	session task.Session,
+	tsk *task.Task,
+	result *task.Result,
+	err error,
+) TaskStatusMsg {
+	msg := TaskStatusMsg{
+		TaskID:   tsk.ID,
+		Status:   StatusSuccess,
+		Time:     time.Now(),
+		Session:  session,
+		Executor: tsk.Executor,
+		Args:     tsk.Args,
+		Attempt:  1,
+		Result:   result,
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		msg.Status = StatusCanceled
+		msg.Result = nil
+		msg.Error = err
+
+	case err != nil:
+		msg.Status = StatusError
+		msg.Result = nil
+		msg.Error = err
+
+	case result.IsCached():
+		msg.Status = StatusCached
+	}
+
+	return msg
+}
@@ -37,8 +37,9 @@ type observSession struct {
 }
 
 type observ struct {
-	exec     executor.Executor
-	sessions map[task.SessionID]*observSession
+	exec       executor.Executor
+	sessions   map[task.SessionID]*observSession
+	sessionsMu sync.RWMutex
 
 	listeners []Observer
 }
@@ -49,33 +50,69 @@ func (obs *observ) Execute(
 	tsk *task.Task,
 	result *task.Result,
 ) error {
+	obs.sessionsMu.RLock()
 	obsSession, ok := obs.sessions[session.ID()]
+	obs.sessionsMu.RUnlock()
 	if !ok {
 		return fmt.Errorf("%w: %s", ErrUnopenedSession, session.ID())
 	}
 
-	obs.trigger(obsSession, TaskRunningMsg(tsk.ID))
+	obs.trigger(obsSession, TaskRunningMsg(session, tsk))
+
+	// Attempts are retried one after another, by the executors beneath this one
+	attempts := 1
+	ctx = context.WithValue(ctx, retryReporterKey{}, retryReporter(func(attempt int, err error) {
+		attempts = attempt + 1
+		obs.trigger(obsSession, TaskRetryingMsg(session, tsk, attempt, err))
+	}))
 
 	err := obs.exec.Execute(ctx, session, tsk, result)
 
-	obs.trigger(obsSession, TaskFinishedMsg(tsk.ID, err))
+	msg := TaskFinishedMsg(session, tsk, result, err)
+	msg.Attempt = attempts
+	obs.trigger(obsSession, msg)
 
 	return err
 }
 
+type (
+	retryReporterKey struct{}
+	retryReporter    func(attempt int, err error)
+)
+
+// ReportRetry notifies the observers of the task being executed with ctx that its attempt failed with err,
+// and that it's being executed again.
+// It's called by executors which retry tasks, and does nothing if the task isn't being observed.
+func ReportRetry(ctx context.Context, attempt int, err error) {
+	if report, ok := ctx.Value(retryReporterKey{}).(retryReporter); ok {
+		report(attempt, err)
+	}
+}
+
 // OpenSession implements Observable.
 func (obs *observ) OpenSession(ctx context.Context, session task.Session) error {
+	obs.sessionsMu.Lock()
 	obs.sessions[session.ID()] = &observSession{}
+	obs.sessionsMu.Unlock()
 
 	return obs.exec.OpenSession(ctx, session)
 }
 
 // CloseSession implements Observable.
 func (obs *observ) CloseSession(ctx context.Context, sessionID task.SessionID) {
+	obs.sessionsMu.RLock()
+	obsSession, ok := obs.sessions[sessionID]
+	obs.sessionsMu.RUnlock()
+
 	// Block until outstanding tasks are done
-	obs.sessions[sessionID].waiter.Wait()
+	if ok {
+		obsSession.waiter.Wait()
+	}
+
 	// Remove the session from the map
+	obs.sessionsMu.Lock()
 	delete(obs.sessions, sessionID)
+	obs.sessionsMu.Unlock()
 
 	obs.exec.CloseSession(ctx, sessionID)
 }
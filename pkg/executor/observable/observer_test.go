@@ -9,6 +9,7 @@ import (
 	"testing/synctest"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"go.bonk.build/pkg/executor/mockexec"
@@ -45,7 +46,7 @@ func TestPass(t *testing.T) { //nolint:paralleltest
 		exec.EXPECT().OpenSession(t.Context(), session).Return(nil)
 		exec.EXPECT().CloseSession(t.Context(), session.ID())
 		exec.EXPECT().
-			Execute(t.Context(), session, tsk, &result).
+			Execute(mock.Anything, session, tsk, &result).
 			RunAndReturn(func(context.Context, task.Session, *task.Task, *task.Result) error {
 				<-cont
 
@@ -103,7 +104,7 @@ func TestFail(t *testing.T) { //nolint:paralleltest
 		exec.EXPECT().OpenSession(t.Context(), session).Return(nil)
 		exec.EXPECT().CloseSession(t.Context(), session.ID())
 		exec.EXPECT().
-			Execute(t.Context(), session, tsk, &result).
+			Execute(mock.Anything, session, tsk, &result).
 			RunAndReturn(func(context.Context, task.Session, *task.Task, *task.Result) error {
 				<-cont
 
@@ -130,6 +131,54 @@ func TestFail(t *testing.T) { //nolint:paralleltest
 	})
 }
 
+func TestRetry(t *testing.T) { //nolint:paralleltest
+	synctest.Test(t, func(t *testing.T) {
+		exec := mockexec.NewMockExecutor(t)
+		session := task.NewTestSession()
+		obs := observable.New(exec)
+
+		result := task.Result{}
+		tsk := task.New(task.NewID("testing"), "exec", nil)
+
+		var msgs []observable.TaskStatusMsg
+		err := obs.Listen(func(tsm observable.TaskStatusMsg) {
+			msgs = append(msgs, tsm)
+		})
+		require.NoError(t, err)
+
+		exec.EXPECT().OpenSession(t.Context(), session).Return(nil)
+		exec.EXPECT().
+			Execute(mock.Anything, session, tsk, &result).
+			RunAndReturn(func(ctx context.Context, _ task.Session, _ *task.Task, _ *task.Result) error {
+				synctest.Wait()
+				observable.ReportRetry(ctx, 1, assert.AnError)
+				synctest.Wait()
+				observable.ReportRetry(ctx, 2, assert.AnError)
+				synctest.Wait()
+
+				return nil
+			})
+
+		err = obs.OpenSession(t.Context(), session)
+		require.NoError(t, err)
+
+		err = obs.Execute(t.Context(), session, tsk, &result)
+		require.NoError(t, err)
+		synctest.Wait()
+
+		require.Len(t, msgs, 4)
+		assert.Equal(t, observable.StatusRunning, msgs[0].Status)
+		assert.Equal(t, 1, msgs[0].Attempt)
+		assert.Equal(t, observable.StatusRetrying, msgs[1].Status)
+		assert.Equal(t, 1, msgs[1].Attempt)
+		assert.ErrorIs(t, msgs[1].Error, assert.AnError)
+		assert.Equal(t, observable.StatusRetrying, msgs[2].Status)
+		assert.Equal(t, 2, msgs[2].Attempt)
+		assert.Equal(t, observable.StatusSuccess, msgs[3].Status)
+		assert.Equal(t, 3, msgs[3].Attempt)
+	})
+}
+
 func TestUnopened(t *testing.T) {
 	t.Parallel()
 
@@ -146,3 +195,33 @@ func TestUnopened(t *testing.T) {
 	err := obs.Execute(t.Context(), session, tsk, &result)
 	require.ErrorIs(t, err, observable.ErrUnopenedSession)
 }
+
+func TestFinishedStatus(t *testing.T) {
+	t.Parallel()
+
+	session := task.NewTestSession()
+	tsk := task.New(task.NewID("testing"), "exec", nil)
+
+	cached := &task.Result{}
+	cached.MarkCached()
+
+	for name, test := range map[string]struct {
+		result   *task.Result
+		err      error
+		expected observable.TaskStatus
+	}{
+		"success":  {result: &task.Result{}, expected: observable.StatusSuccess},
+		"cached":   {result: cached, expected: observable.StatusCached},
+		"failed":   {result: &task.Result{}, err: assert.AnError, expected: observable.StatusError},
+		"canceled": {result: &task.Result{}, err: context.Canceled, expected: observable.StatusCanceled},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			msg := observable.TaskFinishedMsg(session, tsk, test.result, test.err)
+			assert.Equal(t, test.expected, msg.Status)
+			assert.Equal(t, name, msg.Status.String())
+			assert.ErrorIs(t, msg.Error, test.err)
+		})
+	}
+}
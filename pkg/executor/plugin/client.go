@@ -8,14 +8,27 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"os/exec"
+	"strings"
+	"sync"
+
+	"go.uber.org/multierr"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/ValerySidorin/shclog"
 
 	goplugin "github.com/hashicorp/go-plugin"
 
 	"go.bonk.build/pkg/executor"
+	"go.bonk.build/pkg/executor/argconv"
+	"go.bonk.build/pkg/executor/router"
 	"go.bonk.build/pkg/executor/rpc"
+	"go.bonk.build/pkg/profile"
+	"go.bonk.build/pkg/task"
+	"go.bonk.build/pkg/tracing"
 )
 
 var handshake = goplugin.HandshakeConfig{
@@ -24,23 +37,132 @@ var handshake = goplugin.HandshakeConfig{
 	MagicCookieValue: "bonk the builder",
 }
 
+// MaxRestarts is the number of times a crashed plugin is restarted before giving up on it.
+const MaxRestarts = 3
+
+var (
+	// ErrPluginCrashed is returned for tasks which were executing when their plugin crashed.
+	ErrPluginCrashed = errors.New("plugin crashed")
+	// ErrPluginExited is returned when a plugin has exited and can't be restarted.
+	ErrPluginExited = errors.New("plugin exited")
+)
+
 // PluginClient manages a [goplugin.Client] and exposes it as a [executor.Executor].
+// If the plugin process crashes, it's restarted up to [MaxRestarts] times, and open sessions are re-opened.
+//
+// Lazy clients don't start the plugin process until it's first needed to execute a task,
+// until then sessions are only remembered.
 type PluginClient struct {
+	name  string
+	start func(ctx context.Context) (*pluginProcess, error)
+
+	mu       sync.RWMutex
+	process  *pluginProcess
+	restarts int
+	sessions map[task.SessionID]task.Session
+	// launching is closed once the process being started or restarted is up, or has failed to start.
+	// It's nil unless a process is being launched, which is done without holding mu.
+	launching chan struct{}
+	// cancelLaunch stops the process being launched, which isn't canceled with the task that launched it.
+	cancelLaunch context.CancelFunc
+	// startErr is set if a lazy client failed to start, so that it isn't attempted for every task.
+	startErr error
+	shutdown bool
+
+	// described caches the plugin's description, which is shared by the clients of a pool.
+	described *pluginDescription
+}
+
+// pluginDescription caches the description of a plugin, from the first successful call to Describe.
+type pluginDescription struct {
+	mu          sync.RWMutex
+	description *executor.Description
+	// undescribable is set once the plugin is known to not support Describe.
+	undescribable bool
+}
+
+var (
+	_ executor.Executor  = (*PluginClient)(nil)
+	_ executor.Describer = (*PluginClient)(nil)
+	_ managedPlugin      = (*PluginClient)(nil)
+)
+
+// pluginProcess is a single run of a plugin subprocess.
+type pluginProcess struct {
 	executor.Executor
 
-	pluginClient *goplugin.Client
+	exited func() bool
+	ping   func() error
+	kill   func()
+}
+
+// NewPluginClient starts a plugin binary as a subprocess and opens a gRPC connection to it.
+// Use [Store.Resolve] to find the binary for a plugin.
+func NewPluginClient(ctx context.Context, name string, binary string) (*PluginClient, error) {
+	return newPluginClient(ctx, name, func(ctx context.Context) (*pluginProcess, error) {
+		return startProcess(ctx, name, binary)
+	})
 }
 
-var _ executor.Executor = (*PluginClient)(nil)
+// NewLazyPluginClient creates a [PluginClient] which resolves the plugin ref with store
+// and starts it when the first task is executed.
+func NewLazyPluginClient(store *Store, name string, ref string) *PluginClient {
+	return newLazyPluginClient(name, func(ctx context.Context) (*pluginProcess, error) {
+		ctx = profile.WithLane(ctx, "plugin "+name)
+
+		region := profile.Begin(ctx, "plugin", "resolve "+ref)
+		pluginName, binary, err := store.Resolve(ctx, ref)
+		region.End()
+
+		if err != nil {
+			return nil, err
+		}
+
+		region = profile.Begin(ctx, "plugin", "start "+pluginName)
+		region.SetArg("path", binary)
+		defer region.End()
+
+		return startProcess(ctx, pluginName, binary)
+	})
+}
+
+func newPluginClient(
+	ctx context.Context,
+	name string,
+	start func(ctx context.Context) (*pluginProcess, error),
+) (*PluginClient, error) {
+	process, err := start(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	plugin := newLazyPluginClient(name, start)
+	plugin.process = process
+
+	return plugin, nil
+}
+
+func newLazyPluginClient(name string, start func(ctx context.Context) (*pluginProcess, error)) *PluginClient {
+	return &PluginClient{
+		name:      name,
+		start:     start,
+		sessions:  make(map[task.SessionID]task.Session),
+		described: &pluginDescription{},
+	}
+}
+
+func startProcess(ctx context.Context, name string, binary string) (*pluginProcess, error) {
+	// The process must outlive ctx so that tasks can be canceled gracefully, it is stopped by Shutdown.
+	cmd := exec.CommandContext(context.WithoutCancel(ctx), binary)
+	cmd.Env = append(cmd.Environ(), tracing.Environ(name)...)
 
-// NewPluginClient starts a plugin subprocess and opens a gRPC connection to it.
-func NewPluginClient(ctx context.Context, goCmdPath string) (*PluginClient, error) {
 	client := goplugin.NewClient(&goplugin.ClientConfig{
 		HandshakeConfig: handshake,
-		Cmd:             exec.CommandContext(ctx, "go", "run", goCmdPath),
+		Cmd:             cmd,
 		AllowedProtocols: []goplugin.Protocol{
 			goplugin.ProtocolGRPC,
 		},
+		GRPCDialOptions: rpc.DialOptions(),
 		// Necessary for it to not abort immediately
 		VersionedPlugins: map[int]goplugin.PluginSet{
 			int(handshake.ProtocolVersion): {}, //nolint:gosec
@@ -48,12 +170,10 @@ func NewPluginClient(ctx context.Context, goCmdPath string) (*PluginClient, erro
 		Logger: shclog.New(slog.Default()),
 	})
 
-	plug := &PluginClient{
-		pluginClient: client,
-	}
-
 	rpcClient, err := client.Client()
 	if err != nil {
+		client.Kill()
+
 		return nil, fmt.Errorf("failed to create client: %w", err)
 	}
 
@@ -62,12 +182,471 @@ func NewPluginClient(ctx context.Context, goCmdPath string) (*PluginClient, erro
 		panic(errors.New("rpcclient is of the wrong type"))
 	}
 
-	plug.Executor = rpc.NewGRPCClient(grpcClient.Conn)
+	return &pluginProcess{
+		Executor: rpc.NewGRPCClient(grpcClient.Conn),
+		exited:   client.Exited,
+		ping:     grpcClient.Ping,
+		kill:     client.Kill,
+	}, nil
+}
+
+// Execute implements executor.Executor.
+// If the plugin crashes while executing the task, it's restarted and the task fails with [ErrPluginCrashed].
+func (plugin *PluginClient) Execute(
+	ctx context.Context,
+	session task.Session,
+	tsk *task.Task,
+	result *task.Result,
+) error {
+	err := plugin.checkExecutor(ctx, tsk)
+	if err != nil {
+		return err
+	}
+
+	process, err := plugin.healthyProcess(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = process.Execute(ctx, session, tsk, result)
+	// Plugins are expected to go away if the run has been canceled
+	if err == nil || ctx.Err() != nil || !process.crashed(err) {
+		return err //nolint:wrapcheck
+	}
+
+	slog.WarnContext(ctx, "plugin crashed while executing task", "plugin", plugin.name, "task", tsk.ID, "error", err)
+
+	restartErr := plugin.restart(ctx, process)
+	if restartErr != nil {
+		return fmt.Errorf("%w: %s while executing %s: %w", ErrPluginCrashed, plugin.name, tsk.ID, restartErr)
+	}
+
+	// The plugin is back up, so the task is safe to try again
+	return executor.Retryable(fmt.Errorf("%w: %s while executing %s: %w", ErrPluginCrashed, plugin.name, tsk.ID, err))
+}
+
+// OpenSession implements executor.Executor.
+// Sessions are remembered, so that they may be re-opened if the plugin is restarted.
+func (plugin *PluginClient) OpenSession(ctx context.Context, session task.Session) error {
+	plugin.mu.Lock()
+	if plugin.process == nil && plugin.launching == nil && !plugin.shutdown {
+		// Opened once the plugin starts
+		plugin.sessions[session.ID()] = session
+		plugin.mu.Unlock()
+
+		return nil
+	}
+	plugin.mu.Unlock()
+
+	process, err := plugin.healthyProcess(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = process.OpenSession(ctx, session)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	plugin.mu.Lock()
+	plugin.sessions[session.ID()] = session
+	plugin.mu.Unlock()
+
+	return nil
+}
+
+// CloseSession implements executor.Executor.
+func (plugin *PluginClient) CloseSession(ctx context.Context, sessionID task.SessionID) {
+	plugin.mu.Lock()
+	delete(plugin.sessions, sessionID)
+	process := plugin.process
+	plugin.mu.Unlock()
+
+	if process != nil && !process.exited() {
+		process.CloseSession(ctx, sessionID)
+	}
+}
+
+// Describe implements executor.Describer.
+// The description is requested from the plugin once, and cached.
+func (plugin *PluginClient) Describe(ctx context.Context) (executor.Description, error) {
+	description, cached, err := plugin.described.cached(plugin.name)
+	if cached {
+		return description, err
+	}
+
+	process, err := plugin.healthyProcess(ctx)
+	if err != nil {
+		return executor.Description{}, err
+	}
+
+	describer, ok := process.Executor.(executor.Describer)
+	if !ok {
+		err = errors.ErrUnsupported
+	} else {
+		var desc executor.Description
+		desc, err = describer.Describe(ctx)
+		if err == nil {
+			plugin.described.mu.Lock()
+			plugin.described.description = &desc
+			plugin.described.mu.Unlock()
+
+			return desc, nil
+		}
+	}
+
+	// Plugins built against older versions of bonk won't learn to describe themselves
+	if errors.Is(err, errors.ErrUnsupported) || status.Code(err) == codes.Unimplemented {
+		plugin.described.mu.Lock()
+		plugin.described.undescribable = true
+		plugin.described.mu.Unlock()
+	}
+
+	return executor.Description{}, fmt.Errorf("failed to describe plugin %s: %w", plugin.name, err)
+}
+
+// cached returns the description of the plugin, or why it can't be described, if either is known.
+func (described *pluginDescription) cached(name string) (executor.Description, bool, error) {
+	described.mu.RLock()
+	defer described.mu.RUnlock()
+
+	switch {
+	case described.description != nil:
+		return *described.description, true, nil
+	case described.undescribable:
+		return executor.Description{}, true, fmt.Errorf("failed to describe plugin %s: %w", name, errors.ErrUnsupported)
+	}
+
+	return executor.Description{}, false, nil
+}
+
+// Healthy returns an error if the plugin process has exited or isn't responding.
+func (plugin *PluginClient) Healthy() error {
+	plugin.mu.RLock()
+	process, shutdown := plugin.process, plugin.shutdown
+	plugin.mu.RUnlock()
+
+	switch {
+	case process == nil && !shutdown:
+		// Not started yet
+		return nil
+	case process == nil || process.exited():
+		return fmt.Errorf("%w: %s", ErrPluginExited, plugin.name)
+	}
+
+	err := process.ping()
+	if err != nil {
+		return fmt.Errorf("plugin %s is unhealthy: %w", plugin.name, err)
+	}
+
+	return nil
+}
+
+// restartUnhealthy restarts the plugin process if it has exited or isn't responding,
+// returning why it was unhealthy. Plugins which haven't started yet are left alone.
+func (plugin *PluginClient) restartUnhealthy(ctx context.Context) error {
+	err := plugin.Healthy()
+	if err == nil {
+		return nil
+	}
+
+	plugin.mu.RLock()
+	process := plugin.process
+	plugin.mu.RUnlock()
+
+	if process != nil {
+		multierr.AppendInto(&err, plugin.restart(ctx, process))
+	}
 
-	return plug, nil
+	return err
 }
 
 // Shutdown kills the subprocess.
 func (plugin *PluginClient) Shutdown() {
-	plugin.pluginClient.Kill()
+	plugin.mu.Lock()
+	process := plugin.process
+	plugin.process = nil
+	plugin.shutdown = true
+	cancelLaunch := plugin.cancelLaunch
+	plugin.mu.Unlock()
+
+	if cancelLaunch != nil {
+		cancelLaunch()
+	}
+
+	if process != nil {
+		process.kill()
+	}
+}
+
+// checkExecutor fails tasks for executors the plugin doesn't provide, or whose arguments don't match the executor's
+// schema, without sending them to the plugin. Plugins which can't describe themselves are sent every task.
+func (plugin *PluginClient) checkExecutor(ctx context.Context, tsk *task.Task) error {
+	desc, err := plugin.Describe(ctx)
+	if err != nil {
+		slog.DebugContext(ctx, "not checking executor", "plugin", plugin.name, "error", err)
+
+		return nil
+	}
+
+	described, ok := desc.Executor(tsk.Executor)
+	if !ok {
+		return executor.NewError(executor.KindInvalidArgument, fmt.Errorf(
+			"%w: %s in plugin %s (available: %s)",
+			router.ErrNoExecutorFound,
+			tsk.Executor,
+			plugin.name,
+			strings.Join(desc.ExecutorNames(), ", "),
+		))
+	}
+
+	err = argconv.ValidateArgs(described.Schema, tsk.Args)
+	switch {
+	case errors.Is(err, argconv.ErrInvalidArgs):
+		return executor.NewError(
+			executor.KindInvalidArgument,
+			fmt.Errorf("%s in plugin %s: %w", tsk.Executor, plugin.name, err),
+		)
+	case err != nil:
+		// The plugin validates the arguments itself
+		slog.DebugContext(ctx, "not checking arguments", "plugin", plugin.name, "executor", tsk.Executor, "error", err)
+	}
+
+	return nil
+}
+
+// healthyProcess returns the current plugin process.
+// The process is started if it hasn't been yet, or restarted if it has exited since the last task.
+func (plugin *PluginClient) healthyProcess(ctx context.Context) (*pluginProcess, error) {
+	for {
+		plugin.mu.RLock()
+		process, shutdown, launching := plugin.process, plugin.shutdown, plugin.launching
+		plugin.mu.RUnlock()
+
+		switch {
+		case shutdown:
+			return nil, fmt.Errorf("%w: %s has been shut down", ErrPluginExited, plugin.name)
+		case launching != nil:
+			err := awaitLaunch(ctx, launching)
+			if err != nil {
+				return nil, err
+			}
+
+			continue
+		case process == nil:
+			return plugin.startLazily(ctx)
+		case !process.exited():
+			return process, nil
+		}
+
+		slog.WarnContext(ctx, "plugin exited unexpectedly", "plugin", plugin.name)
+
+		err := plugin.restart(ctx, process)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// startLazily starts the first plugin process, unless another task has already done so.
+func (plugin *PluginClient) startLazily(ctx context.Context) (*pluginProcess, error) {
+	plugin.mu.Lock()
+
+	switch {
+	case plugin.shutdown:
+		plugin.mu.Unlock()
+
+		return nil, fmt.Errorf("%w: %s has been shut down", ErrPluginExited, plugin.name)
+	case plugin.process != nil:
+		process := plugin.process
+		plugin.mu.Unlock()
+
+		return process, nil
+	case plugin.startErr != nil:
+		err := plugin.startErr
+		plugin.mu.Unlock()
+
+		return nil, err
+	case plugin.launching != nil:
+		// Started by a concurrent task
+		launching := plugin.launching
+		plugin.mu.Unlock()
+
+		err := awaitLaunch(ctx, launching)
+		if err != nil {
+			return nil, err
+		}
+
+		return plugin.healthyProcess(ctx)
+	}
+
+	slog.DebugContext(ctx, "starting plugin", "plugin", plugin.name)
+
+	return plugin.launch(ctx)
+}
+
+// restart replaces crashed with a new process, unless another task has already done so.
+func (plugin *PluginClient) restart(ctx context.Context, crashed *pluginProcess) error {
+	plugin.mu.Lock()
+
+	switch {
+	case plugin.shutdown:
+		plugin.mu.Unlock()
+
+		return fmt.Errorf("%w: %s has been shut down", ErrPluginExited, plugin.name)
+	case plugin.launching != nil:
+		// Being restarted by a concurrent task
+		launching := plugin.launching
+		plugin.mu.Unlock()
+
+		return awaitLaunch(ctx, launching)
+	case plugin.process != crashed:
+		// Already restarted by a concurrent task
+		plugin.mu.Unlock()
+
+		return nil
+	case plugin.restarts >= MaxRestarts:
+		plugin.mu.Unlock()
+
+		return fmt.Errorf("%w: %s crashed too many times (%d restarts)", ErrPluginExited, plugin.name, plugin.restarts)
+	}
+
+	plugin.restarts++
+	crashed.kill()
+
+	slog.InfoContext(ctx, "restarting plugin", "plugin", plugin.name, "restart", plugin.restarts, "max", MaxRestarts)
+
+	_, err := plugin.launch(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to restart plugin %s: %w", plugin.name, err)
+	}
+
+	return nil
+}
+
+// launch starts a new process and opens the remembered sessions in it, making it the plugin's process.
+// It must be called with mu held, which it releases while the process starts, and returns without.
+//
+// The process is started in the background, so that canceling ctx only stops waiting for it:
+// a task canceled while its plugin is being built mustn't leave the plugin unusable. It's stopped by Shutdown.
+func (plugin *PluginClient) launch(ctx context.Context) (*pluginProcess, error) {
+	launching := make(chan struct{})
+	plugin.launching = launching
+	sessions := maps.Clone(plugin.sessions)
+
+	startCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	plugin.cancelLaunch = cancel
+	plugin.mu.Unlock()
+
+	type launched struct {
+		process *pluginProcess
+		err     error
+	}
+
+	result := make(chan launched, 1)
+	go func() {
+		defer cancel()
+
+		process, err := plugin.startWith(startCtx, launching, sessions)
+		result <- launched{process, err}
+	}()
+
+	select {
+	case launched := <-result:
+		return launched.process, launched.err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("stopped waiting for plugin %s to start: %w", plugin.name, context.Cause(ctx))
+	}
+}
+
+// startWith starts a process for launch and opens sessions in it, closing launching once it's done.
+func (plugin *PluginClient) startWith(
+	ctx context.Context,
+	launching chan struct{},
+	sessions map[task.SessionID]task.Session,
+) (*pluginProcess, error) {
+	process, startErr := plugin.start(ctx)
+
+	// Bring the new process up to date with the sessions that are open
+	var sessionErr error
+	for _, session := range sessions {
+		if startErr != nil {
+			break
+		}
+
+		sessionErr = process.OpenSession(ctx, session)
+		if sessionErr != nil {
+			process.kill()
+			sessionErr = fmt.Errorf("failed to open session %s: %w", session.ID(), sessionErr)
+
+			break
+		}
+	}
+
+	plugin.mu.Lock()
+	defer close(launching)
+
+	plugin.launching = nil
+	plugin.cancelLaunch = nil
+
+	switch {
+	case plugin.shutdown:
+		plugin.mu.Unlock()
+
+		if process != nil {
+			process.kill()
+		}
+
+		return nil, fmt.Errorf("%w: %s has been shut down", ErrPluginExited, plugin.name)
+	case startErr != nil && plugin.process == nil:
+		err := fmt.Errorf("failed to start plugin %s: %w", plugin.name, startErr)
+		// Lazy clients don't try to start again for every task, unless starting was interrupted
+		if ctx.Err() == nil {
+			plugin.startErr = err
+		}
+		plugin.mu.Unlock()
+
+		return nil, err
+	case startErr != nil:
+		plugin.mu.Unlock()
+
+		return nil, startErr
+	case sessionErr != nil:
+		// The process started, so opening sessions may succeed the next time
+		plugin.mu.Unlock()
+
+		return nil, sessionErr
+	}
+
+	plugin.process = process
+
+	// Sessions closed while the process was starting would otherwise stay open in it
+	var closed []task.SessionID
+	for id := range sessions {
+		if _, open := plugin.sessions[id]; !open {
+			closed = append(closed, id)
+		}
+	}
+	plugin.mu.Unlock()
+
+	for _, id := range closed {
+		process.CloseSession(ctx, id)
+	}
+
+	return process, nil
+}
+
+// awaitLaunch waits for a process being launched by another task to be up, or to fail to start.
+func awaitLaunch(ctx context.Context, launching <-chan struct{}) error {
+	select {
+	case <-launching:
+		return nil
+	case <-ctx.Done():
+		return context.Cause(ctx) //nolint:wrapcheck
+	}
+}
+
+// crashed reports whether err was caused by the process going away, rather than by the task.
+func (process *pluginProcess) crashed(err error) bool {
+	return process.exited() || status.Code(err) == codes.Unavailable
 }
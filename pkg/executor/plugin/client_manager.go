@@ -5,8 +5,9 @@ package plugin
 
 import (
 	"context"
-	"path"
+	"log/slog"
 	"sync"
+	"time"
 
 	"go.uber.org/multierr"
 
@@ -21,53 +22,105 @@ type PluginClientManager interface {
 	// NOTE(colden): these should eventually be moved out of here
 	RegisterExecutor(name string, exec executor.Executor) error
 	UnregisterExecutors(names ...string)
-
+	// RegisterAlias routes the executors beneath alias to those beneath target, as [router.Router.RegisterAlias].
+	RegisterAlias(alias string, target string) error
+	// Routes lists the routes of every executor and alias, as [router.Router.Routes].
+	Routes() []router.Route
+	// Matcher matches executors against routes once their aliases are resolved, as [router.Router.Matcher].
+	Matcher() router.Matcher
+
+	// RegisterPlugin registers a plugin to handle the executors beneath prefix, run as described by pool.
+	// The plugin isn't resolved or started until the first task is routed to it.
+	RegisterPlugin(prefix string, pluginRef string, pool PoolOptions) error
+	// RegisterPlugins calls RegisterPlugin for each plugin with a single process, prefixed by its [RefName].
+	RegisterPlugins(pluginRefs ...string) error
+	// StartPlugins registers and immediately starts plugins, prefixed by their [RefName].
 	StartPlugins(ctx context.Context, plugins ...string) error
 	Shutdown(ctx context.Context)
 }
 
+// HealthCheckInterval is how often plugin processes are checked, and restarted if they've exited or stopped responding.
+const HealthCheckInterval = 30 * time.Second
+
 type pluginClientManager struct {
 	router.Router
 
-	mu sync.Mutex
+	store *Store
+	mu    sync.Mutex
+
+	stopHealthChecks context.CancelFunc
+}
+
+// NewPluginClientManager creates a new empty [PluginClientManager], which finds plugins in store.
+// The processes of its plugins are checked every [HealthCheckInterval] until it's shut down.
+func NewPluginClientManager(store *Store) PluginClientManager {
+	ctx, cancel := context.WithCancel(context.Background())
+	pm := &pluginClientManager{
+		Router:           router.New(),
+		store:            store,
+		stopHealthChecks: cancel,
+	}
+
+	go pm.checkHealthEvery(ctx, HealthCheckInterval)
+
+	return pm
+}
+
+// RegisterPlugin implements PluginClientManager.
+func (pm *pluginClientManager) RegisterPlugin(prefix string, pluginRef string, pool PoolOptions) error {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	if !pool.Isolated && pool.Size <= 1 {
+		return pm.RegisterExecutor(prefix, NewLazyPluginClient(pm.store, prefix, pluginRef))
+	}
+
+	return pm.RegisterExecutor(prefix, newPluginPool(prefix, pool, func() *PluginClient {
+		return NewLazyPluginClient(pm.store, prefix, pluginRef)
+	}))
 }
 
-// NewPluginClientManager creates a new empty [PluginClientManager].
-func NewPluginClientManager() PluginClientManager {
-	return &pluginClientManager{
-		Router: router.New(),
+// RegisterPlugins implements PluginClientManager.
+func (pm *pluginClientManager) RegisterPlugins(pluginRefs ...string) error {
+	var err error
+	for _, pluginRef := range pluginRefs {
+		multierr.AppendInto(&err, pm.RegisterPlugin(RefName(pluginRef), pluginRef, PoolOptions{}))
 	}
+
+	return err
 }
 
-// StartPlugin calls [NewPluginClient] and registers the executor by the plugin's name.
-func (pm *pluginClientManager) StartPlugin(ctx context.Context, pluginPath string) error {
-	plug, err := NewPluginClient(ctx, pluginPath)
+// StartPlugin resolves and starts the plugin, and registers the executor by the plugin's name.
+func (pm *pluginClientManager) StartPlugin(ctx context.Context, pluginRef string) error {
+	plug := NewLazyPluginClient(pm.store, RefName(pluginRef), pluginRef)
+
+	_, err := plug.healthyProcess(ctx)
 	if err != nil {
 		return err
 	}
 
-	pluginName := path.Base(pluginPath)
-
 	pm.mu.Lock()
-	err = pm.RegisterExecutor(pluginName, plug)
+	err = pm.RegisterExecutor(RefName(pluginRef), plug)
 	pm.mu.Unlock()
 
 	if err != nil {
+		plug.Shutdown()
+
 		return err
 	}
 
 	return nil
 }
 
-// StartPlugins calls [StartPlugin] in parallel per pluginPath.
-func (pm *pluginClientManager) StartPlugins(ctx context.Context, pluginPath ...string) error {
+// StartPlugins calls [StartPlugin] in parallel per pluginRef.
+func (pm *pluginClientManager) StartPlugins(ctx context.Context, pluginRef ...string) error {
 	var (
 		pluginWaiter sync.WaitGroup
 		allErrs      error
 		errMu        sync.Mutex
 	)
 
-	for _, plugin := range pluginPath {
+	for _, plugin := range pluginRef {
 		pluginWaiter.Go(func() {
 			err := pm.StartPlugin(ctx, plugin)
 
@@ -82,15 +135,60 @@ func (pm *pluginClientManager) StartPlugins(ctx context.Context, pluginPath ...s
 	return allErrs
 }
 
+// checkHealthEvery calls checkHealth every interval until ctx is done.
+func (pm *pluginClientManager) checkHealthEvery(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		err := pm.checkHealth(ctx)
+		if err != nil {
+			slog.WarnContext(ctx, "restarted unhealthy plugins", "error", err)
+		}
+	}
+}
+
+// checkHealth restarts the plugin processes which have exited or aren't responding, returning why they were unhealthy.
+// Processes which exit while idle would otherwise only be restarted by the next task routed to them.
+func (pm *pluginClientManager) checkHealth(ctx context.Context) error {
+	var plugins []managedPlugin
+	pm.ForEachExecutor(func(_ string, exec executor.Executor) {
+		if plug, ok := exec.(managedPlugin); ok {
+			plugins = append(plugins, plug)
+		}
+	})
+
+	// Restarting a plugin starts a process, which shouldn't block routing
+	var err error
+	for _, plug := range plugins {
+		multierr.AppendInto(&err, plug.restartUnhealthy(ctx))
+	}
+
+	return err
+}
+
 // Shutdown does de initialization and kills all plugin processes.
+// It is safe to call while tasks are executing, which will fail once their plugin is killed.
 func (pm *pluginClientManager) Shutdown(context.Context) {
+	pm.stopHealthChecks()
+
 	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	var names []string
 	pm.ForEachExecutor(func(name string, exec executor.Executor) {
-		pm.UnregisterExecutors(name)
+		names = append(names, name)
 
-		if plug, ok := exec.(*PluginClient); ok {
+		if plug, ok := exec.(managedPlugin); ok {
 			plug.Shutdown()
 		}
 	})
-	pm.mu.Unlock()
+
+	pm.UnregisterExecutors(names...)
 }
@@ -0,0 +1,326 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package plugin
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go.bonk.build/pkg/executor"
+	"go.bonk.build/pkg/executor/mockexec"
+	"go.bonk.build/pkg/task"
+)
+
+// fakeProcesses starts processes backed by mock executors, configured by setup.
+type fakeProcesses struct {
+	started []*fakeProcess
+	setup   func(exec *mockexec.MockExecutor)
+}
+
+type fakeProcess struct {
+	exec         *mockexec.MockExecutor
+	exited       atomic.Bool
+	unresponsive atomic.Bool
+}
+
+func (f *fakeProcesses) start(t *testing.T) func(context.Context) (*pluginProcess, error) {
+	t.Helper()
+
+	return func(context.Context) (*pluginProcess, error) {
+		fake := &fakeProcess{
+			exec: mockexec.NewMockExecutor(t),
+		}
+		f.setup(fake.exec)
+		f.started = append(f.started, fake)
+
+		return &pluginProcess{
+			Executor: fake.exec,
+			exited:   fake.exited.Load,
+			ping: func() error {
+				if fake.unresponsive.Load() {
+					return status.Error(codes.DeadlineExceeded, "ping timed out")
+				}
+
+				return nil
+			},
+			kill: func() { fake.exited.Store(true) },
+		}, nil
+	}
+}
+
+func TestPluginClient_Restart(t *testing.T) {
+	t.Parallel()
+
+	session := task.NewTestSession()
+	tsk := task.New(task.NewID("Test"), "test.Test", nil)
+
+	processes := &fakeProcesses{}
+	processes.setup = func(exec *mockexec.MockExecutor) {
+		exec.EXPECT().OpenSession(mock.Anything, session).Return(nil)
+	}
+
+	plug, err := newPluginClient(t.Context(), "test", processes.start(t))
+	require.NoError(t, err)
+	require.NoError(t, plug.OpenSession(t.Context(), session))
+	require.NoError(t, plug.Healthy())
+
+	// Crash while executing
+	processes.started[0].exec.EXPECT().Execute(mock.Anything, session, tsk, mock.Anything).
+		RunAndReturn(func(context.Context, task.Session, *task.Task, *task.Result) error {
+			processes.started[0].exited.Store(true)
+
+			return status.Error(codes.Unavailable, "connection reset")
+		})
+
+	err = plug.Execute(t.Context(), session, tsk, &task.Result{})
+	require.ErrorIs(t, err, ErrPluginCrashed)
+	assert.ErrorContains(t, err, "Test")
+	assert.True(t, executor.IsRetryable(err))
+
+	// The session was re-opened on the new process, which is used from now on
+	require.Len(t, processes.started, 2)
+	processes.started[1].exec.EXPECT().Execute(mock.Anything, session, tsk, mock.Anything).Return(nil)
+	require.NoError(t, plug.Execute(t.Context(), session, tsk, &task.Result{}))
+
+	plug.Shutdown()
+	assert.True(t, processes.started[1].exited.Load())
+	require.ErrorIs(t, plug.Healthy(), ErrPluginExited)
+	require.ErrorIs(t, plug.Execute(t.Context(), session, tsk, &task.Result{}), ErrPluginExited)
+}
+
+func TestPluginClient_RestartUnhealthy(t *testing.T) {
+	t.Parallel()
+
+	session := task.NewTestSession()
+
+	processes := &fakeProcesses{}
+	processes.setup = func(exec *mockexec.MockExecutor) {
+		exec.EXPECT().OpenSession(mock.Anything, session).Return(nil)
+	}
+
+	// Plugins which haven't started are left alone
+	plug := newLazyPluginClient("test", processes.start(t))
+	require.NoError(t, plug.OpenSession(t.Context(), session))
+	require.NoError(t, plug.restartUnhealthy(t.Context()))
+	assert.Empty(t, processes.started)
+
+	_, err := plug.healthyProcess(t.Context())
+	require.NoError(t, err)
+	require.NoError(t, plug.restartUnhealthy(t.Context()))
+	require.Len(t, processes.started, 1)
+
+	// Hung processes are killed and replaced, with the session re-opened
+	processes.started[0].unresponsive.Store(true)
+	err = plug.restartUnhealthy(t.Context())
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "unhealthy")
+	require.Len(t, processes.started, 2)
+	assert.True(t, processes.started[0].exited.Load())
+	require.NoError(t, plug.Healthy())
+
+	plug.Shutdown()
+}
+
+func TestPluginClientManager_CheckHealth(t *testing.T) {
+	t.Parallel()
+
+	processes := &fakeProcesses{setup: func(*mockexec.MockExecutor) {}}
+	plug, err := newPluginClient(t.Context(), "test", processes.start(t))
+	require.NoError(t, err)
+
+	pm, ok := NewPluginClientManager(nil).(*pluginClientManager)
+	require.True(t, ok)
+	t.Cleanup(func() { pm.Shutdown(context.Background()) })
+	require.NoError(t, pm.RegisterExecutor("test", plug))
+
+	// Processes which exit while idle are restarted before the next task
+	processes.started[0].exited.Store(true)
+	require.ErrorIs(t, pm.checkHealth(t.Context()), ErrPluginExited)
+	require.Len(t, processes.started, 2)
+	require.NoError(t, pm.checkHealth(t.Context()))
+}
+
+func TestPluginClient_ConcurrentStart(t *testing.T) {
+	t.Parallel()
+
+	session := task.NewTestSession()
+	tsk := task.New(task.NewID("Test"), "test.Test", nil)
+
+	processes := &fakeProcesses{}
+	processes.setup = func(exec *mockexec.MockExecutor) {
+		exec.EXPECT().OpenSession(mock.Anything, session).Return(nil)
+		exec.EXPECT().Execute(mock.Anything, session, tsk, mock.Anything).Return(nil).Times(2)
+		exec.EXPECT().CloseSession(mock.Anything, session.ID())
+	}
+
+	starting := make(chan struct{})
+	unblock := make(chan struct{})
+	start := processes.start(t)
+	plug := newLazyPluginClient("test", func(ctx context.Context) (*pluginProcess, error) {
+		close(starting)
+		<-unblock
+
+		return start(ctx)
+	})
+	require.NoError(t, plug.OpenSession(t.Context(), session))
+
+	var executing sync.WaitGroup
+	executing.Go(func() {
+		assert.NoError(t, plug.Execute(t.Context(), session, tsk, &task.Result{}))
+	})
+	<-starting
+
+	// The client isn't locked while the process starts, and other tasks wait for it
+	require.NoError(t, plug.Healthy())
+	executing.Go(func() {
+		assert.NoError(t, plug.Execute(t.Context(), session, tsk, &task.Result{}))
+	})
+
+	close(unblock)
+	executing.Wait()
+	assert.Len(t, processes.started, 1)
+
+	plug.CloseSession(t.Context(), session.ID())
+	plug.Shutdown()
+}
+
+func TestPluginClient_MaxRestarts(t *testing.T) {
+	t.Parallel()
+
+	session := task.NewTestSession()
+	tsk := task.New(task.NewID("Test"), "test.Test", nil)
+
+	processes := &fakeProcesses{
+		setup: func(exec *mockexec.MockExecutor) {
+			exec.EXPECT().Execute(mock.Anything, session, tsk, mock.Anything).Return(nil).Maybe()
+		},
+	}
+
+	plug, err := newPluginClient(t.Context(), "test", processes.start(t))
+	require.NoError(t, err)
+
+	for range MaxRestarts {
+		// Exiting between tasks restarts the plugin before the next one
+		processes.started[len(processes.started)-1].exited.Store(true)
+		require.NoError(t, plug.Execute(t.Context(), session, tsk, &task.Result{}))
+	}
+
+	processes.started[len(processes.started)-1].exited.Store(true)
+	err = plug.Execute(t.Context(), session, tsk, &task.Result{})
+	require.ErrorIs(t, err, ErrPluginExited)
+	assert.Len(t, processes.started, MaxRestarts+1)
+}
+
+func TestPluginClient_Lazy(t *testing.T) {
+	t.Parallel()
+
+	session := task.NewTestSession()
+	tsk := task.New(task.NewID("Test"), "test.Test", nil)
+
+	processes := &fakeProcesses{
+		setup: func(exec *mockexec.MockExecutor) {
+			exec.EXPECT().OpenSession(mock.Anything, session).Return(nil)
+			exec.EXPECT().Execute(mock.Anything, session, tsk, mock.Anything).Return(nil)
+			exec.EXPECT().CloseSession(mock.Anything, session.ID())
+		},
+	}
+
+	plug := newLazyPluginClient("test", processes.start(t))
+
+	// Sessions are remembered without starting the plugin
+	require.NoError(t, plug.OpenSession(t.Context(), session))
+	require.NoError(t, plug.Healthy())
+	assert.Empty(t, processes.started)
+
+	// The first task starts it, and opens the session
+	require.NoError(t, plug.Execute(t.Context(), session, tsk, &task.Result{}))
+	require.Len(t, processes.started, 1)
+
+	plug.CloseSession(t.Context(), session.ID())
+	plug.Shutdown()
+}
+
+func TestPluginClient_LazyStartFailure(t *testing.T) {
+	t.Parallel()
+
+	var starts atomic.Int32
+	plug := newLazyPluginClient("test", func(context.Context) (*pluginProcess, error) {
+		starts.Add(1)
+
+		return nil, assert.AnError
+	})
+
+	tsk := task.New(task.NewID("Test"), "test.Test", nil)
+	for range 2 {
+		err := plug.Execute(t.Context(), task.NewTestSession(), tsk, &task.Result{})
+		require.ErrorIs(t, err, assert.AnError)
+	}
+
+	// Starting isn't retried for every task
+	assert.Equal(t, int32(1), starts.Load())
+}
+
+func TestPluginClient_LazyStartCanceled(t *testing.T) {
+	t.Parallel()
+
+	session := task.NewTestSession()
+	tsk := task.New(task.NewID("Test"), "test.Test", nil)
+
+	processes := &fakeProcesses{
+		setup: func(exec *mockexec.MockExecutor) {
+			exec.EXPECT().Execute(mock.Anything, session, tsk, mock.Anything).Return(nil)
+		},
+	}
+
+	building := make(chan struct{})
+	built := make(chan struct{})
+	start := processes.start(t)
+	plug := newLazyPluginClient("test", func(ctx context.Context) (*pluginProcess, error) {
+		close(building)
+		<-built
+
+		// Starting isn't canceled with the task which started it
+		require.NoError(t, ctx.Err())
+
+		return start(ctx)
+	})
+
+	ctx, cancel := context.WithCancel(t.Context())
+	go func() {
+		<-building
+		cancel()
+	}()
+
+	err := plug.Execute(ctx, session, tsk, &task.Result{})
+	require.ErrorIs(t, err, context.Canceled)
+	close(built)
+
+	// The next task uses the process, rather than failing because the first was canceled
+	require.NoError(t, plug.Execute(t.Context(), session, tsk, &task.Result{}))
+	assert.Len(t, processes.started, 1)
+
+	plug.Shutdown()
+}
+
+func TestPluginClient_LazyShutdown(t *testing.T) {
+	t.Parallel()
+
+	processes := &fakeProcesses{setup: func(*mockexec.MockExecutor) {}}
+	plug := newLazyPluginClient("test", processes.start(t))
+	plug.Shutdown()
+
+	err := plug.Execute(t.Context(), task.NewTestSession(), task.New("", "test.Test", nil), &task.Result{})
+	require.ErrorIs(t, err, ErrPluginExited)
+	require.ErrorIs(t, plug.Healthy(), ErrPluginExited)
+	assert.Empty(t, processes.started)
+}
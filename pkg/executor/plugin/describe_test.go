@@ -0,0 +1,131 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package plugin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	goplugin "github.com/hashicorp/go-plugin"
+
+	"go.bonk.build/pkg/executor"
+	"go.bonk.build/pkg/executor/argconv"
+	"go.bonk.build/pkg/executor/mockexec"
+	"go.bonk.build/pkg/executor/router"
+	"go.bonk.build/pkg/executor/rpc"
+	"go.bonk.build/pkg/task"
+)
+
+type describeParams struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func TestPlugin_Describe(t *testing.T) {
+	t.Parallel()
+
+	plug := NewPlugin("described",
+		WithExecutor("Second", argconv.NewMockTypedExecutor[any](t)),
+		WithExecutor("First", argconv.NewMockTypedExecutor[describeParams](t)),
+	)
+
+	client, server := goplugin.TestPluginGRPCConn(t, false, plug.getPluginSet())
+	t.Cleanup(func() {
+		server.Stop()
+		require.NoError(t, client.Close())
+	})
+
+	describer, ok := rpc.NewGRPCClient(client.Conn).(executor.Describer)
+	require.True(t, ok)
+
+	desc, err := describer.Describe(t.Context())
+	require.NoError(t, err)
+
+	assert.Equal(t, "described", desc.Name)
+	assert.Equal(t, []string{"First", "Second"}, desc.ExecutorNames())
+	assert.Contains(t, desc.Executors[0].Schema, "name:  string")
+	assert.Contains(t, desc.Executors[0].Schema, "count: int")
+	assert.Equal(t, "_", desc.Executors[1].Schema)
+}
+
+// describingExecutor is a mock executor which describes itself.
+type describingExecutor struct {
+	*mockexec.MockExecutor
+
+	description executor.Description
+}
+
+func (d describingExecutor) Describe(context.Context) (executor.Description, error) {
+	return d.description, nil
+}
+
+func TestPluginClient_UnknownExecutor(t *testing.T) {
+	t.Parallel()
+
+	exec := mockexec.NewMockExecutor(t)
+
+	plug, err := newPluginClient(t.Context(), "test", func(context.Context) (*pluginProcess, error) {
+		return &pluginProcess{
+			Executor: describingExecutor{
+				MockExecutor: exec,
+				description: executor.Description{
+					Name:      "test",
+					Executors: []executor.ExecutorDescription{{Name: "Test"}, {Name: "Other"}},
+				},
+			},
+			exited: func() bool { return false },
+			ping:   func() error { return nil },
+			kill:   func() {},
+		}, nil
+	})
+	require.NoError(t, err)
+
+	// Never sent to the plugin
+	err = plug.Execute(t.Context(), task.NewTestSession(), task.New("", "Missing", nil), &task.Result{})
+	require.ErrorIs(t, err, router.ErrNoExecutorFound)
+	assert.Equal(t, executor.KindInvalidArgument, executor.KindOf(err))
+	assert.ErrorContains(t, err, "Test, Other")
+}
+
+func TestPluginClient_InvalidArgs(t *testing.T) {
+	t.Parallel()
+
+	schema, err := argconv.Schema[describeParams]()
+	require.NoError(t, err)
+
+	exec := mockexec.NewMockExecutor(t)
+
+	plug, err := newPluginClient(t.Context(), "test", func(context.Context) (*pluginProcess, error) {
+		return &pluginProcess{
+			Executor: describingExecutor{
+				MockExecutor: exec,
+				description: executor.Description{
+					Name:      "test",
+					Executors: []executor.ExecutorDescription{{Name: "Test", Schema: schema}},
+				},
+			},
+			exited: func() bool { return false },
+			ping:   func() error { return nil },
+			kill:   func() {},
+		}, nil
+	})
+	require.NoError(t, err)
+
+	// Never sent to the plugin
+	invalid := task.New("", "Test", map[string]any{"name": "abc", "count": "many"})
+	err = plug.Execute(t.Context(), task.NewTestSession(), invalid, &task.Result{})
+	require.ErrorIs(t, err, argconv.ErrInvalidArgs)
+	assert.Equal(t, executor.KindInvalidArgument, executor.KindOf(err))
+	assert.ErrorContains(t, err, "count")
+
+	// Whole numbers decoded from JSON are still ints
+	valid := task.New("", "Test", map[string]any{"name": "abc", "count": 3.0})
+	exec.EXPECT().Execute(t.Context(), task.NewTestSession(), valid, &task.Result{}).Return(nil).Once()
+
+	err = plug.Execute(t.Context(), task.NewTestSession(), valid, &task.Result{})
+	require.NoError(t, err)
+}
@@ -6,6 +6,7 @@ package plugin
 import (
 	"context"
 	"errors"
+	"log"
 	"log/slog"
 
 	"go.uber.org/multierr"
@@ -37,8 +38,8 @@ func init() {
 					return next(ctx, record)
 				},
 			),
-			// Route to the buffered handler
-		).Handler(slog.Default().Handler()),
+			// Write straight to log's output, as wrapping the default handler deadlocks once it's replaced
+		).Handler(slog.NewTextHandler(log.Writer(), nil)),
 	))
 }
 
@@ -54,11 +55,11 @@ func getTaskLoggingContext(
 	if err != nil {
 		return nil, nil, errors.New("failed to create task directory")
 	}
-	logFileText, err := taskOutput.Create("log.txt")
+	logFileText, err := taskOutput.Create(task.LogFileText)
 	if err != nil {
 		return nil, nil, errors.New("failed to open log txt file")
 	}
-	logFileJSON, err := taskOutput.Create("log.jsonl")
+	logFileJSON, err := taskOutput.Create(task.LogFileJSON)
 	if err != nil {
 		return nil, nil, errors.New("failed to open log json file")
 	}
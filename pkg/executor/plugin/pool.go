@@ -0,0 +1,246 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"go.uber.org/multierr"
+
+	"go.bonk.build/pkg/executor"
+	"go.bonk.build/pkg/task"
+)
+
+// PoolOptions configures the processes run for a plugin.
+type PoolOptions struct {
+	// Size is the number of processes tasks are spread across, each task going to the least loaded one.
+	// Sizes below 1 are treated as 1.
+	Size int
+	// Isolated starts a new process for every task, which is killed once the task has finished.
+	// It's meant for executors which are untrusted or leak resources, and takes precedence over Size.
+	Isolated bool
+}
+
+// managedPlugin is implemented by the plugin executors registered with a [PluginClientManager].
+type managedPlugin interface {
+	restartUnhealthy(ctx context.Context) error
+	Shutdown()
+}
+
+// pluginPool runs a plugin as several processes, each managed by a [PluginClient].
+type pluginPool struct {
+	name      string
+	options   PoolOptions
+	newClient func() *PluginClient
+
+	mu      sync.Mutex
+	clients []*pooledClient
+	// isolated are the clients executing a single task each, which are shut down once it has finished.
+	isolated map[*PluginClient]struct{}
+	sessions map[task.SessionID]task.Session
+	shutdown bool
+
+	// described is shared by every client, so that each isolated process isn't asked to describe the plugin.
+	described *pluginDescription
+}
+
+// pooledClient is a member of a [pluginPool], and the number of tasks it's executing.
+type pooledClient struct {
+	*PluginClient
+
+	load int
+}
+
+var (
+	_ executor.Executor  = (*pluginPool)(nil)
+	_ executor.Describer = (*pluginPool)(nil)
+	_ managedPlugin      = (*pluginPool)(nil)
+)
+
+// newPluginPool creates a pool of lazy clients created by newClient.
+func newPluginPool(name string, options PoolOptions, newClient func() *PluginClient) *pluginPool {
+	pool := &pluginPool{
+		name:      name,
+		options:   options,
+		newClient: newClient,
+		isolated:  make(map[*PluginClient]struct{}),
+		sessions:  make(map[task.SessionID]task.Session),
+		described: &pluginDescription{},
+	}
+
+	if !options.Isolated {
+		for range max(options.Size, 1) {
+			pool.clients = append(pool.clients, &pooledClient{PluginClient: pool.newMember()})
+		}
+	}
+
+	return pool
+}
+
+// newMember creates a client which shares the pool's description of the plugin.
+func (pool *pluginPool) newMember() *PluginClient {
+	client := pool.newClient()
+	client.described = pool.described
+
+	return client
+}
+
+// Execute implements executor.Executor.
+func (pool *pluginPool) Execute(
+	ctx context.Context,
+	session task.Session,
+	tsk *task.Task,
+	result *task.Result,
+) error {
+	client, release, err := pool.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	return client.Execute(ctx, session, tsk, result)
+}
+
+// OpenSession implements executor.Executor.
+// Sessions are opened in every process, which for lazy clients means once they're started.
+func (pool *pluginPool) OpenSession(ctx context.Context, session task.Session) error {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if pool.shutdown {
+		return fmt.Errorf("%w: %s has been shut down", ErrPluginExited, pool.name)
+	}
+
+	var err error
+	for _, client := range pool.clients {
+		multierr.AppendInto(&err, client.OpenSession(ctx, session))
+	}
+
+	pool.sessions[session.ID()] = session
+
+	return err
+}
+
+// CloseSession implements executor.Executor.
+func (pool *pluginPool) CloseSession(ctx context.Context, sessionID task.SessionID) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	delete(pool.sessions, sessionID)
+
+	for _, client := range pool.clients {
+		client.CloseSession(ctx, sessionID)
+	}
+}
+
+// Describe implements executor.Describer.
+// The plugin is described by the first client to be asked, and the description is shared by the others.
+func (pool *pluginPool) Describe(ctx context.Context) (executor.Description, error) {
+	description, cached, err := pool.described.cached(pool.name)
+	if cached {
+		return description, err
+	}
+
+	client, release, err := pool.acquire(ctx)
+	if err != nil {
+		return executor.Description{}, err
+	}
+	defer release()
+
+	return client.Describe(ctx) //nolint:wrapcheck
+}
+
+// restartUnhealthy restarts each process which has exited or isn't responding, returning why they were unhealthy.
+// Processes running isolated tasks only live as long as their task, so they aren't restarted.
+func (pool *pluginPool) restartUnhealthy(ctx context.Context) error {
+	pool.mu.Lock()
+	clients := slices.Clone(pool.clients)
+	pool.mu.Unlock()
+
+	var err error
+	for _, client := range clients {
+		multierr.AppendInto(&err, client.restartUnhealthy(ctx))
+	}
+
+	return err
+}
+
+// Shutdown kills every process in the pool, including those running isolated tasks.
+func (pool *pluginPool) Shutdown() {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	pool.shutdown = true
+
+	for _, client := range pool.clients {
+		client.Shutdown()
+	}
+	for client := range pool.isolated {
+		client.Shutdown()
+	}
+}
+
+// acquire returns the least loaded client, or a new client with the open sessions in isolated mode.
+// release must be called once the client is no longer in use.
+func (pool *pluginPool) acquire(ctx context.Context) (*PluginClient, func(), error) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if pool.shutdown {
+		return nil, nil, fmt.Errorf("%w: %s has been shut down", ErrPluginExited, pool.name)
+	}
+
+	if pool.options.Isolated {
+		return pool.acquireIsolated(context.WithoutCancel(ctx))
+	}
+
+	least := pool.clients[0]
+	for _, client := range pool.clients[1:] {
+		if client.load < least.load {
+			least = client
+		}
+	}
+
+	least.load++
+
+	return least.PluginClient, func() {
+		pool.mu.Lock()
+		least.load--
+		pool.mu.Unlock()
+	}, nil
+}
+
+// acquireIsolated creates a client for a single task, which is shut down on release.
+// It must be called with mu held.
+func (pool *pluginPool) acquireIsolated(ctx context.Context) (*PluginClient, func(), error) {
+	client := pool.newMember()
+
+	// Lazy clients only remember the sessions until they're started
+	for _, session := range pool.sessions {
+		err := client.OpenSession(ctx, session)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open session %s in plugin %s: %w", session.ID(), pool.name, err)
+		}
+	}
+
+	// Tracked so that it's killed by Shutdown
+	pool.isolated[client] = struct{}{}
+
+	return client, func() {
+		pool.mu.Lock()
+		delete(pool.isolated, client)
+		sessions := slices.Collect(maps.Keys(pool.sessions))
+		pool.mu.Unlock()
+
+		for _, sessionID := range sessions {
+			client.CloseSession(ctx, sessionID)
+		}
+
+		client.Shutdown()
+	}, nil
+}
@@ -0,0 +1,163 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package plugin
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go.bonk.build/pkg/executor"
+	"go.bonk.build/pkg/executor/mockexec"
+	"go.bonk.build/pkg/task"
+)
+
+func TestPluginPool_LeastLoaded(t *testing.T) {
+	t.Parallel()
+
+	session := task.NewTestSession()
+	tsk := task.New(task.NewID("Test"), "test.Test", nil)
+
+	var (
+		started sync.WaitGroup
+		unblock = make(chan struct{})
+	)
+	started.Add(2)
+
+	processes := &fakeProcesses{
+		setup: func(exec *mockexec.MockExecutor) {
+			exec.EXPECT().OpenSession(mock.Anything, session).Return(nil)
+			exec.EXPECT().Execute(mock.Anything, session, tsk, mock.Anything).
+				RunAndReturn(func(context.Context, task.Session, *task.Task, *task.Result) error {
+					started.Done()
+					<-unblock
+
+					return nil
+				}).Once()
+		},
+	}
+
+	var startMu sync.Mutex
+	start := processes.start(t)
+	pool := newPluginPool("test", PoolOptions{Size: 3}, func() *PluginClient {
+		return newLazyPluginClient("test", func(ctx context.Context) (*pluginProcess, error) {
+			startMu.Lock()
+			defer startMu.Unlock()
+
+			return start(ctx)
+		})
+	})
+	require.NoError(t, pool.OpenSession(t.Context(), session))
+
+	// Processes aren't started until a task needs them
+	assert.Empty(t, processes.started)
+
+	var executing sync.WaitGroup
+	for range 2 {
+		executing.Go(func() {
+			assert.NoError(t, pool.Execute(t.Context(), session, tsk, &task.Result{}))
+		})
+	}
+
+	// Both tasks are executing at once, so they must be in different processes
+	started.Wait()
+	close(unblock)
+	executing.Wait()
+
+	require.NoError(t, pool.restartUnhealthy(t.Context()))
+	assert.Len(t, processes.started, 2)
+
+	pool.Shutdown()
+	for _, process := range processes.started {
+		assert.True(t, process.exited.Load())
+	}
+}
+
+func TestPluginPool_Isolated(t *testing.T) {
+	t.Parallel()
+
+	session := task.NewTestSession()
+	tsk := task.New(task.NewID("Test"), "test.Test", nil)
+
+	processes := &fakeProcesses{
+		setup: func(exec *mockexec.MockExecutor) {
+			exec.EXPECT().OpenSession(mock.Anything, session).Return(nil)
+			exec.EXPECT().Execute(mock.Anything, session, tsk, mock.Anything).Return(nil).Once()
+			exec.EXPECT().CloseSession(mock.Anything, session.ID())
+		},
+	}
+
+	pool := newPluginPool("test", PoolOptions{Isolated: true}, func() *PluginClient {
+		return newLazyPluginClient("test", processes.start(t))
+	})
+	require.NoError(t, pool.OpenSession(t.Context(), session))
+
+	for range 2 {
+		require.NoError(t, pool.Execute(t.Context(), session, tsk, &task.Result{}))
+	}
+
+	// Each task had a process of its own, which was killed once it finished
+	require.Len(t, processes.started, 2)
+	for _, process := range processes.started {
+		assert.True(t, process.exited.Load())
+	}
+
+	pool.CloseSession(t.Context(), session.ID())
+	pool.Shutdown()
+	require.ErrorIs(t, pool.Execute(t.Context(), session, tsk, &task.Result{}), ErrPluginExited)
+}
+
+// countingDescriber is a mock executor which counts how many times it's described.
+type countingDescriber struct {
+	*mockexec.MockExecutor
+
+	describes *atomic.Int32
+}
+
+func (d countingDescriber) Describe(context.Context) (executor.Description, error) {
+	d.describes.Add(1)
+
+	return executor.Description{Name: "test", Executors: []executor.ExecutorDescription{{Name: "Test"}}}, nil
+}
+
+func TestPluginPool_IsolatedDescribe(t *testing.T) {
+	t.Parallel()
+
+	session := task.NewTestSession()
+	tsk := task.New(task.NewID("Test"), "Test", nil)
+
+	var describes atomic.Int32
+	pool := newPluginPool("test", PoolOptions{Isolated: true}, func() *PluginClient {
+		return newLazyPluginClient("test", func(context.Context) (*pluginProcess, error) {
+			exec := mockexec.NewMockExecutor(t)
+			exec.EXPECT().Execute(mock.Anything, session, tsk, mock.Anything).Return(nil).Once()
+
+			return &pluginProcess{
+				Executor: countingDescriber{MockExecutor: exec, describes: &describes},
+				exited:   func() bool { return false },
+				ping:     func() error { return nil },
+				kill:     func() {},
+			}, nil
+		})
+	})
+
+	for range 3 {
+		require.NoError(t, pool.Execute(t.Context(), session, tsk, &task.Result{}))
+	}
+
+	// The plugin was described by the first process, rather than by every process
+	assert.Equal(t, int32(1), describes.Load())
+
+	desc, err := pool.Describe(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Test"}, desc.ExecutorNames())
+	assert.Equal(t, int32(1), describes.Load())
+
+	pool.Shutdown()
+}
@@ -8,6 +8,10 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"os" //nolint:depguard // Plugin processes are configured by their host through the environment
+	"runtime/debug"
+	"slices"
+	"strings"
 
 	"go.uber.org/multierr"
 
@@ -22,6 +26,7 @@ import (
 	"go.bonk.build/pkg/executor/router"
 	"go.bonk.build/pkg/executor/rpc"
 	"go.bonk.build/pkg/task"
+	"go.bonk.build/pkg/tracing"
 )
 
 // Plugin describes a plugin and the services it provides.
@@ -34,6 +39,7 @@ type Plugin struct {
 
 var (
 	_ executor.Executor   = (*Plugin)(nil)
+	_ executor.Describer  = (*Plugin)(nil)
 	_ goplugin.GRPCPlugin = (*Plugin)(nil)
 )
 
@@ -68,13 +74,35 @@ func WithExecutor[Params any](name string, exec argconv.TypedExecutor[Params]) P
 }
 
 // Serve starts the plugin gRPC server.
+// If the host is exporting traces, spans created by the plugin are exported alongside them.
 func (p *Plugin) Serve() {
+	ctx := context.Background()
+
+	// The host passes its tracing config through the environment, see [tracing.Environ]
+	shutdownTracing, err := tracing.Setup(ctx, tracing.Config{
+		ServiceName: p.name,
+		Endpoint:    os.Getenv(tracing.EnvEndpoint),
+		File:        os.Getenv(tracing.EnvFile),
+	})
+	if err != nil {
+		slog.WarnContext(ctx, "failed to set up tracing", "error", err)
+	}
+
 	goplugin.Serve(&goplugin.ServeConfig{
 		HandshakeConfig: handshake,
 		Plugins:         p.getPluginSet(),
-		GRPCServer:      goplugin.DefaultGRPCServer,
-		Logger:          shclog.New(slog.Default()),
+		GRPCServer: func(opts []grpc.ServerOption) *grpc.Server {
+			return goplugin.DefaultGRPCServer(append(opts, rpc.ServerOptions()...))
+		},
+		Logger: shclog.New(slog.Default()),
 	})
+
+	if shutdownTracing != nil {
+		err = shutdownTracing(ctx)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to flush traces", "error", err)
+		}
+	}
 }
 
 // GRPCServer calls [rpc.RegisterGRPCServer] for the plugin.
@@ -115,6 +143,35 @@ func (p *Plugin) Execute(
 	return err
 }
 
+// Describe implements executor.Describer.
+// The version is the module version the plugin binary was built from, if known.
+func (p *Plugin) Describe(context.Context) (executor.Description, error) {
+	desc := executor.Description{
+		Name: p.name,
+	}
+
+	if info, ok := debug.ReadBuildInfo(); ok {
+		desc.Version = info.Main.Version
+	}
+
+	var err error
+	p.ForEachExecutor(func(name string, exec executor.Executor) {
+		schema, schemaErr := argconv.ArgsSchema(exec)
+		multierr.AppendInto(&err, schemaErr)
+
+		desc.Executors = append(desc.Executors, executor.ExecutorDescription{
+			Name:   name,
+			Schema: schema,
+		})
+	})
+
+	slices.SortFunc(desc.Executors, func(a, b executor.ExecutorDescription) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return desc, err
+}
+
 func (p *Plugin) getPluginSet() goplugin.PluginSet {
 	return map[string]goplugin.Plugin{
 		"executor": p,
@@ -0,0 +1,316 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package plugin
+
+import (
+	"context"
+	"crypto/sha256"
+	"debug/buildinfo"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"golang.org/x/mod/semver"
+
+	"github.com/spf13/afero"
+)
+
+const (
+	// storeBinDir is the directory in a [Store] containing installed plugins.
+	storeBinDir = "bin"
+	// storeCacheDir is the directory in a [Store] containing plugins built on demand from Go packages.
+	storeCacheDir = "cache"
+)
+
+var ErrPluginNotFound = errors.New("plugin not found")
+
+// Store manages a directory of plugin binaries.
+//
+// Plugins may be referred to by:
+//   - a path to an executable,
+//   - the name of a plugin installed with [Store.Install],
+//   - a Go package, optionally suffixed with @version, which is run from the plugin installed from it if there is one,
+//   - or otherwise built once and cached by its build ID, or its version, and the build flags.
+type Store struct {
+	fs  afero.Fs
+	dir string
+
+	// BuildFlags are passed to go when building plugins from packages.
+	BuildFlags []string
+}
+
+// InstalledPlugin describes a plugin binary in a [Store].
+type InstalledPlugin struct {
+	Name    string
+	Path    string
+	Package string
+	Version string
+}
+
+// NewStore creates a [Store] rooted at dir. Directories are created as plugins are added.
+func NewStore(dir string) *Store {
+	return &Store{
+		fs:  afero.NewOsFs(),
+		dir: dir,
+	}
+}
+
+// Dir returns the directory containing the store.
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Resolve returns the name of the plugin referred to by ref, and the path to an executable for it.
+func (s *Store) Resolve(ctx context.Context, ref string) (string, string, error) {
+	// A path to a binary
+	if info, err := s.fs.Stat(ref); err == nil && info.Mode().IsRegular() {
+		binary, err := filepath.Abs(ref)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to resolve plugin path %s: %w", ref, err)
+		}
+
+		return pluginName(binary), binary, nil
+	}
+
+	// An installed plugin, which is installed with the name of its package
+	if installed, ok := s.installed(ref); ok {
+		return installed.Name, installed.Path, nil
+	}
+
+	// A Go package
+	binary, err := s.build(ctx, ref)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: %s: %w", ErrPluginNotFound, ref, err)
+	}
+
+	return RefName(ref), binary, nil
+}
+
+// Install builds the Go package pkg, optionally suffixed with @version, into the store's installed plugins.
+// The plugin is named by [RefName], replacing any plugin installed with the same name.
+func (s *Store) Install(ctx context.Context, pkg string) (InstalledPlugin, error) {
+	binary := filepath.Join(s.dir, storeBinDir, RefName(pkg))
+
+	err := s.compile(ctx, pkg, binary)
+	if err != nil {
+		return InstalledPlugin{}, fmt.Errorf("failed to install plugin %s: %w", pkg, err)
+	}
+
+	return s.describe(binary)
+}
+
+// installed returns the installed plugin referred to by ref, which is either its name,
+// or the package it was installed from, along with its version if ref has one.
+func (s *Store) installed(ref string) (InstalledPlugin, bool) {
+	binary := filepath.Join(s.dir, storeBinDir, RefName(ref))
+	if info, err := s.fs.Stat(binary); err != nil || !info.Mode().IsRegular() {
+		return InstalledPlugin{}, false
+	}
+
+	pkgPath, version, _ := strings.Cut(ref, "@")
+	if !strings.Contains(pkgPath, "/") {
+		return InstalledPlugin{Name: RefName(ref), Path: binary}, true
+	}
+
+	installed, err := s.describe(binary)
+	if err != nil || installed.Package != pkgPath || (version != "" && installed.Version != version) {
+		return InstalledPlugin{}, false
+	}
+
+	return installed, true
+}
+
+// List returns the installed plugins, sorted by name.
+func (s *Store) List() ([]InstalledPlugin, error) {
+	entries, err := afero.ReadDir(s.fs, filepath.Join(s.dir, storeBinDir))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list plugins: %w", err)
+	}
+
+	plugins := make([]InstalledPlugin, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.Mode().IsRegular() {
+			continue
+		}
+
+		installed, err := s.describe(filepath.Join(s.dir, storeBinDir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		plugins = append(plugins, installed)
+	}
+
+	slices.SortFunc(plugins, func(a, b InstalledPlugin) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return plugins, nil
+}
+
+// describe reads the Go build info embedded in a plugin binary.
+func (s *Store) describe(binary string) (InstalledPlugin, error) {
+	installed := InstalledPlugin{
+		Name: pluginName(binary),
+		Path: binary,
+	}
+
+	file, err := s.fs.Open(binary)
+	if err != nil {
+		return installed, fmt.Errorf("failed to open plugin %s: %w", binary, err)
+	}
+	defer file.Close()
+
+	info, err := buildinfo.Read(file)
+	if err != nil {
+		// Not a Go binary, which is fine as long as it speaks the protocol
+		return installed, nil //nolint:nilerr
+	}
+
+	installed.Package = info.Path
+	installed.Version = info.Main.Version
+
+	return installed, nil
+}
+
+// build compiles the Go package pkg, optionally suffixed with @version, into the cache,
+// unless an up to date binary is already there.
+func (s *Store) build(ctx context.Context, pkg string) (string, error) {
+	pkgPath, version, versioned := strings.Cut(pkg, "@")
+
+	// Released versions never change, while the build ID changes whenever the package or any of its dependencies do
+	key := version
+	if !versioned {
+		listArgs := append([]string{"list", "-export", "-f", "{{.Name}} {{.BuildID}}"}, s.BuildFlags...)
+		listOut, err := runGo(ctx, nil, append(listArgs, pkg)...)
+		if err != nil {
+			return "", err
+		}
+
+		name, buildID, _ := strings.Cut(strings.TrimSpace(string(listOut)), " ")
+		if name != "main" {
+			return "", fmt.Errorf("package %s is not a main package", pkg)
+		}
+		key = buildID
+	}
+
+	hash := sha256.New()
+	for _, part := range append([]string{pkgPath, key}, s.BuildFlags...) {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+
+	binary := filepath.Join(s.dir, storeCacheDir, hex.EncodeToString(hash.Sum(nil))[:16], RefName(pkg))
+
+	// Queries such as @latest may resolve to a different version each time, so they're always rebuilt
+	if !versioned || semver.IsValid(version) {
+		if exists, _ := afero.Exists(s.fs, binary); exists {
+			return binary, nil
+		}
+	}
+
+	err := s.compile(ctx, pkg, binary)
+	if err != nil {
+		return "", err
+	}
+
+	return binary, nil
+}
+
+// compile builds the Go package pkg, optionally suffixed with @version, into binary.
+func (s *Store) compile(ctx context.Context, pkg string, binary string) error {
+	err := s.fs.MkdirAll(filepath.Dir(binary), 0o750)
+	if err != nil {
+		return fmt.Errorf("failed to create plugin directory: %w", err)
+	}
+
+	// Build next to the final location, then move it into place so that a partial build is never used
+	tmpDir, err := afero.TempDir(s.fs, filepath.Dir(binary), "build")
+	if err != nil {
+		return fmt.Errorf("failed to create plugin build directory: %w", err)
+	}
+	defer s.fs.RemoveAll(tmpDir) //nolint:errcheck
+
+	tmpBinary := filepath.Join(tmpDir, RefName(pkg))
+	if strings.Contains(pkg, "@") {
+		// Only go install knows how to build a package at a specific version, and it names the binary itself
+		args := append([]string{"install"}, s.BuildFlags...)
+		_, err = runGo(ctx, []string{"GOBIN=" + tmpDir}, append(args, pkg)...)
+		if err == nil {
+			tmpBinary, err = s.onlyFile(tmpDir)
+		}
+	} else {
+		args := append([]string{"build", "-o", tmpBinary}, s.BuildFlags...)
+		_, err = runGo(ctx, nil, append(args, pkg)...)
+	}
+	if err != nil {
+		return err
+	}
+
+	err = s.fs.Rename(tmpBinary, binary)
+	if err != nil {
+		return fmt.Errorf("failed to move plugin into place: %w", err)
+	}
+
+	return nil
+}
+
+// onlyFile returns the path of the single file in dir.
+func (s *Store) onlyFile(dir string) (string, error) {
+	entries, err := afero.ReadDir(s.fs, dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to find built plugin: %w", err)
+	}
+	if len(entries) != 1 {
+		return "", fmt.Errorf("expected one built plugin in %s, found %d", dir, len(entries))
+	}
+
+	return filepath.Join(dir, entries[0].Name()), nil
+}
+
+// RefName returns the name of the plugin referred to by ref, as used to prefix its executors and to install it.
+// Go packages are named like go install names them, by their last element without their version,
+// unless it's a major version suffix such as /v2.
+func RefName(ref string) string {
+	pkgPath, _, _ := strings.Cut(ref, "@")
+	if dir, base := path.Split(filepath.ToSlash(pkgPath)); dir != "" && majorVersion.MatchString(base) {
+		pkgPath = path.Clean(dir)
+	}
+
+	return pluginName(pkgPath)
+}
+
+// majorVersion matches the major version suffixes of Go module paths.
+var majorVersion = regexp.MustCompile(`^v[0-9]+$`)
+
+// pluginName is the name of the plugin in the binary at path.
+func pluginName(binary string) string {
+	return strings.TrimSuffix(filepath.Base(binary), filepath.Ext(binary))
+}
+
+// runGo runs the go command, returning its stdout.
+func runGo(ctx context.Context, env []string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "go", args...)
+	cmd.Env = append(cmd.Environ(), env...)
+
+	output, err := cmd.Output()
+	if exitErr := (*exec.ExitError)(nil); errors.As(err, &exitErr) {
+		return nil, fmt.Errorf("go %s failed: %w\n%s", args[0], err, exitErr.Stderr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("go %s failed: %w", args[0], err)
+	}
+
+	return output, nil
+}
@@ -0,0 +1,110 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package plugin_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.bonk.build/pkg/executor/plugin"
+)
+
+func TestStore_ResolveBinary(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	binary := filepath.Join(dir, "custom")
+	require.NoError(t, afero.WriteFile(afero.NewOsFs(), binary, []byte("#!/bin/sh"), 0o700))
+
+	name, resolved, err := plugin.NewStore(t.TempDir()).Resolve(t.Context(), binary)
+	require.NoError(t, err)
+	assert.Equal(t, "custom", name)
+	assert.Equal(t, binary, resolved)
+}
+
+func TestStore_ResolveInstalled(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	binary := filepath.Join(dir, "bin", "custom")
+	require.NoError(t, afero.NewOsFs().MkdirAll(filepath.Dir(binary), 0o750))
+	require.NoError(t, afero.WriteFile(afero.NewOsFs(), binary, []byte("#!/bin/sh"), 0o700))
+
+	store := plugin.NewStore(dir)
+
+	name, resolved, err := store.Resolve(t.Context(), "custom")
+	require.NoError(t, err)
+	assert.Equal(t, "custom", name)
+	assert.Equal(t, binary, resolved)
+
+	installed, err := store.List()
+	require.NoError(t, err)
+	require.Len(t, installed, 1)
+	assert.Equal(t, "custom", installed[0].Name)
+	assert.Empty(t, installed[0].Package)
+}
+
+func TestStore_ResolvePackage(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("builds a plugin")
+	}
+
+	store := plugin.NewStore(t.TempDir())
+
+	name, first, err := store.Resolve(t.Context(), "go.bonk.build/plugins/test")
+	require.NoError(t, err)
+	assert.Equal(t, "test", name)
+
+	// The second resolution should hit the cache
+	_, second, err := store.Resolve(t.Context(), "go.bonk.build/plugins/test")
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+
+	_, _, err = store.Resolve(t.Context(), "go.bonk.build/pkg/task")
+	require.ErrorIs(t, err, plugin.ErrPluginNotFound)
+}
+
+func TestStore_ResolveInstalledPackage(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("builds a plugin")
+	}
+
+	store := plugin.NewStore(t.TempDir())
+
+	installed, err := store.Install(t.Context(), "go.bonk.build/plugins/test")
+	require.NoError(t, err)
+	assert.Equal(t, "test", installed.Name)
+	assert.Equal(t, "go.bonk.build/plugins/test", installed.Package)
+
+	// Installed plugins are found by the package they were installed from, as well as by name
+	for _, ref := range []string{"test", "go.bonk.build/plugins/test"} {
+		name, resolved, err := store.Resolve(t.Context(), ref)
+		require.NoError(t, err, ref)
+		assert.Equal(t, "test", name, ref)
+		assert.Equal(t, installed.Path, resolved, ref)
+	}
+}
+
+func TestRefName(t *testing.T) {
+	t.Parallel()
+
+	for ref, expected := range map[string]string{
+		"test":                              "test",
+		"/usr/local/bin/test.exe":           "test",
+		"go.bonk.build/plugins/test":        "test",
+		"go.bonk.build/plugins/test@v1.2.3": "test",
+		"example.com/helm/v2":               "helm",
+		"example.com/helm/v2@latest":        "helm",
+	} {
+		assert.Equal(t, expected, plugin.RefName(ref), ref)
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+// Package policy provides an executor which bounds how long tasks may execute, and retries those which fail.
+package policy
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"go.bonk.build/pkg/executor"
+	"go.bonk.build/pkg/executor/observable"
+	"go.bonk.build/pkg/executor/router"
+	"go.bonk.build/pkg/profile"
+	"go.bonk.build/pkg/task"
+)
+
+// AbandonGracePeriod is how long an attempt which timed out is given to stop, before it's abandoned.
+// Abandoning the attempt frees the task's slot and resources, even if its executor never stops.
+const AbandonGracePeriod = 10 * time.Second
+
+// Policy is the default timeout and retry policy of the tasks routed to an executor.
+// Tasks may override either with their own.
+type Policy struct {
+	// Timeout bounds how long each attempt at executing a task may take, or 0 for no limit.
+	// Attempts which don't stop within [AbandonGracePeriod] of timing out are abandoned.
+	Timeout time.Duration `json:"timeout,omitempty" mapstructure:"timeout"`
+	// Retry describes how tasks which fail are retried, or nil to never retry them.
+	Retry *task.RetryPolicy `json:"retry,omitempty" mapstructure:"retry"`
+}
+
+// Enforcer is an executor which enforces the timeout and retry policies of the tasks it executes.
+type Enforcer struct {
+	executor.Executor
+
+	policies   map[string]Policy
+	policiesMu sync.RWMutex
+	matcher    router.Matcher
+}
+
+var _ executor.Executor = (*Enforcer)(nil)
+
+// New wraps child with an executor which enforces the policies of tasks.
+func New(child executor.Executor) *Enforcer {
+	return &Enforcer{
+		Executor: child,
+		policies: make(map[string]Policy),
+	}
+}
+
+// SetExecutorPolicy sets the default policy of the tasks routed to the executor route, and the executors beneath it.
+// Where several routes match a task, the policy of the most specific is used.
+func (e *Enforcer) SetExecutorPolicy(route string, policy Policy) {
+	e.policiesMu.Lock()
+	defer e.policiesMu.Unlock()
+
+	e.policies[route] = policy
+}
+
+// SetMatcher sets how tasks are matched against the routes of policies, such as to resolve the aliases of a router.
+func (e *Enforcer) SetMatcher(matcher router.Matcher) {
+	e.policiesMu.Lock()
+	defer e.policiesMu.Unlock()
+
+	e.matcher = matcher
+}
+
+// Execute implements executor.Executor.
+// Each attempt is executed with a fresh result, and only the result of the successful attempt is kept.
+func (e *Enforcer) Execute(ctx context.Context, session task.Session, tsk *task.Task, result *task.Result) error {
+	policy := e.policyFor(tsk)
+
+	maxAttempts := 1
+	if policy.Retry != nil {
+		maxAttempts = max(policy.Retry.MaxAttempts, 1)
+	}
+
+	for attempt := 1; ; attempt++ {
+		attemptResult := &task.Result{}
+
+		err := e.attempt(ctx, policy.Timeout, session, tsk, attemptResult)
+		if err == nil {
+			result.Append(attemptResult)
+
+			return nil
+		}
+
+		// Attempts aren't retried once the run is canceled
+		if attempt >= maxAttempts || ctx.Err() != nil || !retryable(policy.Retry, err) {
+			if attempt > 1 {
+				return fmt.Errorf("task failed after %d attempts: %w", attempt, err)
+			}
+
+			return err
+		}
+
+		delay := policy.Retry.Delay(attempt)
+		slog.WarnContext(ctx, "task attempt failed, retrying",
+			"task", tsk.ID,
+			"attempt", attempt,
+			"delay", delay,
+			"error", err)
+		profile.Instant(ctx, "policy", "retry", map[string]any{"task": tsk.ID, "attempt": attempt})
+		observable.ReportRetry(ctx, attempt, err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+
+			return fmt.Errorf("task not retried: %w", err)
+		}
+	}
+}
+
+// attempt executes the task once, within its timeout.
+func (e *Enforcer) attempt(
+	ctx context.Context,
+	timeout time.Duration,
+	session task.Session,
+	tsk *task.Task,
+	result *task.Result,
+) error {
+	if timeout <= 0 {
+		return e.Executor.Execute(ctx, session, tsk, result) //nolint:wrapcheck
+	}
+
+	attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- e.Executor.Execute(attemptCtx, session, tsk, result)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-attemptCtx.Done():
+		err = e.awaitStop(ctx, timeout, tsk, done)
+	}
+
+	// Executors may return any error once their context is done, which is reported as the timeout
+	if err != nil && ctx.Err() == nil && attemptCtx.Err() != nil {
+		return &executor.Error{
+			Kind:    executor.KindDeadlineExceeded,
+			Message: fmt.Sprintf("task timed out after %s: %s", timeout, err),
+			Causes:  []error{err},
+		}
+	}
+
+	return err //nolint:wrapcheck
+}
+
+// awaitStop waits for an attempt which timed out to stop, abandoning it if it doesn't within [AbandonGracePeriod].
+// Attempts canceled by ctx are waited for, as the run is stopping anyway.
+func (e *Enforcer) awaitStop(ctx context.Context, timeout time.Duration, tsk *task.Task, done <-chan error) error {
+	if ctx.Err() != nil {
+		return <-done
+	}
+
+	timer := time.NewTimer(AbandonGracePeriod)
+	defer timer.Stop()
+
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C:
+	}
+
+	slog.WarnContext(ctx, "abandoning task which didn't stop after timing out", "task", tsk.ID, "timeout", timeout)
+
+	return &executor.Error{
+		Kind:    executor.KindDeadlineExceeded,
+		Message: fmt.Sprintf("task timed out after %s, and was abandoned after not stopping", timeout),
+		Causes:  []error{context.DeadlineExceeded},
+	}
+}
+
+// policyFor returns the policy of the most specific route matching the task, overridden by the task's own.
+func (e *Enforcer) policyFor(tsk *task.Task) Policy {
+	e.policiesMu.RLock()
+	route, _ := e.matcher.MostSpecific(slices.Collect(maps.Keys(e.policies)), tsk.Executor)
+	policy := e.policies[route]
+	e.policiesMu.RUnlock()
+
+	if tsk.Timeout > 0 {
+		policy.Timeout = tsk.Timeout
+	}
+	if tsk.Retry != nil {
+		policy.Retry = tsk.Retry
+	}
+
+	return policy
+}
+
+// retryable reports whether the error may be retried under policy.
+func retryable(policy *task.RetryPolicy, err error) bool {
+	if policy == nil {
+		return false
+	}
+
+	return executor.IsRetryable(err) || slices.Contains(policy.RetryOn, string(executor.KindOf(err)))
+}
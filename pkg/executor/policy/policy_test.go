@@ -0,0 +1,149 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package policy_test
+
+import (
+	"context"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go.bonk.build/pkg/executor"
+	"go.bonk.build/pkg/executor/mockexec"
+	"go.bonk.build/pkg/executor/policy"
+	"go.bonk.build/pkg/executor/router"
+	"go.bonk.build/pkg/task"
+)
+
+func TestTimeout(t *testing.T) { //nolint:paralleltest
+	synctest.Test(t, func(t *testing.T) {
+		exec := mockexec.NewMockExecutor(t)
+		exec.EXPECT().Execute(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			RunAndReturn(func(ctx context.Context, _ task.Session, _ *task.Task, _ *task.Result) error {
+				<-ctx.Done()
+
+				return assert.AnError
+			})
+
+		enforcer := policy.New(exec)
+		enforcer.SetExecutorPolicy("exec", policy.Policy{Timeout: time.Hour})
+
+		start := time.Now()
+		tsk := task.New("Task", "exec.sub", nil, task.WithTimeout(time.Minute))
+		err := enforcer.Execute(t.Context(), task.NewTestSession(), tsk, &task.Result{})
+
+		// The task's timeout overrides the executor's
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, time.Minute, time.Since(start))
+	})
+}
+
+func TestTimeout_Abandoned(t *testing.T) { //nolint:paralleltest
+	synctest.Test(t, func(t *testing.T) {
+		unblock := make(chan struct{})
+		defer close(unblock)
+
+		// An executor which ignores its ctx, like a plugin ignoring CancelTask
+		exec := mockexec.NewMockExecutor(t)
+		exec.EXPECT().Execute(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			RunAndReturn(func(context.Context, task.Session, *task.Task, *task.Result) error {
+				<-unblock
+
+				return nil
+			})
+
+		enforcer := policy.New(exec)
+
+		start := time.Now()
+		tsk := task.New("Task", "exec", nil, task.WithTimeout(time.Minute))
+		err := enforcer.Execute(t.Context(), task.NewTestSession(), tsk, &task.Result{})
+
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, time.Minute+policy.AbandonGracePeriod, time.Since(start))
+	})
+}
+
+func TestRetry(t *testing.T) { //nolint:paralleltest
+	synctest.Test(t, func(t *testing.T) {
+		exec := mockexec.NewMockExecutor(t)
+		exec.EXPECT().Execute(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(executor.Retryable(assert.AnError)).Times(2)
+		exec.EXPECT().Execute(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			RunAndReturn(func(_ context.Context, _ task.Session, _ *task.Task, result *task.Result) error {
+				result.AddOutputs("output")
+
+				return nil
+			}).Once()
+
+		enforcer := policy.New(exec)
+		enforcer.SetExecutorPolicy("exec", policy.Policy{
+			Retry: &task.RetryPolicy{MaxAttempts: 3, Backoff: time.Second},
+		})
+
+		start := time.Now()
+		result := &task.Result{}
+		err := enforcer.Execute(t.Context(), task.NewTestSession(), task.New("Task", "exec", nil), result)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"output"}, result.GetOutputs())
+		assert.Equal(t, 3*time.Second, time.Since(start))
+	})
+}
+
+func TestRetry_Exhausted(t *testing.T) { //nolint:paralleltest
+	synctest.Test(t, func(t *testing.T) {
+		exec := mockexec.NewMockExecutor(t)
+		exec.EXPECT().Execute(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(&executor.Error{Kind: executor.KindUnavailable, Message: "unavailable"}).Times(2)
+
+		tsk := task.New("Task", "exec", nil, task.WithRetry(task.RetryPolicy{
+			MaxAttempts: 2,
+			RetryOn:     []string{string(executor.KindUnavailable)},
+		}))
+
+		err := policy.New(exec).Execute(t.Context(), task.NewTestSession(), tsk, &task.Result{})
+		require.ErrorIs(t, err, &executor.Error{Kind: executor.KindUnavailable})
+		assert.ErrorContains(t, err, "after 2 attempts")
+	})
+}
+
+func TestRetry_NotRetryable(t *testing.T) {
+	t.Parallel()
+
+	exec := mockexec.NewMockExecutor(t)
+	exec.EXPECT().Execute(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(assert.AnError).Once()
+
+	tsk := task.New("Task", "exec", nil, task.WithRetry(task.RetryPolicy{MaxAttempts: 3}))
+
+	err := policy.New(exec).Execute(t.Context(), task.NewTestSession(), tsk, &task.Result{})
+	require.ErrorIs(t, err, assert.AnError)
+}
+
+func TestPolicy_Routes(t *testing.T) {
+	t.Parallel()
+
+	rtr := router.New()
+	require.NoError(t, rtr.RegisterAlias("charts.*", "helm.*"))
+
+	// Policies keyed by patterns apply to the executors routed to them, including through aliases
+	exec := mockexec.NewMockExecutor(t)
+	exec.EXPECT().Execute(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(executor.Retryable(assert.AnError)).Times(2)
+
+	enforcer := policy.New(exec)
+	enforcer.SetMatcher(rtr.Matcher())
+	enforcer.SetExecutorPolicy("helm.*", policy.Policy{Retry: &task.RetryPolicy{MaxAttempts: 2}})
+	enforcer.SetExecutorPolicy("helm", policy.Policy{Retry: &task.RetryPolicy{MaxAttempts: 3}})
+
+	tsk := task.New("Task", "charts.Template", nil)
+	err := enforcer.Execute(t.Context(), task.NewTestSession(), tsk, &task.Result{})
+	require.ErrorIs(t, err, assert.AnError)
+	assert.ErrorContains(t, err, "after 2 attempts")
+}
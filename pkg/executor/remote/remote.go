@@ -0,0 +1,273 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+// Package remote provides executors served over the network, rather than by plugin subprocesses.
+// Addresses are either "tcp://host:port" (or just "host:port") or "unix:///path/to/socket".
+package remote
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/url"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/spf13/afero"
+
+	"go.bonk.build/pkg/executor"
+	"go.bonk.build/pkg/executor/rpc"
+)
+
+var (
+	// ErrInvalidAddress is returned for addresses which aren't tcp or unix addresses.
+	ErrInvalidAddress = errors.New("invalid executor address")
+	// ErrInvalidTLS is returned when the TLS certificates can't be used.
+	ErrInvalidTLS = errors.New("invalid TLS configuration")
+	// ErrUnreachable is returned by [Client.Probe] when the executor can't be reached.
+	ErrUnreachable = errors.New("executor unreachable")
+)
+
+// Options describes how to reach a remote executor.
+type Options struct {
+	// Address is where the executor is served.
+	Address string `json:"address" mapstructure:"address"`
+	// TLS secures the connection, if set.
+	TLS *TLSConfig `json:"tls,omitempty" mapstructure:"tls"`
+	// SharedWorkspace is set if the executor sees the workspace at the same path, such as on a shared disk.
+	// Otherwise, the workspace's files are served to the executor over the connection.
+	SharedWorkspace bool `json:"sharedWorkspace,omitempty" mapstructure:"shared-workspace"`
+}
+
+// TLSConfig describes the certificates used to secure a connection.
+// Setting CAFile on both sides enables mutual TLS, where each side verifies the other's certificate.
+type TLSConfig struct {
+	// CertFile and KeyFile are the PEM encoded certificate and key presented to the other side.
+	// They're required when serving, and for clients of servers which verify clients.
+	CertFile string `json:"cert,omitempty" mapstructure:"cert"`
+	KeyFile  string `json:"key,omitempty"  mapstructure:"key"`
+	// CAFile is the PEM encoded certificate authority which the other side's certificate must be signed by.
+	// Clients use the system's authorities if it isn't set, and servers don't verify clients.
+	CAFile string `json:"ca,omitempty" mapstructure:"ca"`
+	// ServerName is the name the server's certificate is verified against,
+	// if it isn't the host of the address. It's ignored when serving.
+	ServerName string `json:"serverName,omitempty" mapstructure:"server-name"`
+}
+
+// Client is an executor served at a remote address.
+type Client struct {
+	executor.Executor
+
+	conn *grpc.ClientConn
+}
+
+var (
+	_ executor.Executor  = (*Client)(nil)
+	_ executor.Describer = (*Client)(nil)
+)
+
+// Dial creates a client for the executor served as described by options.
+// Connecting is deferred until the first call, so Dial doesn't fail if the executor isn't reachable yet.
+func Dial(options Options) (*Client, error) {
+	network, address, err := ParseAddress(options.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	creds := insecure.NewCredentials()
+	if options.TLS != nil {
+		tlsConfig, err := options.TLS.clientConfig()
+		if err != nil {
+			return nil, err
+		}
+		creds = credentials.NewTLS(tlsConfig)
+	}
+
+	target := address
+	if network == "unix" {
+		target = "unix:" + address
+	}
+
+	conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for %s: %w", options.Address, err)
+	}
+
+	var clientOptions []rpc.ClientOption
+	if !options.SharedWorkspace {
+		clientOptions = append(clientOptions, rpc.WithRemoteWorkspaces())
+	}
+
+	return &Client{
+		Executor: rpc.NewGRPCClient(conn, clientOptions...),
+		conn:     conn,
+	}, nil
+}
+
+// Describe implements executor.Describer.
+func (c *Client) Describe(ctx context.Context) (executor.Description, error) {
+	return c.Executor.(executor.Describer).Describe(ctx) //nolint:forcetypeassert,wrapcheck
+}
+
+// Probe connects to the executor, failing with [ErrUnreachable] if it can't be reached before ctx is done.
+func (c *Client) Probe(ctx context.Context) error {
+	c.conn.Connect()
+
+	for {
+		state := c.conn.GetState()
+		switch state {
+		case connectivity.Ready:
+			return nil
+		case connectivity.Shutdown:
+			return fmt.Errorf("%w: connection closed", ErrUnreachable)
+		default:
+		}
+
+		if !c.conn.WaitForStateChange(ctx, state) {
+			return fmt.Errorf("%w: %w", ErrUnreachable, context.Cause(ctx))
+		}
+	}
+}
+
+// Close closes the connection to the executor.
+func (c *Client) Close() error {
+	return c.conn.Close() //nolint:wrapcheck
+}
+
+// Serve serves exec as described by options until ctx is canceled.
+func Serve(ctx context.Context, exec executor.Executor, options Options) error {
+	network, address, err := ParseAddress(options.Address)
+	if err != nil {
+		return err
+	}
+
+	var serverOptions []grpc.ServerOption
+	if options.TLS != nil {
+		tlsConfig, err := options.TLS.serverConfig()
+		if err != nil {
+			return err
+		}
+		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	listener, err := (&net.ListenConfig{}).Listen(ctx, network, address)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", options.Address, err)
+	}
+
+	server := grpc.NewServer(serverOptions...)
+	rpc.RegisterGRPCServer(server, exec)
+
+	stop := context.AfterFunc(ctx, server.GracefulStop)
+	defer stop()
+
+	slog.InfoContext(ctx, "serving executors", "address", listener.Addr(), "tls", options.TLS != nil)
+
+	err = server.Serve(listener)
+	if err != nil {
+		return fmt.Errorf("failed to serve executors: %w", err)
+	}
+
+	return nil
+}
+
+// ParseAddress splits address into the network and address to dial or listen on.
+func ParseAddress(address string) (string, string, error) {
+	if !strings.Contains(address, "://") {
+		return "tcp", address, nil
+	}
+
+	parsed, err := url.Parse(address)
+	if err != nil {
+		return "", "", fmt.Errorf("%w %q: %w", ErrInvalidAddress, address, err)
+	}
+
+	switch parsed.Scheme {
+	case "tcp":
+		if parsed.Host == "" {
+			return "", "", fmt.Errorf("%w %q: missing host", ErrInvalidAddress, address)
+		}
+
+		return "tcp", parsed.Host, nil
+
+	case "unix":
+		if parsed.Path == "" {
+			return "", "", fmt.Errorf("%w %q: missing path", ErrInvalidAddress, address)
+		}
+
+		return "unix", parsed.Path, nil
+
+	default:
+		return "", "", fmt.Errorf("%w %q: unsupported scheme %q", ErrInvalidAddress, address, parsed.Scheme)
+	}
+}
+
+func (c *TLSConfig) clientConfig() (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: c.ServerName,
+	}
+
+	if c.CertFile != "" || c.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to load certificate: %w", ErrInvalidTLS, err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	if c.CAFile != "" {
+		pool, err := loadCertPool(c.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = pool
+	}
+
+	return config, nil
+}
+
+func (c *TLSConfig) serverConfig() (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to load certificate: %w", ErrInvalidTLS, err)
+	}
+
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+	}
+
+	if c.CAFile != "" {
+		pool, err := loadCertPool(c.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return config, nil
+}
+
+func loadCertPool(caFile string) (*x509.CertPool, error) {
+	pem, err := afero.ReadFile(afero.NewOsFs(), caFile)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read certificate authority: %w", ErrInvalidTLS, err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("%w: no certificates found in %s", ErrInvalidTLS, caFile)
+	}
+
+	return pool, nil
+}
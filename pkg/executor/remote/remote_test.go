@@ -0,0 +1,269 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package remote_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go.bonk.build/pkg/executor/mockexec"
+	"go.bonk.build/pkg/executor/remote"
+	"go.bonk.build/pkg/task"
+)
+
+func TestParseAddress(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		address string
+		network string
+		addr    string
+		invalid bool
+	}{
+		{address: "build-box:7100", network: "tcp", addr: "build-box:7100"},
+		{address: "tcp://build-box:7100", network: "tcp", addr: "build-box:7100"},
+		{address: "unix:///tmp/bonk.sock", network: "unix", addr: "/tmp/bonk.sock"},
+		{address: "tcp://", invalid: true},
+		{address: "unix://", invalid: true},
+		{address: "http://build-box:7100", invalid: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.address, func(t *testing.T) {
+			t.Parallel()
+
+			network, addr, err := remote.ParseAddress(test.address)
+			if test.invalid {
+				require.ErrorIs(t, err, remote.ErrInvalidAddress)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.network, network)
+			assert.Equal(t, test.addr, addr)
+		})
+	}
+}
+
+// serve serves exec on a unix socket, waiting until it's listened on.
+func serve(t *testing.T, exec *mockexec.MockExecutor, tls *remote.TLSConfig) string {
+	t.Helper()
+
+	socket := filepath.Join(t.TempDir(), "executor.sock")
+	address := "unix://" + socket
+
+	go func() {
+		assert.NoError(t, remote.Serve(t.Context(), exec, remote.Options{
+			Address: address,
+			TLS:     tls,
+		}))
+	}()
+
+	require.Eventually(t, func() bool {
+		conn, err := (&net.Dialer{}).DialContext(t.Context(), "unix", socket)
+		if err != nil {
+			return false
+		}
+
+		return conn.Close() == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	return address
+}
+
+func TestDial(t *testing.T) {
+	t.Parallel()
+
+	exec := mockexec.NewMockExecutor(t)
+	address := serve(t, exec, nil)
+
+	client, err := remote.Dial(remote.Options{Address: address})
+	require.NoError(t, err)
+	defer client.Close()
+
+	session := task.NewTestSession()
+	exec.EXPECT().OpenSession(mock.Anything, mock.Anything).Return(nil)
+	exec.EXPECT().CloseSession(mock.Anything, session.ID())
+	exec.EXPECT().Execute(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(_ context.Context, _ task.Session, tsk *task.Task, result *task.Result) {
+			assert.Equal(t, "test.exec", tsk.Executor)
+			result.AddOutputs("output.txt")
+		}).
+		Return(nil)
+
+	err = client.OpenSession(t.Context(), session)
+	require.NoError(t, err)
+	defer client.CloseSession(t.Context(), session.ID())
+
+	var result task.Result
+	err = client.Execute(t.Context(), session, task.New("Test.Task", "test.exec", nil), &result)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"output.txt"}, result.GetOutputs())
+}
+
+func TestClient_Probe(t *testing.T) {
+	t.Parallel()
+
+	client, err := remote.Dial(remote.Options{Address: serve(t, mockexec.NewMockExecutor(t), nil)})
+	require.NoError(t, err)
+	defer client.Close()
+
+	require.NoError(t, client.Probe(t.Context()))
+
+	// Nothing listens on the address once its listener is closed
+	lis, err := (&net.ListenConfig{}).Listen(t.Context(), "tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	require.NoError(t, lis.Close())
+
+	unreachable, err := remote.Dial(remote.Options{Address: lis.Addr().String()})
+	require.NoError(t, err)
+	defer unreachable.Close()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, unreachable.Probe(ctx), remote.ErrUnreachable)
+}
+
+func TestDial_MutualTLS(t *testing.T) {
+	t.Parallel()
+
+	certs := newTestCertificates(t)
+
+	exec := mockexec.NewMockExecutor(t)
+	address := serve(t, exec, &remote.TLSConfig{
+		CertFile: certs.serverCert,
+		KeyFile:  certs.serverKey,
+		CAFile:   certs.ca,
+	})
+
+	session := task.NewTestSession()
+
+	t.Run("verified", func(t *testing.T) {
+		exec.EXPECT().OpenSession(mock.Anything, mock.Anything).Return(nil).Once()
+		exec.EXPECT().CloseSession(mock.Anything, session.ID()).Once()
+
+		client, err := remote.Dial(remote.Options{
+			Address: address,
+			TLS: &remote.TLSConfig{
+				CertFile:   certs.clientCert,
+				KeyFile:    certs.clientKey,
+				CAFile:     certs.ca,
+				ServerName: "localhost",
+			},
+		})
+		require.NoError(t, err)
+		defer client.Close()
+
+		err = client.OpenSession(t.Context(), session)
+		require.NoError(t, err)
+		client.CloseSession(t.Context(), session.ID())
+	})
+
+	t.Run("no client certificate", func(t *testing.T) {
+		client, err := remote.Dial(remote.Options{
+			Address: address,
+			TLS: &remote.TLSConfig{
+				CAFile:     certs.ca,
+				ServerName: "localhost",
+			},
+		})
+		require.NoError(t, err)
+		defer client.Close()
+
+		err = client.OpenSession(t.Context(), session)
+		require.Error(t, err)
+	})
+}
+
+func TestDial_InvalidTLS(t *testing.T) {
+	t.Parallel()
+
+	_, err := remote.Dial(remote.Options{
+		Address: "build-box:7100",
+		TLS:     &remote.TLSConfig{CAFile: filepath.Join(t.TempDir(), "missing.pem")},
+	})
+	require.ErrorIs(t, err, remote.ErrInvalidTLS)
+}
+
+type testCertificates struct {
+	ca         string
+	serverCert string
+	serverKey  string
+	clientCert string
+	clientKey  string
+}
+
+// newTestCertificates writes a certificate authority, and server and client certificates signed by it.
+func newTestCertificates(t *testing.T) testCertificates {
+	t.Helper()
+
+	dir := t.TempDir()
+	fs := afero.NewOsFs()
+
+	writePEM := func(name, blockType string, der []byte) string {
+		path := filepath.Join(dir, name)
+		err := afero.WriteFile(fs, path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600)
+		require.NoError(t, err)
+
+		return path
+	}
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "bonk test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+
+	issue := func(name string, serial int64, usage x509.ExtKeyUsage) (string, string) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: name},
+			DNSNames:     []string{"localhost"},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, caTemplate, &key.PublicKey, caKey)
+		require.NoError(t, err)
+
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		require.NoError(t, err)
+
+		return writePEM(name+".pem", "CERTIFICATE", der), writePEM(name+"-key.pem", "EC PRIVATE KEY", keyDER)
+	}
+
+	certs := testCertificates{ca: writePEM("ca.pem", "CERTIFICATE", caDER)}
+	certs.serverCert, certs.serverKey = issue("server", 2, x509.ExtKeyUsageServerAuth)
+	certs.clientCert, certs.clientKey = issue("client", 3, x509.ExtKeyUsageClientAuth)
+
+	return certs
+}
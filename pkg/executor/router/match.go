@@ -0,0 +1,164 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package router
+
+import (
+	"cmp"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"go.bonk.build/pkg/task"
+)
+
+// Matcher matches executors against routes the way a [Router] routes them, once the router's aliases are resolved,
+// so that settings keyed by route, such as limits and policies, apply to the executors routed to the route.
+// The zero Matcher doesn't resolve any aliases.
+type Matcher struct {
+	router *Router
+}
+
+// Matcher returns a [Matcher] which resolves the router's aliases.
+func (r *Router) Matcher() Matcher {
+	return Matcher{router: r}
+}
+
+// Matches reports whether exec is routed to route, or beneath it.
+func (m Matcher) Matches(route string, exec string) bool {
+	return MatchRoute(route, m.dealias(exec))
+}
+
+// MostSpecific returns the most specific of routes which exec is routed to or beneath, as ordered by [CompareRoutes].
+func (m Matcher) MostSpecific(routes []string, exec string) (string, bool) {
+	exec = m.dealias(exec)
+
+	var (
+		best  string
+		found bool
+	)
+	for _, route := range routes {
+		if MatchRoute(route, exec) && (!found || CompareRoutes(route, best) < 0) {
+			best = route
+			found = true
+		}
+	}
+
+	return best, found
+}
+
+func (m Matcher) dealias(exec string) string {
+	if m.router == nil {
+		return exec
+	}
+
+	// Alias cycles fail when the task is routed, so the executor is matched as it's written until then
+	dealiased, err := m.router.dealias(exec)
+	if err != nil {
+		return exec
+	}
+
+	return dealiased
+}
+
+// MatchRoute reports whether exec is routed to route, or beneath it, matching each of the route's segments as a
+// [Router] does. Unversioned names also match each of their versions, so "kustomize" matches "kustomize@v2.Build".
+// Aliases aren't resolved, see [Matcher].
+func MatchRoute(route string, exec string) bool {
+	for route != "" {
+		var segment string
+		segment, route, _ = cutSegment(route)
+
+		if segmentKind(segment) == RouteRegexp {
+			re, err := compileRouteRegexp(segment)
+			if err != nil {
+				return false
+			}
+
+			match := re.FindString(exec)
+			if match == "" {
+				return false
+			}
+			exec = strings.TrimPrefix(exec[len(match):], task.TaskIDSep)
+
+			continue
+		}
+
+		var execSegment string
+		execSegment, exec, _ = cutSegment(exec)
+		if !matchSegment(segment, execSegment) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchSegment reports whether a segment of an executor matches a segment of a route, other than a regexp.
+func matchSegment(segment string, execSegment string) bool {
+	switch segmentKind(segment) {
+	case RouteWildcard:
+		return true
+	case RouteGlob:
+		matched, _ := path.Match(segment, execSegment)
+
+		return matched
+	case RouteExact:
+		name, _, _ := strings.Cut(execSegment, VersionSep)
+
+		return name == segment
+	default:
+		return execSegment == segment
+	}
+}
+
+// CompareRoutes orders routes from the most to the least specific, comparing the kinds of their segments in turn.
+// Routes with more segments are more specific than those they're beneath, and routes which match equally are
+// ordered by length, longest first.
+func CompareRoutes(a string, b string) int {
+	order := []RouteKind{RouteExact, RouteVersioned, RouteGlob, RouteRegexp, RouteWildcard}
+
+	for aRest, bRest := a, b; aRest != "" || bRest != ""; {
+		if aRest == "" {
+			return 1
+		}
+		if bRest == "" {
+			return -1
+		}
+
+		var aSegment, bSegment string
+		aSegment, aRest, _ = cutSegment(aRest)
+		bSegment, bRest, _ = cutSegment(bRest)
+
+		if c := cmp.Compare(
+			slices.Index(order, segmentKind(aSegment)),
+			slices.Index(order, segmentKind(bSegment)),
+		); c != 0 {
+			return c
+		}
+	}
+
+	return cmp.Or(cmp.Compare(len(b), len(a)), cmp.Compare(a, b))
+}
+
+// routeRegexps caches the compiled regexps of route segments.
+var routeRegexps sync.Map
+
+// compileRouteRegexp compiles a regexp route segment, which matches the start of an executor
+// and must end at a segment boundary.
+func compileRouteRegexp(segment string) (*regexp.Regexp, error) {
+	if re, ok := routeRegexps.Load(segment); ok {
+		return re.(*regexp.Regexp), nil //nolint:forcetypeassert
+	}
+
+	// Matches must end at a segment boundary, so that the rest of the executor can be routed
+	re, err := regexp.Compile(`^(?:` + strings.TrimPrefix(segment, RegexpPrefix) + `)(?:\.|$)`)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	routeRegexps.Store(segment, re)
+
+	return re, nil
+}
@@ -0,0 +1,107 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package router_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.bonk.build/pkg/executor/mockexec"
+	"go.bonk.build/pkg/executor/router"
+)
+
+func Test_MatchRoute(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		route   string
+		exec    string
+		matches bool
+	}{
+		{"kube", "kube", true},
+		{"kube", "kube.apply.Deployment", true},
+		{"kube", "kubectl.apply", false},
+		{"kube.apply", "kube", false},
+		{"kube.*", "kube.get.services", true},
+		{"kube.*", "helm.get", false},
+		{"kube.app*", "kube.approve.Certificate", true},
+		{"kube.app*", "kube.delete", false},
+		{`kube.re:(get|list)\.pods`, "kube.get.pods.Watch", true},
+		{`kube.re:(get|list)\.pods`, "kube.get.podsecurity", false},
+		{`re:[a-z]+\.apply`, "kube.apply.Deployment", true},
+		{"kustomize", "kustomize@v2.Build", true},
+		{"kustomize@v1", "kustomize@v1.Build", true},
+		{"kustomize@v1", "kustomize@v2.Build", false},
+		{"kustomize@v1", "kustomize.Build", false},
+		{"bad.[", "bad.x", false},
+	} {
+		assert.Equal(t, test.matches, router.MatchRoute(test.route, test.exec), "%s ~ %s", test.route, test.exec)
+	}
+}
+
+func Test_CompareRoutes(t *testing.T) {
+	t.Parallel()
+
+	routes := []string{
+		"*",
+		"kube.*",
+		`kube.re:(get|list)`,
+		"kube.app*",
+		"kube",
+		"kube.apply",
+		"kube.apply.Deployment",
+		"kustomize@v1",
+		"kustomize",
+	}
+	slices.SortFunc(routes, router.CompareRoutes)
+
+	assert.Equal(t, []string{
+		"kube.apply.Deployment",
+		"kube.apply",
+		"kube.app*",
+		`kube.re:(get|list)`,
+		"kube.*",
+		"kustomize",
+		"kube",
+		"kustomize@v1",
+		"*",
+	}, routes)
+}
+
+func Test_Matcher(t *testing.T) {
+	t.Parallel()
+
+	rtr := router.New()
+	require.NoError(t, rtr.RegisterExecutor("kustomize", mockexec.NewMockExecutor(t)))
+	require.NoError(t, rtr.RegisterAlias("k8s.kustomize.*", "kustomize.*"))
+	require.NoError(t, rtr.RegisterAlias("first", "second"))
+	require.NoError(t, rtr.RegisterAlias("second", "first"))
+
+	matcher := rtr.Matcher()
+	routes := []string{"kustomize", "kustomize.Build", "k*", "k8s"}
+
+	// Aliases are resolved before matching
+	assert.True(t, matcher.Matches("kustomize", "k8s.kustomize.Build"))
+	assert.False(t, matcher.Matches("k8s", "k8s.kustomize.Build"))
+
+	route, ok := matcher.MostSpecific(routes, "k8s.kustomize.Build")
+	assert.True(t, ok)
+	assert.Equal(t, "kustomize.Build", route)
+
+	route, ok = matcher.MostSpecific(routes, "k8s.kustomize.Edit")
+	assert.True(t, ok)
+	assert.Equal(t, "kustomize", route)
+
+	_, ok = matcher.MostSpecific(routes, "helm.Template")
+	assert.False(t, ok)
+
+	// Alias cycles match the executor as it's written
+	assert.True(t, matcher.Matches("first", "first.Build"))
+
+	// The zero Matcher doesn't resolve aliases
+	assert.True(t, router.Matcher{}.Matches("k8s", "k8s.kustomize.Build"))
+}
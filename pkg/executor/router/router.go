@@ -2,16 +2,32 @@
 // SPDX-License-Identifier: MIT
 
 // Package router provides [Router], which is meant to route tasks to child executors.
+//
+// Executors are registered by routes of segments separated by [task.TaskIDSep], each of which may be:
+//   - An exact name, such as "kube", optionally versioned as [VersionSep] then the version, like "kustomize@v2".
+//     Executors without a version are routed to the unversioned route, or else the latest version registered.
+//   - A glob, such as "kube*" or "[ab]pply", matched by [path.Match].
+//   - A regular expression prefixed by [RegexpPrefix], which spans the rest of the route.
+//     It's matched against the start of the executor, and must end at a segment boundary.
+//   - The [Wildcard], which matches any segment.
+//
+// Executors matching several routes are routed by the first in the order returned by [Router.Routes].
 package router
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"path"
+	"regexp"
+	"slices"
 	"strings"
 	"sync"
+	"unicode"
 
 	"go.uber.org/multierr"
+	"golang.org/x/mod/semver"
 
 	"go.bonk.build/pkg/executor"
 	"go.bonk.build/pkg/task"
@@ -21,10 +37,20 @@ import (
 // and can be used for branching executor trees.
 type Router struct {
 	children map[string]executor.Executor
+	// patterns are the keys of the glob and regexp children, in the order they were registered
+	patterns []string
+	regexps  map[string]*regexp.Regexp
+	aliases  map[string]string
 	mu       sync.RWMutex
 }
 
-const Wildcard = "*"
+const (
+	Wildcard = "*"
+	// VersionSep separates a segment's name from its version, such as in "kustomize@v2.Kustomize".
+	VersionSep = "@"
+	// RegexpPrefix prefixes routes which are regular expressions, such as `re:kube\.(apply|delete)`.
+	RegexpPrefix = "re:"
+)
 
 var (
 	// Note that Router is itself an Executor.
@@ -32,30 +58,71 @@ var (
 
 	ErrDuplicateExecutor = errors.New("duplicate executor name")
 	ErrNoExecutorFound   = errors.New("no executor found")
+	ErrInvalidRoute      = errors.New("invalid route")
+	ErrAliasCycle        = errors.New("alias cycle")
+)
+
+// RouteKind describes how a route is matched.
+type RouteKind string
+
+// Route kinds, from the most to the least specific.
+const (
+	RouteExact     RouteKind = "exact"
+	RouteVersioned RouteKind = "versioned"
+	RouteGlob      RouteKind = "glob"
+	RouteRegexp    RouteKind = "regexp"
+	RouteWildcard  RouteKind = "wildcard"
+	RouteAlias     RouteKind = "alias"
 )
 
+// Route describes a route registered with a [Router].
+type Route struct {
+	// Pattern is the route, such as "kube.apply" or "kube.*".
+	Pattern string
+	// Kind is the least specific way any of the route's segments are matched.
+	Kind RouteKind
+	// Precedence is the route's position in the order routes are tried, starting from 0.
+	// Aliases are tried before any other route.
+	Precedence int
+	// Target is the route an alias routes its executors to, such as "kustomize" for the alias "k8s.kustomize".
+	Target string
+	// Executor is the executor tasks matching the route are routed to, or nil for aliases.
+	Executor executor.Executor
+}
+
 func New() Router {
 	return Router{
 		children: make(map[string]executor.Executor),
 	}
 }
 
+func newRouter() *Router {
+	router := New()
+
+	return &router
+}
+
 func (r *Router) RegisterExecutor(name string, exec executor.Executor) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	before, after, needsSubRouter := strings.Cut(name, task.TaskIDSep)
+	before, after, needsSubRouter := cutSegment(name)
 	child, hasChild := r.children[before]
 
+	if !hasChild {
+		err := r.addPattern(before)
+		if err != nil {
+			return err
+		}
+	}
+
 	switch {
 	// Needs & has subrouter, just recurse
 	case needsSubRouter && hasChild:
 		childRouter, ok := child.(*Router)
 		if !ok {
 			// If there's a child that isn't a router, replace it with a router and recurse.
-			childRouter = &Router{
-				children: make(map[string]executor.Executor, 1),
-			}
+			childRouter = newRouter()
 			r.children[before] = childRouter
 			// Re-register the old child as a nameless.
 			err := childRouter.RegisterExecutor("", child)
@@ -68,9 +135,7 @@ func (r *Router) RegisterExecutor(name string, exec executor.Executor) error {
 
 	// Needs & doesn't have router, add router and rerecurse
 	case needsSubRouter && !hasChild:
-		childRouter := &Router{
-			children: make(map[string]executor.Executor, 1),
-		}
+		childRouter := newRouter()
 		r.children[before] = childRouter
 
 		return childRouter.RegisterExecutor(after, exec)
@@ -94,28 +159,82 @@ func (r *Router) RegisterExecutor(name string, exec executor.Executor) error {
 	}
 }
 
+// addPattern records the segment if it's a glob or regexp, validating it. It must be called with the lock held.
+func (r *Router) addPattern(segment string) error {
+	switch segmentKind(segment) {
+	case RouteGlob:
+		_, err := path.Match(segment, "")
+		if err != nil {
+			return fmt.Errorf("%w %q: %w", ErrInvalidRoute, segment, err)
+		}
+
+	case RouteRegexp:
+		re, err := compileRouteRegexp(segment)
+		if err != nil {
+			return fmt.Errorf("%w %q: %w", ErrInvalidRoute, segment, err)
+		}
+		if r.regexps == nil {
+			r.regexps = make(map[string]*regexp.Regexp)
+		}
+		r.regexps[segment] = re
+
+	default:
+		return nil
+	}
+
+	r.patterns = append(r.patterns, segment)
+
+	return nil
+}
+
+// RegisterAlias routes the executors beneath alias to those beneath target instead,
+// such as "k8s.kustomize.Build" to "kustomize.Build" for the alias "k8s.kustomize" of "kustomize".
+// Trailing wildcards are ignored, so the same alias may be written as "k8s.kustomize.*" and "kustomize.*".
+// Where several aliases match an executor, the longest is used.
+func (r *Router) RegisterAlias(alias string, target string) error {
+	alias = strings.TrimSuffix(alias, task.TaskIDSep+Wildcard)
+	target = strings.TrimSuffix(target, task.TaskIDSep+Wildcard)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.aliases[alias]; ok {
+		return fmt.Errorf("%w: alias %s", ErrDuplicateExecutor, alias)
+	}
+	if r.aliases == nil {
+		r.aliases = make(map[string]string)
+	}
+	r.aliases[alias] = target
+
+	return nil
+}
+
 func (r *Router) UnregisterExecutors(names ...string) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	for _, name := range names {
-		before, after, _ := strings.Cut(name, task.TaskIDSep)
+		before, after, _ := cutSegment(name)
 		child, ok := r.children[before]
 		if !ok {
-			return
+			continue
 		}
 
 		if child, ok := child.(*Router); ok {
 			child.UnregisterExecutors(after)
 
-			// If children remain, return so as to not remove
+			// If children remain, continue so as to not remove
 			if len(child.children) > 0 {
-				return
+				continue
 			}
 		}
 
 		// Remove the child
-		delete(r.children, name)
+		delete(r.children, before)
+		r.patterns = slices.DeleteFunc(r.patterns, func(pattern string) bool {
+			return pattern == before
+		})
+		delete(r.regexps, before)
 	}
 }
 
@@ -146,28 +265,283 @@ func (r *Router) Execute(
 	tsk *task.Task,
 	result *task.Result,
 ) error {
+	exec := tsk.Executor
+
+	name, err := r.dealias(exec)
+	if err != nil {
+		return err
+	}
+
+	child, rest, ok := r.resolve(name)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNoExecutorFound, exec)
+	}
+
+	tsk.Executor = rest
+	err = child.Execute(ctx, session, tsk, result)
+	tsk.Executor = exec
+
+	return err //nolint:wrapcheck
+}
+
+// dealias rewrites the executor beneath any aliases to the executor beneath their targets.
+func (r *Router) dealias(exec string) (string, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	exec := tsk.Executor
-	before, after, _ := strings.Cut(exec, task.TaskIDSep)
+	// Each alias may only be used once, as using it again would loop forever
+	for range len(r.aliases) + 1 {
+		var (
+			target string
+			best   = -1
+		)
+		for alias, aliasTarget := range r.aliases {
+			if exec != alias && !strings.HasPrefix(exec, alias+task.TaskIDSep) {
+				continue
+			}
+			if len(alias) > best {
+				target = aliasTarget + exec[len(alias):]
+				best = len(alias)
+			}
+		}
 
-	// Check for the original key and the wildcard key.
-	for _, searchKey := range []string{before, "*"} {
-		if child, ok := r.children[searchKey]; ok {
-			tsk.Executor = after
-			err := child.Execute(ctx, session, tsk, result)
-			tsk.Executor = exec
+		if best < 0 {
+			return exec, nil
+		}
+		exec = target
+	}
 
-			return err
+	return "", fmt.Errorf("%w: %s", ErrAliasCycle, exec)
+}
+
+// resolve finds the executor that the named executor is routed to, and the rest of the name once routed.
+// Candidates are tried in order of precedence, moving on if none of a subrouter's children match.
+func (r *Router) resolve(name string) (executor.Executor, string, bool) {
+	for _, candidate := range r.candidates(name) {
+		childRouter, ok := candidate.exec.(*Router)
+		if !ok {
+			return candidate.exec, candidate.rest, true
+		}
+
+		exec, rest, ok := childRouter.resolve(candidate.rest)
+		if ok {
+			return exec, rest, true
+		}
+	}
+
+	return nil, "", false
+}
+
+// candidate is a child which may match an executor, and the rest of the executor's name once routed to it.
+type candidate struct {
+	exec executor.Executor
+	rest string
+}
+
+// candidates returns the children that may match name, in order of precedence.
+func (r *Router) candidates(name string) []candidate {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var found []candidate
+	add := func(key string, rest string) {
+		if child, ok := r.children[key]; ok {
+			found = append(found, candidate{exec: child, rest: rest})
 		}
 	}
 
-	if fallback, ok := r.children[""]; ok {
-		return fallback.Execute(ctx, session, tsk, result)
+	before, after, _ := cutSegment(name)
+	add(before, after)
+	if !strings.Contains(before, VersionSep) {
+		for _, key := range r.versionsOf(before) {
+			add(key, after)
+		}
+	}
+
+	for _, pattern := range r.patterns {
+		if re, ok := r.regexps[pattern]; ok {
+			if match := re.FindString(name); match != "" {
+				add(pattern, strings.TrimPrefix(name[len(match):], task.TaskIDSep))
+			}
+		} else if matched, _ := path.Match(pattern, before); matched {
+			add(pattern, after)
+		}
+	}
+
+	add(Wildcard, after)
+	add("", name)
+
+	return found
+}
+
+// versionsOf returns the keys of the versioned children named name, from the latest version to the earliest.
+// It must be called with the lock held.
+func (r *Router) versionsOf(name string) []string {
+	var keys []string
+	for key := range r.children {
+		if strings.HasPrefix(key, name+VersionSep) {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.SortFunc(keys, compareVersions)
+
+	return keys
+}
+
+// compareVersions orders versioned keys of the same name from the latest version to the earliest.
+func compareVersions(a, b string) int {
+	_, aVersion, _ := strings.Cut(a, VersionSep)
+	_, bVersion, _ := strings.Cut(b, VersionSep)
+
+	aCanonical, bCanonical := canonicalVersion(aVersion), canonicalVersion(bVersion)
+	if aCanonical == bCanonical {
+		return cmp.Compare(b, a)
+	}
+
+	return semver.Compare(bCanonical, aCanonical)
+}
+
+// canonicalVersion returns the semantic version of a route's version, which may not have a leading "v".
+func canonicalVersion(version string) string {
+	if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+
+	return semver.Canonical(version)
+}
+
+// Routes lists the executors and aliases registered, in the order they're tried when routing an executor.
+// Aliases come first, longest first, followed by the rest of the routes ordered as they're tried at each segment:
+// exact names, with unversioned names before their latest versions, then globs and regexps in the order
+// they were registered, then the [Wildcard], then the executor registered for the segment before them.
+func (r *Router) Routes() []Route {
+	r.mu.RLock()
+	aliases := make([]Route, 0, len(r.aliases))
+	for alias, target := range r.aliases {
+		aliases = append(aliases, Route{
+			Pattern: alias,
+			Kind:    RouteAlias,
+			Target:  target,
+		})
+	}
+	r.mu.RUnlock()
+
+	slices.SortFunc(aliases, func(a, b Route) int {
+		return cmp.Or(cmp.Compare(len(b.Pattern), len(a.Pattern)), cmp.Compare(a.Pattern, b.Pattern))
+	})
+
+	routes := r.routes(aliases, "", RouteExact)
+	for idx := range routes {
+		routes[idx].Precedence = idx
+	}
+
+	return routes
+}
+
+// routes appends the routes beneath the router to routes, prefixing them with prefix.
+func (r *Router) routes(routes []Route, prefix string, kind RouteKind) []Route {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// Exact names are ordered so that unversioned names come before their versions
+	var exact []string
+	for key := range r.children {
+		if key != "" && key != Wildcard && !slices.Contains(r.patterns, key) {
+			exact = append(exact, key)
+		}
+	}
+	slices.SortFunc(exact, func(a, b string) int {
+		aName, _, aVersioned := strings.Cut(a, VersionSep)
+		bName, _, bVersioned := strings.Cut(b, VersionSep)
+
+		switch {
+		case aName != bName:
+			return cmp.Compare(aName, bName)
+		case aVersioned != bVersioned && !aVersioned:
+			return -1
+		case aVersioned != bVersioned:
+			return 1
+		default:
+			return compareVersions(a, b)
+		}
+	})
+
+	keys := slices.Concat(exact, r.patterns, []string{Wildcard, ""})
+	for _, key := range keys {
+		child, ok := r.children[key]
+		if !ok {
+			continue
+		}
+
+		pattern := prefix
+		if key != "" {
+			pattern = strings.TrimPrefix(prefix+task.TaskIDSep+key, task.TaskIDSep)
+		}
+
+		childKind := kind
+		if key != "" {
+			childKind = leastSpecific(kind, segmentKind(key))
+		}
+
+		if childRouter, ok := child.(*Router); ok {
+			routes = childRouter.routes(routes, pattern, childKind)
+		} else {
+			routes = append(routes, Route{
+				Pattern:  pattern,
+				Kind:     childKind,
+				Executor: child,
+			})
+		}
+	}
+
+	return routes
+}
+
+// leastSpecific returns whichever kind matches more executors.
+func leastSpecific(a, b RouteKind) RouteKind {
+	order := []RouteKind{RouteExact, RouteVersioned, RouteGlob, RouteRegexp, RouteWildcard}
+
+	return order[max(slices.Index(order, a), slices.Index(order, b))]
+}
+
+// segmentKind returns how a route segment is matched.
+func segmentKind(segment string) RouteKind {
+	switch {
+	case segment == Wildcard:
+		return RouteWildcard
+	case strings.HasPrefix(segment, RegexpPrefix):
+		return RouteRegexp
+	case strings.ContainsAny(segment, `*?[\`):
+		return RouteGlob
+	case strings.Contains(segment, VersionSep):
+		return RouteVersioned
+	default:
+		return RouteExact
+	}
+}
+
+// cutSegment cuts the first segment from a route or executor name, such as "kube" from "kube.apply".
+// Versions are kept with their segment, so "kustomize@v2.1.Kustomize" is cut into "kustomize@v2.1" and "Kustomize".
+// Regular expressions span the rest of the route.
+func cutSegment(name string) (string, string, bool) {
+	if strings.HasPrefix(name, RegexpPrefix) {
+		return name, "", false
+	}
+
+	before, after, found := strings.Cut(name, task.TaskIDSep)
+	if !strings.Contains(before, VersionSep) {
+		return before, after, found
+	}
+
+	// Segments of the version start with digits, unlike those of executors
+	for found && after != "" && unicode.IsDigit(rune(after[0])) {
+		var next string
+		next, after, found = strings.Cut(after, task.TaskIDSep)
+		before += task.TaskIDSep + next
 	}
 
-	return fmt.Errorf("%w: %s", ErrNoExecutorFound, tsk.Executor)
+	return before, after, found
 }
 
 func (r *Router) GetNumExecutors() int {
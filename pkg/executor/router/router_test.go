@@ -4,6 +4,7 @@
 package router_test
 
 import (
+	"context"
 	"maps"
 	"slices"
 	"sync"
@@ -221,3 +222,146 @@ func Test_OpenCloseSession_Error(t *testing.T) {
 	require.ErrorIs(t, err, assert.AnError)
 	defer rtr.CloseSession(t.Context(), session.ID())
 }
+
+func Test_Call_Patterns(t *testing.T) {
+	t.Parallel()
+
+	rtr := router.New()
+	executors := make(map[string]*mockexec.MockExecutor)
+	for _, route := range []string{
+		"kube.apply",
+		"kube.app*",
+		`kube.re:(get|list)\.pods`,
+		"kube.*",
+		"kustomize",
+		"kustomize@v1",
+		"kustomize@v2.1.0",
+		"helm@v3",
+		"helm@v4",
+	} {
+		executors[route] = mockexec.NewMockExecutor(t)
+		require.NoError(t, rtr.RegisterExecutor(route, executors[route]))
+	}
+
+	for sent, expected := range map[string]struct {
+		route    string
+		executor string
+	}{
+		"kube.apply.Deployment":    {"kube.apply", "Deployment"},
+		"kube.approve.Certificate": {"kube.app*", "Certificate"},
+		"kube.get.pods.Watch":      {`kube.re:(get|list)\.pods`, "Watch"},
+		"kube.get.services":        {"kube.*", "services"},
+		"kustomize.Build":          {"kustomize", "Build"},
+		"kustomize@v1.Build":       {"kustomize@v1", "Build"},
+		"kustomize@v2.1.0.Build":   {"kustomize@v2.1.0", "Build"},
+		"helm.Template":            {"helm@v4", "Template"},
+		"helm@v3.Template":         {"helm@v3", "Template"},
+	} {
+		tsk := task.New("Task", sent, nil)
+
+		executors[expected.route].EXPECT().Execute(t.Context(), nil, tsk, (*task.Result)(nil)).
+			Run(func(_ context.Context, _ task.Session, tsk *task.Task, _ *task.Result) {
+				assert.Equal(t, expected.executor, tsk.Executor, sent)
+			}).
+			Return(nil).Once()
+
+		err := rtr.Execute(t.Context(), nil, tsk, nil)
+		require.NoError(t, err, sent)
+		assert.Equal(t, sent, tsk.Executor)
+	}
+
+	err := rtr.RegisterExecutor("bad.[", mockexec.NewMockExecutor(t))
+	require.ErrorIs(t, err, router.ErrInvalidRoute)
+}
+
+func Test_Call_Backtrack(t *testing.T) {
+	t.Parallel()
+
+	// Routes which don't match the rest of the executor give way to those after them
+	exact := mockexec.NewMockExecutor(t)
+	glob := mockexec.NewMockExecutor(t)
+
+	rtr := router.New()
+	require.NoError(t, rtr.RegisterExecutor("kube.apply", exact))
+	require.NoError(t, rtr.RegisterExecutor("k*.delete", glob))
+
+	tsk := task.New("Task", "kube.delete", nil)
+	glob.EXPECT().Execute(t.Context(), nil, tsk, (*task.Result)(nil)).Return(nil)
+
+	err := rtr.Execute(t.Context(), nil, tsk, nil)
+	require.NoError(t, err)
+}
+
+func Test_Call_Alias(t *testing.T) {
+	t.Parallel()
+
+	exec := mockexec.NewMockExecutor(t)
+
+	rtr := router.New()
+	require.NoError(t, rtr.RegisterExecutor("kustomize", exec))
+	require.NoError(t, rtr.RegisterAlias("k8s.kustomize.*", "kustomize.*"))
+	require.ErrorIs(t, rtr.RegisterAlias("k8s.kustomize", "other"), router.ErrDuplicateExecutor)
+
+	tsk := task.New("Task", "k8s.kustomize.Build", nil)
+	exec.EXPECT().Execute(t.Context(), nil, tsk, (*task.Result)(nil)).
+		Run(func(_ context.Context, _ task.Session, tsk *task.Task, _ *task.Result) {
+			assert.Equal(t, "Build", tsk.Executor)
+		}).
+		Return(nil)
+
+	err := rtr.Execute(t.Context(), nil, tsk, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "k8s.kustomize.Build", tsk.Executor)
+
+	// Aliases can't route executors back to themselves
+	require.NoError(t, rtr.RegisterAlias("first", "second"))
+	require.NoError(t, rtr.RegisterAlias("second", "first"))
+
+	err = rtr.Execute(t.Context(), nil, task.New("Task", "first.Build", nil), nil)
+	require.ErrorIs(t, err, router.ErrAliasCycle)
+}
+
+func Test_Routes(t *testing.T) {
+	t.Parallel()
+
+	rtr := router.New()
+	for _, route := range []string{
+		"kube.*",
+		"kube.app*",
+		"kube.apply",
+		"kustomize@v1",
+		"kustomize@v2",
+		"kustomize",
+		"test",
+		"test.child",
+	} {
+		require.NoError(t, rtr.RegisterExecutor(route, mockexec.NewMockExecutor(t)))
+	}
+	require.NoError(t, rtr.RegisterAlias("k8s.kustomize", "kustomize"))
+
+	routes := rtr.Routes()
+
+	type summary struct {
+		Pattern    string
+		Kind       router.RouteKind
+		Precedence int
+	}
+	summaries := make([]summary, len(routes))
+	for idx, route := range routes {
+		summaries[idx] = summary{route.Pattern, route.Kind, route.Precedence}
+	}
+
+	assert.Equal(t, []summary{
+		{"k8s.kustomize", router.RouteAlias, 0},
+		{"kube.apply", router.RouteExact, 1},
+		{"kube.app*", router.RouteGlob, 2},
+		{"kube.*", router.RouteWildcard, 3},
+		{"kustomize", router.RouteExact, 4},
+		{"kustomize@v2", router.RouteVersioned, 5},
+		{"kustomize@v1", router.RouteVersioned, 6},
+		{"test.child", router.RouteExact, 7},
+		{"test", router.RouteExact, 8},
+	}, summaries)
+	assert.Equal(t, "kustomize", routes[0].Target)
+	assert.NotNil(t, routes[1].Executor)
+}
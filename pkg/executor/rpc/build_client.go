@@ -0,0 +1,185 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/google/uuid"
+
+	bonkv0 "go.bonk.build/api/bonk/v0"
+	"go.bonk.build/pkg/executor/observable"
+	"go.bonk.build/pkg/task"
+)
+
+var (
+	// ErrUnsupportedSession is returned when submitting a session without a local workspace to a build server.
+	ErrUnsupportedSession = errors.New("build servers only support local sessions")
+	// ErrBuildInterrupted is returned when the connection to a build server ends before the build finishes.
+	ErrBuildInterrupted = errors.New("build server stopped before the build finished")
+	// ErrConfigurationMismatch is returned when a build server refuses a build,
+	// because it was started with a different configuration than the client's.
+	ErrConfigurationMismatch = errors.New("build server configuration differs from the client's")
+)
+
+// BuildClient submits builds to a build server, streaming the statuses of their tasks back to observers.
+type BuildClient struct {
+	client        bonkv0.BuildServiceClient
+	configuration string
+}
+
+var _ Builder = (*BuildClient)(nil)
+
+// NewBuildClient creates a client which submits builds across a GRPC connection.
+// configuration identifies the options the client would build with itself, such as its plugins and limits,
+// which must match those the server was started with.
+func NewBuildClient(conn *grpc.ClientConn, configuration string) *BuildClient {
+	return &BuildClient{
+		client:        bonkv0.NewBuildServiceClient(conn),
+		configuration: configuration,
+	}
+}
+
+// Build implements Builder.
+// Canceling ctx cancels the build, which returns once the server has stopped its tasks.
+// The facts of ctx, as set by [task.ContextWithFacts], are sent with the build.
+// If the server's configuration differs, the build is refused with [ErrConfigurationMismatch].
+func (c *BuildClient) Build(
+	ctx context.Context,
+	sessions map[task.Session][]*task.Task,
+	result *task.Result,
+	observers ...observable.Observer,
+) error {
+	req := bonkv0.SubmitBuildRequest_builder{
+		Sessions:      make([]*bonkv0.SubmitBuildRequest_Session, 0, len(sessions)),
+		Configuration: &c.configuration,
+	}
+	if facts, ok := task.FactsFromContext(ctx); ok {
+		req.Facts = bonkv0.Facts_builder{
+			Profiles: facts.Profiles,
+			Env:      facts.Env,
+		}.Build()
+	}
+	byID := make(map[task.SessionID]task.Session, len(sessions))
+
+	for session, tasks := range sessions {
+		localSession, ok := session.(task.LocalSession)
+		if !ok {
+			return fmt.Errorf("%w: session %s", ErrUnsupportedSession, session.ID())
+		}
+		byID[session.ID()] = session
+
+		protoTasks := make([]*bonkv0.BuildTask, len(tasks))
+		for idx, tsk := range tasks {
+			var err error
+			protoTasks[idx], err = toProtoBuildTask(tsk)
+			if err != nil {
+				return err
+			}
+		}
+
+		req.Sessions = append(req.Sessions, bonkv0.SubmitBuildRequest_Session_builder{
+			Id:           new(session.ID().String()),
+			AbsolutePath: new(localSession.LocalPath()),
+			Tasks:        protoTasks,
+		}.Build())
+	}
+
+	// Cancellation is sent explicitly rather than by canceling the call,
+	// so that the statuses of the tasks stopping are still received.
+	callCtx, cancelCall := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelCall()
+
+	stream, err := c.client.SubmitBuild(callCtx, req.Build())
+	if err != nil {
+		return fmt.Errorf("failed to submit build: %w", err)
+	}
+
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return ErrBuildInterrupted
+		}
+		if status.Code(err) == codes.FailedPrecondition {
+			return fmt.Errorf("%w: %s", ErrConfigurationMismatch, status.Convert(err).Message())
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrBuildInterrupted, err)
+		}
+
+		switch event.WhichEvent() {
+		case bonkv0.BuildEvent_Started_case:
+			buildID := event.GetStarted().GetBuildId()
+			stopCancel := context.AfterFunc(ctx, func() {
+				_, err := c.client.CancelBuild(callCtx, bonkv0.CancelBuildRequest_builder{
+					BuildId: &buildID,
+				}.Build())
+				if err != nil {
+					slog.DebugContext(callCtx, "failed to cancel build", "build", buildID, "error", err)
+				}
+			})
+			defer stopCancel()
+
+		case bonkv0.BuildEvent_TaskStatus_case:
+			msg := fromProtoTaskStatus(event.GetTaskStatus(), byID)
+
+			// Outputs are added for each finished task, as they are by the scheduler
+			result.AddOutputs(msg.Result.GetOutputs()...)
+
+			for _, observer := range observers {
+				observer(msg)
+			}
+
+		case bonkv0.BuildEvent_Finished_case:
+			if event.GetFinished().HasError() {
+				return FromProtoError(event.GetFinished().GetError())
+			}
+
+			return nil
+
+		default:
+			slog.DebugContext(ctx, "received unknown build event")
+		}
+	}
+}
+
+func fromProtoTaskStatus(
+	protoStatus *bonkv0.BuildEvent_TaskStatus,
+	sessions map[task.SessionID]task.Session,
+) observable.TaskStatusMsg {
+	msg := observable.TaskStatusMsg{
+		TaskID:   task.ID(protoStatus.GetTaskId()),
+		Status:   observable.TaskStatus(protoStatus.GetStatus()),
+		Time:     protoStatus.GetTime().AsTime(),
+		Executor: protoStatus.GetExecutor(),
+		Args:     protoStatus.GetArguments().AsInterface(),
+		Attempt:  int(protoStatus.GetAttempt()),
+	}
+
+	if sessionID, err := uuid.Parse(protoStatus.GetSessionId()); err == nil {
+		msg.Session = sessions[sessionID]
+	}
+
+	if msg.Status == observable.StatusSuccess || msg.Status == observable.StatusCached {
+		msg.Result = &task.Result{}
+		msg.Result.AddOutputs(protoStatus.GetOutputs()...)
+		if msg.Status == observable.StatusCached {
+			msg.Result.MarkCached()
+		}
+	}
+
+	if protoStatus.HasError() {
+		msg.Error = FromProtoError(protoStatus.GetError())
+	}
+
+	return msg
+}
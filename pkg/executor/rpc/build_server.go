@@ -0,0 +1,197 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/google/uuid"
+
+	bonkv0 "go.bonk.build/api/bonk/v0"
+	"go.bonk.build/pkg/executor/observable"
+	"go.bonk.build/pkg/task"
+)
+
+// Builder executes the tasks of each session, calling observers with the statuses of the build's tasks,
+// and adding the outputs of every task to result.
+// It's implemented by the driver's engine, and by [BuildClient] to forward builds to a build server.
+type Builder interface {
+	Build(
+		ctx context.Context,
+		sessions map[task.Session][]*task.Task,
+		result *task.Result,
+		observers ...observable.Observer,
+	) error
+}
+
+type buildServer struct {
+	bonkv0.UnimplementedBuildServiceServer
+
+	builder       Builder
+	configuration string
+
+	running   map[string]context.CancelFunc
+	runningMu sync.Mutex
+}
+
+var _ bonkv0.BuildServiceServer = (*buildServer)(nil)
+
+// RegisterBuildServer creates a GRPC server which executes submitted builds with builder.
+// Builds submitted with a different configuration than the server's are refused, as their clients would build
+// them differently. See [NewBuildClient].
+func RegisterBuildServer(server *grpc.Server, builder Builder, configuration string) {
+	bonkv0.RegisterBuildServiceServer(server, &buildServer{
+		builder:       builder,
+		configuration: configuration,
+		running:       make(map[string]context.CancelFunc),
+	})
+}
+
+// SubmitBuild implements v0.BuildServiceServer.
+func (s *buildServer) SubmitBuild(
+	req *bonkv0.SubmitBuildRequest,
+	stream grpc.ServerStreamingServer[bonkv0.BuildEvent],
+) error {
+	if req.GetConfiguration() != s.configuration {
+		return status.Errorf(codes.FailedPrecondition, "build server configuration %q differs from the client's %q",
+			s.configuration, req.GetConfiguration())
+	}
+
+	sessions := make(map[task.Session][]*task.Task, len(req.GetSessions()))
+	for _, protoSession := range req.GetSessions() {
+		sessionID, err := uuid.Parse(protoSession.GetId())
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid session id %q: %s", protoSession.GetId(), err)
+		}
+
+		tasks := make([]*task.Task, len(protoSession.GetTasks()))
+		for idx, protoTask := range protoSession.GetTasks() {
+			tasks[idx] = fromProtoBuildTask(protoTask)
+		}
+
+		sessions[task.NewLocalSession(sessionID, protoSession.GetAbsolutePath())] = tasks
+	}
+
+	// The build stops if it's canceled or the client goes away
+	buildID := uuid.NewString()
+	ctx, cancel := context.WithCancel(stream.Context())
+	if req.HasFacts() {
+		ctx = task.ContextWithFacts(ctx, task.Facts{
+			Profiles: req.GetFacts().GetProfiles(),
+			Env:      req.GetFacts().GetEnv(),
+		})
+	}
+
+	s.runningMu.Lock()
+	s.running[buildID] = cancel
+	s.runningMu.Unlock()
+
+	defer func() {
+		s.runningMu.Lock()
+		delete(s.running, buildID)
+		s.runningMu.Unlock()
+
+		cancel()
+	}()
+
+	// Statuses are sent from many goroutines, which a stream doesn't support
+	var sendMu sync.Mutex
+	send := func(event *bonkv0.BuildEvent) error {
+		sendMu.Lock()
+		defer sendMu.Unlock()
+
+		return stream.Send(event)
+	}
+
+	err := send(bonkv0.BuildEvent_builder{
+		Started: bonkv0.BuildEvent_Started_builder{
+			BuildId: &buildID,
+		}.Build(),
+	}.Build())
+	if err != nil {
+		return fmt.Errorf("failed to send build start: %w", err)
+	}
+
+	slog.DebugContext(ctx, "starting build", "build", buildID, "sessions", len(sessions))
+
+	err = s.builder.Build(ctx, sessions, nil, func(msg observable.TaskStatusMsg) {
+		err := send(bonkv0.BuildEvent_builder{
+			TaskStatus: toProtoTaskStatus(ctx, msg),
+		}.Build())
+		if err != nil {
+			slog.DebugContext(ctx, "failed to send task status", "build", buildID, "task", msg.TaskID, "error", err)
+		}
+	})
+
+	finished := bonkv0.BuildEvent_Finished_builder{}
+	if err != nil {
+		finished.Error = ToProtoError(err)
+	}
+
+	slog.DebugContext(ctx, "finished build", "build", buildID, "error", err)
+
+	err = send(bonkv0.BuildEvent_builder{
+		Finished: finished.Build(),
+	}.Build())
+	if err != nil {
+		return fmt.Errorf("failed to send build result: %w", err)
+	}
+
+	return nil
+}
+
+// CancelBuild implements v0.BuildServiceServer.
+func (s *buildServer) CancelBuild(
+	ctx context.Context,
+	req *bonkv0.CancelBuildRequest,
+) (*bonkv0.CancelBuildResponse, error) {
+	s.runningMu.Lock()
+	cancel, ok := s.running[req.GetBuildId()]
+	s.runningMu.Unlock()
+
+	if ok {
+		slog.DebugContext(ctx, "canceling build", "build", req.GetBuildId())
+		cancel()
+	}
+
+	return bonkv0.CancelBuildResponse_builder{
+		Canceled: &ok,
+	}.Build(), nil
+}
+
+func toProtoTaskStatus(ctx context.Context, msg observable.TaskStatusMsg) *bonkv0.BuildEvent_TaskStatus {
+	builder := bonkv0.BuildEvent_TaskStatus_builder{
+		SessionId: new(msg.Session.ID().String()),
+		TaskId:    new(msg.TaskID.String()),
+		Status:    new(int64(msg.Status)),
+		Time:      timestamppb.New(msg.Time),
+		Executor:  &msg.Executor,
+		Attempt:   new(int64(msg.Attempt)),
+	}
+
+	// Arguments are only informational, so the status is still worth sending without them
+	args, err := ToProtoValue(msg.Args)
+	if err != nil {
+		slog.DebugContext(ctx, "failed to encode task args", "task", msg.TaskID, "error", err)
+	} else {
+		builder.Arguments = args
+	}
+
+	if msg.Result != nil {
+		builder.Outputs = msg.Result.GetOutputs()
+	}
+	if msg.Error != nil {
+		builder.Error = ToProtoError(msg.Error)
+	}
+
+	return builder.Build()
+}
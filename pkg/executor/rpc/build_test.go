@@ -0,0 +1,238 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package rpc_test
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.bonk.build/pkg/executor"
+	"go.bonk.build/pkg/executor/observable"
+	"go.bonk.build/pkg/executor/rpc"
+	"go.bonk.build/pkg/task"
+)
+
+type builderFunc func(
+	ctx context.Context,
+	sessions map[task.Session][]*task.Task,
+	result *task.Result,
+	observers ...observable.Observer,
+) error
+
+func (f builderFunc) Build(
+	ctx context.Context,
+	sessions map[task.Session][]*task.Task,
+	result *task.Result,
+	observers ...observable.Observer,
+) error {
+	return f(ctx, sessions, result, observers...)
+}
+
+func newBuildClient(t *testing.T, builder rpc.Builder) *rpc.BuildClient {
+	t.Helper()
+
+	return newBuildClientWithConfiguration(t, builder, "configuration", "configuration")
+}
+
+func newBuildClientWithConfiguration(
+	t *testing.T,
+	builder rpc.Builder,
+	serverConfiguration, clientConfiguration string,
+) *rpc.BuildClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	rpc.RegisterBuildServer(server, builder, serverConfiguration)
+
+	go server.Serve(lis) //nolint:errcheck
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return rpc.NewBuildClient(conn, clientConfiguration)
+}
+
+func TestBuild(t *testing.T) {
+	t.Parallel()
+
+	session := task.NewLocalSession(task.NewSessionID(), t.TempDir())
+	submitted := task.New("Test.Task", "test.exec", map[string]any{"value": 3.0},
+		task.WithInputs("input.txt"),
+		task.WithDependencies("Test.Other"),
+		task.WithResources(task.Resources{"cpu": 2}),
+		task.WithTimeout(time.Minute),
+		task.WithRetry(task.RetryPolicy{MaxAttempts: 3, Backoff: time.Second, RetryOn: []string{"unavailable"}}),
+		task.WithMatrix(task.Matrix{"env": {"dev", "prod"}}),
+		task.WithCondition(task.Condition{
+			Profiles: []string{"ci"},
+			Results:  map[task.ID]task.Outcome{"Test.Other": task.OutcomeExecuted},
+			Outputs:  map[task.ID][]string{"Test.Other": {"*.yaml"}},
+			Not:      &task.Condition{Exists: []string{"skip"}},
+		}),
+	)
+
+	facts := task.Facts{Profiles: []string{"ci"}, Env: map[string]string{"CI": "true"}}
+	client := newBuildClient(t, builderFunc(func(
+		ctx context.Context,
+		sessions map[task.Session][]*task.Task,
+		_ *task.Result,
+		observers ...observable.Observer,
+	) error {
+		// The build is evaluated against the client's facts, rather than the server's
+		serverFacts, ok := task.FactsFromContext(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, facts, serverFacts)
+
+		assert.Len(t, sessions, 1)
+		for serverSession, tasks := range sessions {
+			assert.Equal(t, session.ID(), serverSession.ID())
+			assert.Equal(t, []*task.Task{submitted}, tasks)
+
+			result := &task.Result{}
+			result.AddOutputs("output.txt")
+			result.MarkCached()
+
+			for _, observer := range observers {
+				observer(observable.TaskRunningMsg(serverSession, tasks[0]))
+				observer(observable.TaskFinishedMsg(serverSession, tasks[0], result, nil))
+			}
+		}
+
+		return nil
+	}))
+
+	var (
+		msgs   []observable.TaskStatusMsg
+		msgsMu sync.Mutex
+	)
+	result := task.Result{}
+
+	ctx := task.ContextWithFacts(t.Context(), facts)
+	err := client.Build(ctx, map[task.Session][]*task.Task{session: {submitted}}, &result,
+		func(msg observable.TaskStatusMsg) {
+			msgsMu.Lock()
+			defer msgsMu.Unlock()
+
+			msgs = append(msgs, msg)
+		})
+	require.NoError(t, err)
+
+	require.Len(t, msgs, 2)
+	assert.Equal(t, observable.StatusRunning, msgs[0].Status)
+	assert.Equal(t, observable.StatusCached, msgs[1].Status)
+	assert.Same(t, session, msgs[1].Session)
+	assert.Equal(t, submitted.ID, msgs[1].TaskID)
+	assert.Equal(t, submitted.Args, msgs[1].Args)
+	assert.Equal(t, 1, msgs[1].Attempt)
+	assert.True(t, msgs[1].Result.IsCached())
+	assert.Equal(t, []string{"output.txt"}, result.GetOutputs())
+}
+
+func TestBuild_Error(t *testing.T) {
+	t.Parallel()
+
+	client := newBuildClient(t, builderFunc(func(
+		context.Context,
+		map[task.Session][]*task.Task,
+		*task.Result,
+		...observable.Observer,
+	) error {
+		return executor.NewError(executor.KindInvalidArgument, assert.AnError)
+	}))
+
+	session := task.NewLocalSession(task.NewSessionID(), t.TempDir())
+	err := client.Build(t.Context(), map[task.Session][]*task.Task{session: nil}, nil)
+	require.ErrorIs(t, err, &executor.Error{Kind: executor.KindInvalidArgument})
+	require.ErrorContains(t, err, assert.AnError.Error())
+}
+
+func TestBuild_Cancel(t *testing.T) {
+	t.Parallel()
+
+	started := make(chan struct{})
+	client := newBuildClient(t, builderFunc(func(
+		ctx context.Context,
+		_ map[task.Session][]*task.Task,
+		_ *task.Result,
+		_ ...observable.Observer,
+	) error {
+		close(started)
+		<-ctx.Done()
+
+		return ctx.Err()
+	}))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	session := task.NewLocalSession(task.NewSessionID(), t.TempDir())
+
+	errs := make(chan error)
+	go func() {
+		errs <- client.Build(ctx, map[task.Session][]*task.Task{session: nil}, nil)
+	}()
+
+	select {
+	case err := <-errs:
+		require.ErrorIs(t, err, context.Canceled)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "build wasn't canceled")
+	}
+}
+
+func TestBuild_UnsupportedSession(t *testing.T) {
+	t.Parallel()
+
+	client := newBuildClient(t, builderFunc(func(
+		context.Context,
+		map[task.Session][]*task.Task,
+		*task.Result,
+		...observable.Observer,
+	) error {
+		return nil
+	}))
+
+	err := client.Build(t.Context(), map[task.Session][]*task.Task{task.NewTestSession(): nil}, nil)
+	require.ErrorIs(t, err, rpc.ErrUnsupportedSession)
+}
+
+func TestBuild_ConfigurationMismatch(t *testing.T) {
+	t.Parallel()
+
+	client := newBuildClientWithConfiguration(t, builderFunc(func(
+		context.Context,
+		map[task.Session][]*task.Task,
+		*task.Result,
+		...observable.Observer,
+	) error {
+		assert.Fail(t, "build with a different configuration shouldn't be executed")
+
+		return nil
+	}), "server", "client")
+
+	session := task.NewLocalSession(task.NewSessionID(), t.TempDir())
+	err := client.Build(t.Context(), map[task.Session][]*task.Task{session: nil}, nil)
+	require.ErrorIs(t, err, rpc.ErrConfigurationMismatch)
+}
@@ -9,8 +9,12 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"sync"
+
+	"go.uber.org/multierr"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/spf13/afero"
@@ -20,20 +24,47 @@ import (
 	"go.bonk.build/pkg/task"
 )
 
+// ClientOption configures a client created by [NewGRPCClient].
+type ClientOption func(*grpcClient)
+
+// WithRemoteWorkspaces serves the files of every session to the executor over the connection,
+// rather than only those of sessions without a local path, for executors which don't share the local disk.
+func WithRemoteWorkspaces() ClientOption {
+	return func(pb *grpcClient) {
+		pb.remoteWorkspaces = true
+	}
+}
+
 // NewGRPCClient creates an executor that forwards task invocations across a GRPC connection.
 func NewGRPCClient(
 	conn *grpc.ClientConn,
+	options ...ClientOption,
 ) executor.Executor {
-	return &grpcClient{
-		client: bonkv0.NewExecutorServiceClient(conn),
+	pb := &grpcClient{
+		client:     bonkv0.NewExecutorServiceClient(conn),
+		workspaces: make(map[task.SessionID]context.CancelFunc),
+	}
+	for _, option := range options {
+		option(pb)
 	}
+
+	return pb
 }
 
 type grpcClient struct {
 	client bonkv0.ExecutorServiceClient
+
+	remoteWorkspaces bool
+
+	// workspaces stops serving the remote workspace of each session
+	workspaces   map[task.SessionID]context.CancelFunc
+	workspacesMu sync.Mutex
 }
 
-var _ executor.Executor = (*grpcClient)(nil)
+var (
+	_ executor.Executor  = (*grpcClient)(nil)
+	_ executor.Describer = (*grpcClient)(nil)
+)
 
 func (pb *grpcClient) OpenSession(ctx context.Context, session task.Session) error {
 	slog.DebugContext(ctx, "opening session", "session", session.ID())
@@ -46,22 +77,62 @@ func (pb *grpcClient) OpenSession(ctx context.Context, session task.Session) err
 		}.Build(),
 	}
 
-	if localSession, ok := session.(task.LocalSession); ok {
+	localSession, isLocal := session.(task.LocalSession)
+	_, isTest := session.SourceFS().(*afero.MemMapFs)
+
+	switch {
+	case isTest:
+		openSessionRequest.Test = bonkv0.OpenSessionRequest_WorkspaceDescriptionTest_builder{}.Build()
+
+	case isLocal && !pb.remoteWorkspaces:
 		openSessionRequest.Local = bonkv0.OpenSessionRequest_WorkspaceDescriptionLocal_builder{
 			AbsolutePath: new(localSession.LocalPath()),
 		}.Build()
+
+	default:
+		// The workspace is served until the session is closed
+		workspaceCtx, cancel := context.WithCancel(ctx)
+		err := pb.attachWorkspace(workspaceCtx, session)
+		if err != nil {
+			cancel()
+
+			return err
+		}
+
+		pb.workspacesMu.Lock()
+		pb.workspaces[session.ID()] = cancel
+		pb.workspacesMu.Unlock()
+
+		openSessionRequest.Remote = bonkv0.OpenSessionRequest_WorkspaceDescriptionRemote_builder{}.Build()
 	}
-	if _, ok := session.SourceFS().(*afero.MemMapFs); ok {
-		openSessionRequest.Test = bonkv0.OpenSessionRequest_WorkspaceDescriptionTest_builder{}.Build()
+
+	err := pb.openSession(ctx, openSessionRequest.Build())
+	if err != nil {
+		pb.stopWorkspace(session.ID())
+
+		return err
 	}
-	stream, err := pb.client.OpenSession(ctx, openSessionRequest.Build())
+
+	return nil
+}
+
+func (pb *grpcClient) openSession(ctx context.Context, req *bonkv0.OpenSessionRequest) error {
+	stream, err := pb.client.OpenSession(ctx, req)
 	if err != nil {
+		if status.Code(err) == codes.Unavailable {
+			return unavailableError(err)
+		}
+
 		return fmt.Errorf("failed to open session stream: %w", err)
 	}
 
 	// Wait for ack message
 	msg, err := stream.Recv()
 	if err != nil {
+		if status.Code(err) == codes.Unavailable {
+			return unavailableError(err)
+		}
+
 		return fmt.Errorf("error receiving ack: %w", err)
 	}
 	if msg.WhichMessage() != bonkv0.OpenSessionResponse_Ack_case {
@@ -74,6 +145,23 @@ func (pb *grpcClient) OpenSession(ctx context.Context, session task.Session) err
 	return nil
 }
 
+// unavailableError classifies err as the executor being unreachable, so that callers may try another.
+func unavailableError(err error) error {
+	return executor.NewError(executor.KindUnavailable, fmt.Errorf("executor unavailable: %w", err))
+}
+
+// stopWorkspace stops serving the session's remote workspace, if it has one.
+func (pb *grpcClient) stopWorkspace(sessionID task.SessionID) {
+	pb.workspacesMu.Lock()
+	cancel, ok := pb.workspaces[sessionID]
+	delete(pb.workspaces, sessionID)
+	pb.workspacesMu.Unlock()
+
+	if ok {
+		cancel()
+	}
+}
+
 func (pb *grpcClient) CloseSession(ctx context.Context, sessionID task.SessionID) {
 	_, err := pb.client.CloseSession(ctx, bonkv0.CloseSessionRequest_builder{
 		Id: new(sessionID.String()),
@@ -81,6 +169,8 @@ func (pb *grpcClient) CloseSession(ctx context.Context, sessionID task.SessionID
 	if err != nil {
 		slog.ErrorContext(ctx, "got error closing session", "session", sessionID, "error", err)
 	}
+
+	pb.stopWorkspace(sessionID)
 }
 
 func (pb *grpcClient) Execute(
@@ -102,11 +192,37 @@ func (pb *grpcClient) Execute(
 		return fmt.Errorf("failed to encode args to proto: %w", err)
 	}
 
-	res, err := pb.client.ExecuteTask(ctx, taskReqBuilder.Build())
+	if ctx.Err() != nil {
+		return fmt.Errorf("task not started: %w", ctx.Err())
+	}
+
+	// Cancellation is sent explicitly rather than by canceling the call,
+	// so that the call only returns once the executor has stopped.
+	callCtx := context.WithoutCancel(ctx)
+	stopCancel := context.AfterFunc(ctx, func() {
+		_, err := pb.client.CancelTask(callCtx, bonkv0.CancelTaskRequest_builder{
+			SessionId: new(session.ID().String()),
+			Id:        (*string)(&tsk.ID),
+		}.Build())
+		if err != nil {
+			slog.DebugContext(callCtx, "failed to cancel task", "task", tsk.ID, "error", err)
+		}
+	})
+	defer stopCancel()
+
+	res, err := pb.client.ExecuteTask(callCtx, taskReqBuilder.Build())
 	if err != nil {
 		status := status.Convert(err)
 		if status.Code() == CodeExecErr {
-			return errors.New(status.Message())
+			return executionErrorFromStatus(status)
+		}
+
+		// The call may fail if the server was killed after cancellation
+		if ctx.Err() != nil {
+			return fmt.Errorf("task canceled: %w", multierr.Combine(ctx.Err(), err))
+		}
+		if status.Code() == codes.Unavailable {
+			return unavailableError(err)
 		}
 
 		return fmt.Errorf("unknown error performing task: %w", err)
@@ -115,15 +231,36 @@ func (pb *grpcClient) Execute(
 	result.AddOutputs(res.GetOutput()...)
 	followups := make([]*task.Task, len(res.GetFollowupTasks()))
 	for ii, followup := range res.GetFollowupTasks() {
-		// Create the new task and append it
-		followups[ii] = task.New(
-			task.NewID(followup.GetId()),
-			followup.GetExecutor(),
-			followup.GetArguments().AsInterface(),
-			task.WithInputs(followup.GetInputs()...),
-		)
+		followups[ii] = fromProtoBuildTask(followup)
 	}
 	result.AddFollowupTasks(followups...)
 
 	return nil
 }
+
+// Describe implements executor.Describer.
+// Servers which don't support it return an Unimplemented status.
+func (pb *grpcClient) Describe(ctx context.Context) (executor.Description, error) {
+	res, err := pb.client.Describe(ctx, &bonkv0.DescribeRequest{})
+	if err != nil {
+		if status := status.Convert(err); status.Code() == CodeExecErr {
+			return executor.Description{}, executionErrorFromStatus(status)
+		}
+
+		return executor.Description{}, fmt.Errorf("failed to describe executor: %w", err)
+	}
+
+	desc := executor.Description{
+		Name:      res.GetName(),
+		Version:   res.GetVersion(),
+		Executors: make([]executor.ExecutorDescription, len(res.GetExecutors())),
+	}
+	for idx, exec := range res.GetExecutors() {
+		desc.Executors[idx] = executor.ExecutorDescription{
+			Name:   exec.GetName(),
+			Schema: exec.GetCueSchema(),
+		}
+	}
+
+	return desc, nil
+}
@@ -7,6 +7,6 @@ import "google.golang.org/grpc/codes"
 
 const (
 	// CodeExecErr indicates that no infrastructural errors occurred.
-	// The underlying error message should be passed through unwrapped.
+	// The underlying error is attached as an ExecutionError detail, and should be passed through unwrapped.
 	CodeExecErr codes.Code = 100
 )
@@ -0,0 +1,141 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/status"
+
+	cueerrors "cuelang.org/go/cue/errors"
+
+	bonkv0 "go.bonk.build/api/bonk/v0"
+	"go.bonk.build/pkg/executor"
+)
+
+// maxErrorDepth limits how deep an error tree is encoded, to protect against cyclic errors.
+const maxErrorDepth = 32
+
+// executionErrorStatus creates a [CodeExecErr] status with err's structure attached as a detail.
+func executionErrorStatus(err error) error {
+	st := status.New(CodeExecErr, err.Error())
+
+	detailed, detailErr := st.WithDetails(ToProtoError(err))
+	if detailErr != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
+
+// executionErrorFromStatus reconstructs the error encoded by [executionErrorStatus].
+func executionErrorFromStatus(st *status.Status) error {
+	for _, detail := range st.Details() {
+		if protoErr, ok := detail.(*bonkv0.ExecutionError); ok {
+			return FromProtoError(protoErr)
+		}
+	}
+
+	// The server didn't attach any details, so only the message is known
+	return errors.New(st.Message())
+}
+
+// ToProtoError encodes err and its tree of causes into an [bonkv0.ExecutionError].
+func ToProtoError(err error) *bonkv0.ExecutionError {
+	return toProtoError(err, 0)
+}
+
+func toProtoError(err error, depth int) *bonkv0.ExecutionError {
+	builder := bonkv0.ExecutionError_builder{
+		Kind:    new(string(errorKind(err))),
+		Message: new(err.Error()),
+	}
+
+	//nolint:errorlint // Only this level of the tree is inspected, causes are encoded below
+	switch typed := err.(type) {
+	case *executor.Error:
+		builder.Retryable = &typed.Retryable
+		for _, pos := range typed.Positions {
+			builder.Positions = append(builder.Positions, bonkv0.ExecutionError_Position_builder{
+				Filename: &pos.Filename,
+				Line:     new(int64(pos.Line)),
+				Column:   new(int64(pos.Column)),
+			}.Build())
+		}
+
+	case cueerrors.Error:
+		for _, pos := range cueerrors.Positions(typed) {
+			builder.Positions = append(builder.Positions, bonkv0.ExecutionError_Position_builder{
+				Filename: new(pos.Filename()),
+				Line:     new(int64(pos.Line())),
+				Column:   new(int64(pos.Column())),
+			}.Build())
+		}
+	}
+
+	if depth >= maxErrorDepth {
+		return builder.Build()
+	}
+
+	//nolint:errorlint // The tree is walked manually
+	switch wrapper := err.(type) {
+	case interface{ Unwrap() error }:
+		if cause := wrapper.Unwrap(); cause != nil {
+			builder.Causes = append(builder.Causes, toProtoError(cause, depth+1))
+		}
+
+	case interface{ Unwrap() []error }:
+		for _, cause := range wrapper.Unwrap() {
+			if cause != nil {
+				builder.Causes = append(builder.Causes, toProtoError(cause, depth+1))
+			}
+		}
+	}
+
+	return builder.Build()
+}
+
+// FromProtoError reconstructs an error encoded by [ToProtoError].
+func FromProtoError(protoErr *bonkv0.ExecutionError) *executor.Error {
+	result := &executor.Error{
+		Kind:      executor.ErrorKind(protoErr.GetKind()),
+		Message:   protoErr.GetMessage(),
+		Retryable: protoErr.GetRetryable(),
+	}
+
+	for _, pos := range protoErr.GetPositions() {
+		result.Positions = append(result.Positions, executor.Position{
+			Filename: pos.GetFilename(),
+			Line:     int(pos.GetLine()),
+			Column:   int(pos.GetColumn()),
+		})
+	}
+
+	for _, cause := range protoErr.GetCauses() {
+		result.Causes = append(result.Causes, FromProtoError(cause))
+	}
+
+	return result
+}
+
+// errorKind classifies err without looking at its causes, as they are encoded separately.
+func errorKind(err error) executor.ErrorKind {
+	//nolint:errorlint // Only this level of the tree is inspected
+	switch typed := err.(type) {
+	case *executor.Error:
+		return typed.Kind
+	case cueerrors.Error:
+		return executor.KindCUE
+	}
+
+	switch err { //nolint:errorlint // Only this level of the tree is inspected
+	case context.Canceled:
+		return executor.KindCanceled
+	case context.DeadlineExceeded:
+		return executor.KindDeadlineExceeded
+	default:
+		return executor.KindUnknown
+	}
+}
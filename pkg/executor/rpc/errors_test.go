@@ -0,0 +1,125 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package rpc_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+
+	"go.bonk.build/pkg/executor"
+	"go.bonk.build/pkg/executor/rpc"
+	"go.bonk.build/pkg/task"
+)
+
+func TestProtoError(t *testing.T) {
+	t.Parallel()
+
+	cause := executor.Retryable(executor.NewError(executor.KindInvalidArgument, context.DeadlineExceeded))
+	err := fmt.Errorf("outer: %w", cause)
+
+	decoded := rpc.FromProtoError(rpc.ToProtoError(err))
+
+	assert.Equal(t, err.Error(), decoded.Error())
+	require.ErrorIs(t, decoded, context.DeadlineExceeded)
+	require.ErrorIs(t, decoded, &executor.Error{Kind: executor.KindInvalidArgument})
+	require.NotErrorIs(t, decoded, context.Canceled)
+	assert.True(t, executor.IsRetryable(decoded))
+	assert.Equal(t, executor.KindInvalidArgument, executor.KindOf(decoded))
+}
+
+func TestProtoError_CUE(t *testing.T) {
+	t.Parallel()
+
+	value := cuecontext.New().CompileString("a: int\na: \"string\"", cue.Filename("test.cue"))
+	require.Error(t, value.Err())
+
+	decoded := rpc.FromProtoError(rpc.ToProtoError(fmt.Errorf("evaluating: %w", value.Err())))
+
+	assert.Equal(t, executor.KindCUE, executor.KindOf(decoded))
+
+	var cueErr *executor.Error
+	require.ErrorAs(t, decoded.Causes[0], &cueErr)
+	require.NotEmpty(t, cueErr.Positions)
+	assert.Equal(t, "test.cue", cueErr.Positions[0].Filename)
+	assert.Positive(t, cueErr.Positions[0].Line)
+}
+
+func TestExecuteError(t *testing.T) {
+	t.Parallel()
+
+	suite := rpcSuite{}
+	suite.SetupTest(t)
+	defer suite.AfterTest(t)
+
+	suite.exec.EXPECT().OpenSession(mock.Anything, mock.Anything).Return(nil)
+	suite.exec.EXPECT().CloseSession(mock.Anything, suite.session.ID())
+
+	err := suite.grpcClient.OpenSession(t.Context(), suite.session)
+	require.NoError(t, err)
+	defer suite.grpcClient.CloseSession(t.Context(), suite.session.ID())
+
+	suite.exec.EXPECT().Execute(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(fmt.Errorf("failed: %w", executor.Retryable(context.Canceled)))
+
+	err = suite.grpcClient.Execute(t.Context(), suite.session, task.New(
+		task.NewID("test", "task"),
+		"test.exec",
+		nil,
+	), &task.Result{})
+
+	require.ErrorIs(t, err, context.Canceled)
+	require.EqualError(t, err, "failed: context canceled")
+	assert.True(t, executor.IsRetryable(err))
+}
+
+func TestExecuteCanceled(t *testing.T) {
+	t.Parallel()
+
+	suite := rpcSuite{}
+	suite.SetupTest(t)
+	defer suite.AfterTest(t)
+
+	suite.exec.EXPECT().OpenSession(mock.Anything, mock.Anything).Return(nil)
+	suite.exec.EXPECT().CloseSession(mock.Anything, suite.session.ID())
+
+	err := suite.grpcClient.OpenSession(t.Context(), suite.session)
+	require.NoError(t, err)
+	defer suite.grpcClient.CloseSession(t.Context(), suite.session.ID())
+
+	ctx, cancel := context.WithCancel(t.Context())
+	started := make(chan struct{})
+	stopped := false
+
+	suite.exec.EXPECT().Execute(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, _ task.Session, _ *task.Task, _ *task.Result) error {
+			close(started)
+			<-ctx.Done()
+			stopped = true
+
+			return ctx.Err()
+		})
+
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	err = suite.grpcClient.Execute(ctx, suite.session, task.New(
+		task.NewID("test", "task"),
+		"test.exec",
+		nil,
+	), &task.Result{})
+
+	require.ErrorIs(t, err, context.Canceled)
+	// The call should only return once the executor has stopped
+	assert.True(t, stopped)
+}
@@ -9,17 +9,21 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"golang.org/x/sync/errgroup"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	bonkv0 "go.bonk.build/api/bonk/v0"
 	"go.bonk.build/pkg/executor"
 	"go.bonk.build/pkg/executor/argconv"
 	"go.bonk.build/pkg/executor/mockexec"
@@ -35,6 +39,7 @@ type rpcSuite struct {
 	exec         *mockexec.MockExecutor
 	grpcServer   *grpc.Server
 	grpcClient   executor.Executor
+	grpcConn     *grpc.ClientConn
 	session      task.Session
 	serverWaiter errgroup.Group
 }
@@ -63,6 +68,7 @@ func (s *rpcSuite) SetupTest(t *testing.T) {
 	require.NoError(t, err)
 
 	s.grpcClient = rpc.NewGRPCClient(clientConn)
+	s.grpcConn = clientConn
 
 	s.session = task.NewTestSession()
 }
@@ -86,8 +92,8 @@ func (s *rpcSuite) Test_Connection(t *testing.T) {
 func (s *rpcSuite) Test_Session(t *testing.T) {
 	t.Parallel()
 
-	s.exec.EXPECT().OpenSession(mock.Anything, mock.Anything)
-	s.exec.EXPECT().CloseSession(mock.Anything, s.session.ID())
+	s.exec.EXPECT().OpenSession(mock.Anything, mock.Anything).Return(nil)
+	s.exec.EXPECT().CloseSession(mock.Anything, s.session.ID()).Return()
 
 	err := s.grpcClient.OpenSession(t.Context(), s.session)
 	require.NoError(t, err)
@@ -103,13 +109,30 @@ func (s *rpcSuite) Test_Session_Fail(t *testing.T) {
 	require.ErrorContains(t, err, assert.AnError.Error())
 }
 
+func (s *rpcSuite) Test_InvalidSessionID(t *testing.T) {
+	t.Parallel()
+
+	client := bonkv0.NewExecutorServiceClient(s.grpcConn)
+
+	_, err := client.ExecuteTask(t.Context(), bonkv0.ExecuteTaskRequest_builder{
+		SessionId: new("not a session"),
+	}.Build())
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.CloseSession(t.Context(), bonkv0.CloseSessionRequest_builder{Id: new("")}.Build())
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.CancelTask(t.Context(), bonkv0.CancelTaskRequest_builder{SessionId: new("42")}.Build())
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
 func (s *rpcSuite) Test_Args(t *testing.T) {
 	t.Parallel()
 
 	var result task.Result
 
-	s.exec.EXPECT().OpenSession(mock.Anything, mock.Anything)
-	s.exec.EXPECT().CloseSession(mock.Anything, s.session.ID())
+	s.exec.EXPECT().OpenSession(mock.Anything, mock.Anything).Return(nil)
+	s.exec.EXPECT().CloseSession(mock.Anything, s.session.ID()).Return()
 
 	err := s.grpcClient.OpenSession(t.Context(), s.session)
 	require.NoError(t, err)
@@ -132,8 +155,8 @@ func (s *rpcSuite) Test_Followups(t *testing.T) {
 
 	var result task.Result
 
-	s.exec.EXPECT().OpenSession(mock.Anything, mock.Anything)
-	s.exec.EXPECT().CloseSession(mock.Anything, s.session.ID())
+	s.exec.EXPECT().OpenSession(mock.Anything, mock.Anything).Return(nil)
+	s.exec.EXPECT().CloseSession(mock.Anything, s.session.ID()).Return()
 
 	err := s.grpcClient.OpenSession(t.Context(), s.session)
 	require.NoError(t, err)
@@ -149,6 +172,12 @@ func (s *rpcSuite) Test_Followups(t *testing.T) {
 			"File1.txt",
 			"File2.txt",
 		),
+		task.WithDependencies("Test.Other"),
+		task.WithResources(task.Resources{"cpu": 2}),
+		task.WithTimeout(time.Minute),
+		task.WithRetry(task.RetryPolicy{MaxAttempts: 2, Backoff: time.Second}),
+		task.WithMatrix(task.Matrix{"env": {"dev", "prod"}}),
+		task.WithCondition(task.Condition{Profiles: []string{"ci"}}),
 	)
 
 	s.exec.EXPECT().Execute(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
@@ -172,12 +201,17 @@ func (s *rpcSuite) Test_Followups(t *testing.T) {
 
 	require.NoError(t, err)
 	assert.EqualExportedValues(t, expectedTask.Args, *unboxed)
+
+	// Everything else about the followup is kept too
+	followup := *result.GetFollowupTasks()[0]
+	followup.Args = expectedTask.Args
+	assert.Equal(t, *expectedTask, followup)
 }
 
 func TestRPC(t *testing.T) {
 	t.Parallel()
 
-	suiteT := reflect.TypeFor[rpcSuite]()
+	suiteT := reflect.TypeFor[*rpcSuite]()
 
 	for method := range suiteT.Methods() { //nolint:paralleltest
 		if !strings.HasPrefix(method.Name, "Test") {
@@ -191,6 +225,7 @@ func TestRPC(t *testing.T) {
 
 			method.Func.Call([]reflect.Value{
 				reflect.ValueOf(&suite),
+				reflect.ValueOf(t),
 			})
 
 			suite.AfterTest(t)
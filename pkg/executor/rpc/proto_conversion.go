@@ -7,9 +7,13 @@ import (
 	"fmt"
 	"reflect"
 
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/structpb"
 
 	"github.com/go-viper/mapstructure/v2"
+
+	bonkv0 "go.bonk.build/api/bonk/v0"
+	"go.bonk.build/pkg/task"
 )
 
 // ToProtoValue wraps any value into a [structpb.Value].
@@ -70,3 +74,161 @@ func toProtoValue(value reflect.Value) (*structpb.Value, error) {
 		return structpb.NewValue(value.Interface())
 	}
 }
+
+// toProtoBuildTask encodes a task submitted to a build server, or returned by an executor as a followup.
+func toProtoBuildTask(tsk *task.Task) (*bonkv0.BuildTask, error) {
+	args, err := ToProtoValue(tsk.Args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode args of task %s: %w", tsk.ID, err)
+	}
+
+	dependencies := make([]string, len(tsk.Dependencies))
+	for idx, dependency := range tsk.Dependencies {
+		dependencies[idx] = dependency.String()
+	}
+
+	resources := make(map[string]int64, len(tsk.Resources))
+	for name, amount := range tsk.Resources {
+		resources[name] = int64(amount)
+	}
+
+	var matrix map[string]*bonkv0.MatrixValues
+	if len(tsk.Matrix) > 0 {
+		matrix = make(map[string]*bonkv0.MatrixValues, len(tsk.Matrix))
+		for name, values := range tsk.Matrix {
+			matrix[name] = bonkv0.MatrixValues_builder{Values: values}.Build()
+		}
+	}
+
+	builder := bonkv0.BuildTask_builder{
+		Id:           new(tsk.ID.String()),
+		Executor:     &tsk.Executor,
+		Inputs:       tsk.Inputs,
+		Arguments:    args,
+		Dependencies: dependencies,
+		Resources:    resources,
+		Matrix:       matrix,
+	}
+	if tsk.Timeout > 0 {
+		builder.Timeout = durationpb.New(tsk.Timeout)
+	}
+	if tsk.When != nil {
+		builder.When = toProtoCondition(tsk.When)
+	}
+	if tsk.Retry != nil {
+		builder.Retry = bonkv0.RetryPolicy_builder{
+			MaxAttempts: new(int64(tsk.Retry.MaxAttempts)),
+			Backoff:     durationpb.New(tsk.Retry.Backoff),
+			MaxBackoff:  durationpb.New(tsk.Retry.MaxBackoff),
+			RetryOn:     tsk.Retry.RetryOn,
+		}.Build()
+	}
+
+	return builder.Build(), nil
+}
+
+// fromProtoBuildTask decodes a task encoded by [toProtoBuildTask].
+func fromProtoBuildTask(protoTask *bonkv0.BuildTask) *task.Task {
+	dependencies := make([]task.ID, len(protoTask.GetDependencies()))
+	for idx, dependency := range protoTask.GetDependencies() {
+		dependencies[idx] = task.ID(dependency)
+	}
+
+	tsk := task.New(
+		task.ID(protoTask.GetId()),
+		protoTask.GetExecutor(),
+		protoTask.GetArguments().AsInterface(),
+		task.WithInputs(protoTask.GetInputs()...),
+		task.WithDependencies(dependencies...),
+	)
+
+	if len(protoTask.GetResources()) > 0 {
+		tsk.Resources = make(task.Resources, len(protoTask.GetResources()))
+		for name, amount := range protoTask.GetResources() {
+			tsk.Resources[name] = int(amount)
+		}
+	}
+
+	if len(protoTask.GetMatrix()) > 0 {
+		tsk.Matrix = make(task.Matrix, len(protoTask.GetMatrix()))
+		for name, values := range protoTask.GetMatrix() {
+			tsk.Matrix[name] = values.GetValues()
+		}
+	}
+
+	if protoTask.HasWhen() {
+		tsk.When = fromProtoCondition(protoTask.GetWhen())
+	}
+
+	if protoTask.HasTimeout() {
+		tsk.Timeout = protoTask.GetTimeout().AsDuration()
+	}
+	if protoTask.HasRetry() {
+		retry := protoTask.GetRetry()
+		tsk.Retry = &task.RetryPolicy{
+			MaxAttempts: int(retry.GetMaxAttempts()),
+			Backoff:     retry.GetBackoff().AsDuration(),
+			MaxBackoff:  retry.GetMaxBackoff().AsDuration(),
+			RetryOn:     retry.GetRetryOn(),
+		}
+	}
+
+	return tsk
+}
+
+// toProtoCondition encodes the condition of a task submitted to a build server.
+func toProtoCondition(condition *task.Condition) *bonkv0.Condition {
+	results := make(map[string]string, len(condition.Results))
+	for id, outcome := range condition.Results {
+		results[id.String()] = string(outcome)
+	}
+
+	outputs := make(map[string]*bonkv0.OutputPatterns, len(condition.Outputs))
+	for id, patterns := range condition.Outputs {
+		outputs[id.String()] = bonkv0.OutputPatterns_builder{Patterns: patterns}.Build()
+	}
+
+	builder := bonkv0.Condition_builder{
+		Profiles:    condition.Profiles,
+		Env:         condition.Env,
+		Exists:      condition.Exists,
+		InputsExist: &condition.InputsExist,
+		Results:     results,
+		Outputs:     outputs,
+	}
+	if condition.Not != nil {
+		builder.Not = toProtoCondition(condition.Not)
+	}
+
+	return builder.Build()
+}
+
+// fromProtoCondition decodes a condition encoded by [toProtoCondition].
+func fromProtoCondition(protoCondition *bonkv0.Condition) *task.Condition {
+	condition := &task.Condition{
+		Profiles:    protoCondition.GetProfiles(),
+		Env:         protoCondition.GetEnv(),
+		Exists:      protoCondition.GetExists(),
+		InputsExist: protoCondition.GetInputsExist(),
+	}
+
+	if len(protoCondition.GetResults()) > 0 {
+		condition.Results = make(map[task.ID]task.Outcome, len(protoCondition.GetResults()))
+		for id, outcome := range protoCondition.GetResults() {
+			condition.Results[task.ID(id)] = task.Outcome(outcome)
+		}
+	}
+
+	if len(protoCondition.GetOutputs()) > 0 {
+		condition.Outputs = make(map[task.ID][]string, len(protoCondition.GetOutputs()))
+		for id, patterns := range protoCondition.GetOutputs() {
+			condition.Outputs[task.ID(id)] = patterns.GetPatterns()
+		}
+	}
+
+	if protoCondition.HasNot() {
+		condition.Not = fromProtoCondition(protoCondition.GetNot())
+	}
+
+	return condition
+}
@@ -19,6 +19,7 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/google/uuid"
+	"github.com/spf13/afero"
 
 	slogmulti "github.com/samber/slog-multi"
 	slogctx "github.com/veqryn/slog-context"
@@ -50,6 +51,20 @@ type grpcServer struct {
 
 	sessions   map[task.SessionID]grpcServerSession
 	sessionsMu sync.RWMutex
+
+	running   map[runningTaskKey]context.CancelFunc
+	runningMu sync.Mutex
+
+	// workspaces are the remote workspaces attached to sessions, which are shared by their cached content
+	workspaces   map[task.SessionID]*workspaceConn
+	workspacesMu sync.Mutex
+	contents     *contentCache
+}
+
+// runningTaskKey identifies a task being executed, so that it may be canceled.
+type runningTaskKey struct {
+	session task.SessionID
+	id      task.ID
 }
 
 var _ bonkv0.ExecutorServiceServer = (*grpcServer)(nil)
@@ -60,8 +75,11 @@ func RegisterGRPCServer(
 	executor executor.Executor,
 ) {
 	bonkv0.RegisterExecutorServiceServer(server, &grpcServer{
-		executor: executor,
-		sessions: make(map[task.SessionID]grpcServerSession),
+		executor:   executor,
+		sessions:   make(map[task.SessionID]grpcServerSession),
+		running:    make(map[runningTaskKey]context.CancelFunc),
+		workspaces: make(map[task.SessionID]*workspaceConn),
+		contents:   newContentCache(defaultContentCacheSize),
 	})
 }
 
@@ -72,13 +90,31 @@ func (s *grpcServer) OpenSession(
 	ctx := stream.Context()
 	slog.DebugContext(ctx, "opening session", "session", req.GetSessionId())
 
-	sessionID := uuid.MustParse(req.GetSessionId())
+	sessionID, err := parseSessionID(req.GetSessionId())
+	if err != nil {
+		return err
+	}
+
 	var session task.Session
 
 	switch req.WhichWorkspaceDescription() {
 	case bonkv0.OpenSessionRequest_Local_case:
 		session = task.NewLocalSession(sessionID, req.GetLocal().GetAbsolutePath())
 
+	case bonkv0.OpenSessionRequest_Remote_case:
+		s.workspacesMu.Lock()
+		conn, ok := s.workspaces[sessionID]
+		s.workspacesMu.Unlock()
+		if !ok {
+			return status.Errorf(codes.FailedPrecondition, "no workspace attached to session %s", sessionID)
+		}
+
+		session = &task.DefaultSession{
+			Id:       sessionID,
+			SourceFs: afero.NewReadOnlyFs(newRemoteFs(conn, bonkv0.WorkspaceCall_ROOT_SOURCE)),
+			OutputFs: newRemoteFs(conn, bonkv0.WorkspaceCall_ROOT_OUTPUT),
+		}
+
 	case bonkv0.OpenSessionRequest_Test_case:
 		session = task.NewTestSession()
 
@@ -143,7 +179,7 @@ func (s *grpcServer) OpenSession(
 		)
 		ctx = slogctx.NewCtx(ctx, logger)
 	}
-	err := s.executor.OpenSession(ctx, session)
+	err = s.executor.OpenSession(ctx, session)
 	if err != nil {
 		return err
 	}
@@ -179,7 +215,10 @@ func (s *grpcServer) CloseSession(
 	req *bonkv0.CloseSessionRequest,
 ) (*bonkv0.CloseSessionResponse, error) {
 	// Find the relevant session
-	sessionID := uuid.MustParse(req.GetId())
+	sessionID, err := parseSessionID(req.GetId())
+	if err != nil {
+		return nil, err
+	}
 
 	s.sessionsMu.RLock()
 	session, ok := s.sessions[sessionID]
@@ -209,7 +248,10 @@ func (s *grpcServer) ExecuteTask(
 	req *bonkv0.ExecuteTaskRequest,
 ) (*bonkv0.ExecuteTaskResponse, error) {
 	// Find the relevant session
-	sessionID := uuid.MustParse(req.GetSessionId())
+	sessionID, err := parseSessionID(req.GetSessionId())
+	if err != nil {
+		return nil, err
+	}
 
 	s.sessionsMu.RLock()
 	session, ok := s.sessions[sessionID]
@@ -224,6 +266,24 @@ func (s *grpcServer) ExecuteTask(
 
 	ctx = slogctx.NewCtx(ctx, session.logger)
 
+	// Register the task so that it may be canceled by CancelTask
+	runningKey := runningTaskKey{
+		session: sessionID,
+		id:      task.ID(req.GetId()),
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	s.runningMu.Lock()
+	s.running[runningKey] = cancel
+	s.runningMu.Unlock()
+
+	defer func() {
+		s.runningMu.Lock()
+		delete(s.running, runningKey)
+		s.runningMu.Unlock()
+
+		cancel()
+	}()
+
 	tsk := task.Task{
 		ID:       task.ID(req.GetId()),
 		Executor: req.GetExecutor(),
@@ -233,7 +293,7 @@ func (s *grpcServer) ExecuteTask(
 
 	taskOutputFs := task.OutputFS(session.Session, tsk.ID)
 
-	err := taskOutputFs.MkdirAll("", 0o750)
+	err = taskOutputFs.MkdirAll("", 0o750)
 	if err != nil {
 		return nil, status.Errorf(
 			codes.Unknown,
@@ -244,31 +304,133 @@ func (s *grpcServer) ExecuteTask(
 	var response task.Result
 	err = s.executor.Execute(ctx, session, &tsk, &response)
 	if err != nil {
-		return nil, status.Error(CodeExecErr, err.Error())
+		return nil, executionErrorStatus(err)
 	}
 
 	followups := response.GetFollowupTasks()
 	res := bonkv0.ExecuteTaskResponse_builder{
 		Output:        response.GetOutputs(),
-		FollowupTasks: make([]*bonkv0.ExecuteTaskResponse_FollowupTask, len(followups)),
+		FollowupTasks: make([]*bonkv0.BuildTask, len(followups)),
 	}
 
 	for idx, followup := range followups {
-		taskProto := bonkv0.ExecuteTaskResponse_FollowupTask_builder{
-			Id:       (*string)(&followup.ID),
-			Executor: &followup.Executor,
-			Inputs:   followup.Inputs,
-		}
+		var encodeErr error
+		res.FollowupTasks[idx], encodeErr = toProtoBuildTask(followup)
+		multierr.AppendInto(&err, encodeErr)
+	}
 
-		var newValErr error
-		taskProto.Arguments, newValErr = ToProtoValue(followup.Args)
+	return res.Build(), err
+}
 
-		if multierr.AppendInto(&err, newValErr) {
-			continue
+// CancelTask implements v0.ExecutorServiceServer.
+func (s *grpcServer) CancelTask(
+	ctx context.Context,
+	req *bonkv0.CancelTaskRequest,
+) (*bonkv0.CancelTaskResponse, error) {
+	sessionID, err := parseSessionID(req.GetSessionId())
+	if err != nil {
+		return nil, err
+	}
+
+	key := runningTaskKey{
+		session: sessionID,
+		id:      task.ID(req.GetId()),
+	}
+
+	s.runningMu.Lock()
+	cancel, ok := s.running[key]
+	s.runningMu.Unlock()
+
+	if ok {
+		slog.DebugContext(ctx, "canceling task", "session", key.session, "task", key.id)
+		cancel()
+	}
+
+	return bonkv0.CancelTaskResponse_builder{
+		Canceled: &ok,
+	}.Build(), nil
+}
+
+// Workspace implements v0.ExecutorServiceServer.
+func (s *grpcServer) Workspace(stream grpc.BidiStreamingServer[bonkv0.WorkspaceReply, bonkv0.WorkspaceCall]) error {
+	ctx := stream.Context()
+
+	attach, err := stream.Recv()
+	if err != nil {
+		return fmt.Errorf("failed to receive workspace attach: %w", err)
+	}
+	if attach.WhichReply() != bonkv0.WorkspaceReply_Attach_case {
+		return status.Error(codes.InvalidArgument, "expected workspace attach, received other message")
+	}
+
+	sessionID, err := parseSessionID(attach.GetAttach().GetSessionId())
+	if err != nil {
+		return err
+	}
+
+	conn := newWorkspaceConn(stream, s.contents)
+
+	s.workspacesMu.Lock()
+	s.workspaces[sessionID] = conn
+	s.workspacesMu.Unlock()
+
+	defer func() {
+		s.workspacesMu.Lock()
+		if s.workspaces[sessionID] == conn {
+			delete(s.workspaces, sessionID)
 		}
+		s.workspacesMu.Unlock()
+	}()
 
-		res.FollowupTasks[idx] = taskProto.Build()
+	err = conn.send(bonkv0.WorkspaceCall_builder{
+		Ack: &bonkv0.WorkspaceCall_Ack{},
+	}.Build())
+	if err != nil {
+		return fmt.Errorf("failed to send workspace ack: %w", err)
 	}
 
-	return res.Build(), err
+	slog.DebugContext(ctx, "attached remote workspace", "session", sessionID)
+
+	// Serve calls until the client stops serving the workspace
+	return conn.receive(ctx)
+}
+
+// Describe implements v0.ExecutorServiceServer.
+func (s *grpcServer) Describe(
+	ctx context.Context,
+	_ *bonkv0.DescribeRequest,
+) (*bonkv0.DescribeResponse, error) {
+	describer, ok := s.executor.(executor.Describer)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "executor does not support describe")
+	}
+
+	desc, err := describer.Describe(ctx)
+	if err != nil {
+		return nil, executionErrorStatus(err)
+	}
+
+	executors := make([]*bonkv0.DescribeResponse_Executor, len(desc.Executors))
+	for idx, exec := range desc.Executors {
+		executors[idx] = bonkv0.DescribeResponse_Executor_builder{
+			Name:      &exec.Name,
+			CueSchema: &exec.Schema,
+		}.Build()
+	}
+
+	return bonkv0.DescribeResponse_builder{
+		Name:      &desc.Name,
+		Version:   &desc.Version,
+		Executors: executors,
+	}.Build(), nil
+}
+
+// parseSessionID parses a session ID sent by a client, returning an InvalidArgument status if it's malformed.
+func parseSessionID(id string) (uuid.UUID, error) {
+	sessionID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.UUID{}, status.Errorf(codes.InvalidArgument, "invalid session id %q: %s", id, err)
+	}
+
+	return sessionID, nil
 }
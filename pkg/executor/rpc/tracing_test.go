@@ -0,0 +1,80 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package rpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"go.bonk.build/pkg/executor/mockexec"
+	"go.bonk.build/pkg/executor/rpc"
+	"go.bonk.build/pkg/task"
+)
+
+func TestTracePropagation(t *testing.T) { //nolint:paralleltest // modifies the global tracer provider
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	exec := mockexec.NewMockExecutor(t)
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(rpc.ServerOptions()...)
+	rpc.RegisterGRPCServer(server, exec)
+
+	go func() {
+		_ = server.Serve(lis)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		append(rpc.DialOptions(),
+			grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+				return lis.Dial()
+			}),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)...,
+	)
+	require.NoError(t, err)
+
+	client := rpc.NewGRPCClient(conn)
+	session := task.NewTestSession()
+
+	ctx, hostSpan := otel.Tracer("test").Start(t.Context(), "host")
+
+	var pluginSpan trace.SpanContext
+
+	exec.EXPECT().OpenSession(mock.Anything, mock.Anything).Return(nil)
+	exec.EXPECT().CloseSession(mock.Anything, session.ID())
+	exec.EXPECT().Execute(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, _ task.Session, _ *task.Task, _ *task.Result) {
+			pluginSpan = trace.SpanContextFromContext(ctx)
+		}).
+		Return(nil)
+
+	require.NoError(t, client.OpenSession(ctx, session))
+	require.NoError(t, client.Execute(ctx, session, task.New("test", "exec", nil), &task.Result{}))
+	client.CloseSession(ctx, session.ID())
+	hostSpan.End()
+
+	// The server-side span should be part of the host's trace
+	require.True(t, pluginSpan.IsValid())
+	assert.Equal(t, hostSpan.SpanContext().TraceID(), pluginSpan.TraceID())
+	assert.NotEqual(t, hostSpan.SpanContext().SpanID(), pluginSpan.SpanID())
+}
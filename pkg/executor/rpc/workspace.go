@@ -0,0 +1,400 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package rpc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.uber.org/multierr"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/spf13/afero"
+
+	bonkv0 "go.bonk.build/api/bonk/v0"
+	"go.bonk.build/pkg/task"
+)
+
+// workspaceChunkSize is the most file content sent in a single workspace message.
+const workspaceChunkSize = 1 << 20
+
+var errUnknownRoot = errors.New("unknown workspace root")
+
+// attachWorkspace opens a Workspace stream serving session's files, which lasts until ctx is canceled.
+func (pb *grpcClient) attachWorkspace(ctx context.Context, session task.Session) error {
+	stream, err := pb.client.Workspace(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to open workspace stream: %w", err)
+	}
+
+	err = stream.Send(bonkv0.WorkspaceReply_builder{
+		Attach: bonkv0.WorkspaceReply_Attach_builder{
+			SessionId: new(session.ID().String()),
+		}.Build(),
+	}.Build())
+	if err != nil {
+		return fmt.Errorf("failed to attach workspace: %w", err)
+	}
+
+	// Wait for ack message
+	call, err := stream.Recv()
+	if err != nil {
+		return fmt.Errorf("error receiving workspace ack: %w", err)
+	}
+	if call.WhichCall() != bonkv0.WorkspaceCall_Ack_case {
+		return errors.New("expected workspace ack, received other message")
+	}
+
+	server := &workspaceServer{
+		stream:  stream,
+		session: session,
+		digests: make(map[digestKey]string),
+	}
+	go server.serve(ctx)
+
+	return nil
+}
+
+// workspaceServer replies to the calls an executor sends to access a session's files.
+type workspaceServer struct {
+	stream  grpc.BidiStreamingClient[bonkv0.WorkspaceReply, bonkv0.WorkspaceCall]
+	session task.Session
+
+	sendMu sync.Mutex
+
+	// digests caches the digests of unchanged files, which are otherwise computed on every stat
+	digests   map[digestKey]string
+	digestsMu sync.Mutex
+}
+
+type digestKey struct {
+	root    bonkv0.WorkspaceCall_Root
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// serve replies to calls until the stream ends, handling each call concurrently.
+func (ws *workspaceServer) serve(ctx context.Context) {
+	for {
+		call, err := ws.stream.Recv()
+		if err != nil {
+			if !errors.Is(err, io.EOF) && ctx.Err() == nil {
+				slog.DebugContext(ctx, "workspace stream failed", "session", ws.session.ID(), "error", err)
+			}
+
+			return
+		}
+
+		go func() {
+			err := ws.handle(call)
+			if err != nil {
+				err = ws.send(call.GetId(), bonkv0.WorkspaceReply_builder{Error: toProtoFileError(err)})
+			}
+			if err != nil {
+				slog.DebugContext(ctx, "failed to reply to workspace call", "session", ws.session.ID(), "error", err)
+			}
+		}()
+	}
+}
+
+// handle replies to call, returning the error to reply with if it fails.
+func (ws *workspaceServer) handle(call *bonkv0.WorkspaceCall) error {
+	switch call.WhichCall() {
+	case bonkv0.WorkspaceCall_Stat_case:
+		return ws.stat(call.GetId(), call.GetStat())
+
+	case bonkv0.WorkspaceCall_ReadDir_case:
+		fsys, err := ws.root(call.GetReadDir().GetRoot())
+		if err != nil {
+			return err
+		}
+
+		infos, err := afero.ReadDir(fsys, call.GetReadDir().GetPath())
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		entries := make([]*bonkv0.WorkspaceReply_FileInfo, len(infos))
+		for idx, info := range infos {
+			entries[idx] = toProtoFileInfo(info, "")
+		}
+
+		return ws.send(call.GetId(), bonkv0.WorkspaceReply_builder{
+			Entries: bonkv0.WorkspaceReply_DirEntries_builder{
+				Entries: entries,
+			}.Build(),
+		})
+
+	case bonkv0.WorkspaceCall_ReadFile_case:
+		return ws.readFile(call.GetId(), call.GetReadFile())
+
+	case bonkv0.WorkspaceCall_WriteFile_case:
+		return ws.writeFile(call.GetId(), call.GetWriteFile())
+
+	case bonkv0.WorkspaceCall_Mkdir_case:
+		mkdir := call.GetMkdir()
+		fsys, err := ws.root(mkdir.GetRoot())
+		if err != nil {
+			return err
+		}
+
+		if mkdir.GetAll() {
+			err = fsys.MkdirAll(mkdir.GetPath(), fs.FileMode(mkdir.GetMode()))
+		} else {
+			err = fsys.Mkdir(mkdir.GetPath(), fs.FileMode(mkdir.GetMode()))
+		}
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		return ws.sendDone(call.GetId())
+
+	case bonkv0.WorkspaceCall_Remove_case:
+		remove := call.GetRemove()
+		fsys, err := ws.root(remove.GetRoot())
+		if err != nil {
+			return err
+		}
+
+		if remove.GetAll() {
+			err = fsys.RemoveAll(remove.GetPath())
+		} else {
+			err = fsys.Remove(remove.GetPath())
+		}
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		return ws.sendDone(call.GetId())
+
+	case bonkv0.WorkspaceCall_Rename_case:
+		rename := call.GetRename()
+		fsys, err := ws.root(rename.GetRoot())
+		if err != nil {
+			return err
+		}
+
+		err = fsys.Rename(rename.GetOldPath(), rename.GetNewPath())
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		return ws.sendDone(call.GetId())
+
+	default:
+		return fmt.Errorf("unsupported workspace call %v", call.WhichCall())
+	}
+}
+
+func (ws *workspaceServer) stat(id int64, stat *bonkv0.WorkspaceCall_Stat) error {
+	fsys, err := ws.root(stat.GetRoot())
+	if err != nil {
+		return err
+	}
+
+	info, err := fsys.Stat(stat.GetPath())
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	var digest string
+	if info.Mode().IsRegular() {
+		digest, err = ws.digest(stat.GetRoot(), fsys, stat.GetPath(), info)
+		if err != nil {
+			return err
+		}
+	}
+
+	return ws.send(id, bonkv0.WorkspaceReply_builder{
+		Info: toProtoFileInfo(info, digest),
+	})
+}
+
+// digest returns the digest of a regular file's content, which is cached until the file changes.
+func (ws *workspaceServer) digest(
+	root bonkv0.WorkspaceCall_Root,
+	fsys afero.Fs,
+	name string,
+	info fs.FileInfo,
+) (string, error) {
+	key := digestKey{
+		root:    root,
+		path:    name,
+		size:    info.Size(),
+		modTime: info.ModTime(),
+	}
+
+	ws.digestsMu.Lock()
+	digest, ok := ws.digests[key]
+	ws.digestsMu.Unlock()
+	if ok {
+		return digest, nil
+	}
+
+	file, err := fsys.Open(name)
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	_, err = io.Copy(hash, file)
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+	digest = hex.EncodeToString(hash.Sum(nil))
+
+	ws.digestsMu.Lock()
+	ws.digests[key] = digest
+	ws.digestsMu.Unlock()
+
+	return digest, nil
+}
+
+func (ws *workspaceServer) readFile(id int64, read *bonkv0.WorkspaceCall_ReadFile) error {
+	fsys, err := ws.root(read.GetRoot())
+	if err != nil {
+		return err
+	}
+
+	file, err := fsys.Open(read.GetPath())
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	defer file.Close()
+
+	buf := make([]byte, workspaceChunkSize)
+	for {
+		n, err := io.ReadFull(file, buf)
+		eof := errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+		if err != nil && !eof {
+			return err //nolint:wrapcheck
+		}
+
+		err = ws.send(id, bonkv0.WorkspaceReply_builder{
+			Content: bonkv0.WorkspaceReply_Content_builder{
+				Data: buf[:n],
+				Eof:  &eof,
+			}.Build(),
+		})
+		if err != nil || eof {
+			return err
+		}
+	}
+}
+
+func (ws *workspaceServer) writeFile(id int64, write *bonkv0.WorkspaceCall_WriteFile) error {
+	fsys, err := ws.root(write.GetRoot())
+	if err != nil {
+		return err
+	}
+
+	flag := syscall.O_WRONLY
+	if write.GetCreate() {
+		flag |= syscall.O_CREAT
+	}
+	if write.GetTruncate() {
+		flag |= syscall.O_TRUNC
+	}
+
+	file, err := fsys.OpenFile(write.GetPath(), flag, fs.FileMode(write.GetMode()))
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if len(write.GetData()) > 0 {
+		_, err = file.WriteAt(write.GetData(), write.GetOffset())
+	}
+	err = multierr.Combine(err, file.Close())
+	if err != nil {
+		return err
+	}
+
+	return ws.sendDone(id)
+}
+
+func (ws *workspaceServer) root(root bonkv0.WorkspaceCall_Root) (afero.Fs, error) {
+	switch root {
+	case bonkv0.WorkspaceCall_ROOT_SOURCE:
+		return ws.session.SourceFS(), nil
+	case bonkv0.WorkspaceCall_ROOT_OUTPUT:
+		return ws.session.OutputFS(), nil
+	default:
+		return nil, fmt.Errorf("%w: %v", errUnknownRoot, root)
+	}
+}
+
+func (ws *workspaceServer) sendDone(id int64) error {
+	return ws.send(id, bonkv0.WorkspaceReply_builder{
+		Done: &bonkv0.WorkspaceReply_Done{},
+	})
+}
+
+func (ws *workspaceServer) send(id int64, reply bonkv0.WorkspaceReply_builder) error {
+	reply.Id = &id
+
+	ws.sendMu.Lock()
+	defer ws.sendMu.Unlock()
+
+	err := ws.stream.Send(reply.Build())
+	if err != nil {
+		return fmt.Errorf("failed to send workspace reply: %w", err)
+	}
+
+	return nil
+}
+
+func toProtoFileInfo(info fs.FileInfo, digest string) *bonkv0.WorkspaceReply_FileInfo {
+	return bonkv0.WorkspaceReply_FileInfo_builder{
+		Name:    new(info.Name()),
+		Size:    new(info.Size()),
+		Mode:    new(uint32(info.Mode())),
+		ModTime: timestamppb.New(info.ModTime()),
+		Digest:  &digest,
+	}.Build()
+}
+
+func toProtoFileError(err error) *bonkv0.WorkspaceReply_Error {
+	kind := bonkv0.WorkspaceReply_Error_KIND_UNSPECIFIED
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		kind = bonkv0.WorkspaceReply_Error_KIND_NOT_EXIST
+	case errors.Is(err, fs.ErrExist):
+		kind = bonkv0.WorkspaceReply_Error_KIND_EXIST
+	case errors.Is(err, fs.ErrPermission):
+		kind = bonkv0.WorkspaceReply_Error_KIND_PERMISSION
+	}
+
+	return bonkv0.WorkspaceReply_Error_builder{
+		Kind:    &kind,
+		Message: new(err.Error()),
+	}.Build()
+}
+
+// fromProtoFileError reconstructs an error encoded by [toProtoFileError].
+// Known kinds are returned as their [fs] errors, so that they're recognized by os.IsNotExist and friends.
+func fromProtoFileError(protoErr *bonkv0.WorkspaceReply_Error) error {
+	switch protoErr.GetKind() {
+	case bonkv0.WorkspaceReply_Error_KIND_NOT_EXIST:
+		return fs.ErrNotExist
+	case bonkv0.WorkspaceReply_Error_KIND_EXIST:
+		return fs.ErrExist
+	case bonkv0.WorkspaceReply_Error_KIND_PERMISSION:
+		return fs.ErrPermission
+	default:
+		return errors.New(protoErr.GetMessage())
+	}
+}
@@ -0,0 +1,692 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/spf13/afero"
+
+	bonkv0 "go.bonk.build/api/bonk/v0"
+)
+
+// defaultContentCacheSize is the number of bytes of file content an executor keeps cached.
+const defaultContentCacheSize = 64 << 20
+
+// ErrWorkspaceClosed is returned when accessing a remote workspace whose stream has ended.
+var ErrWorkspaceClosed = errors.New("remote workspace closed")
+
+// workspaceConn is the executor's end of a Workspace stream,
+// which sends calls to the client serving a session's files and waits for their replies.
+type workspaceConn struct {
+	stream   grpc.BidiStreamingServer[bonkv0.WorkspaceReply, bonkv0.WorkspaceCall]
+	contents *contentCache
+
+	sendMu sync.Mutex
+	nextID atomic.Int64
+
+	pending   map[int64]chan *bonkv0.WorkspaceReply
+	pendingMu sync.Mutex
+
+	// done is closed once the stream ends, after which err is set
+	done chan struct{}
+	err  error
+}
+
+func newWorkspaceConn(
+	stream grpc.BidiStreamingServer[bonkv0.WorkspaceReply, bonkv0.WorkspaceCall],
+	contents *contentCache,
+) *workspaceConn {
+	return &workspaceConn{
+		stream:   stream,
+		contents: contents,
+		pending:  make(map[int64]chan *bonkv0.WorkspaceReply),
+		done:     make(chan struct{}),
+	}
+}
+
+// send sends call to the client, which may be done by many calls at once.
+func (c *workspaceConn) send(call *bonkv0.WorkspaceCall) error {
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+
+	return c.stream.Send(call) //nolint:wrapcheck
+}
+
+// receive dispatches replies to the calls waiting for them, until the stream ends.
+func (c *workspaceConn) receive(ctx context.Context) error {
+	var err error
+	defer func() {
+		c.err = err
+		close(c.done)
+	}()
+
+	for {
+		var reply *bonkv0.WorkspaceReply
+		reply, err = c.stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) || ctx.Err() != nil {
+				err = nil
+			}
+
+			return err //nolint:wrapcheck
+		}
+
+		c.pendingMu.Lock()
+		replies, ok := c.pending[reply.GetId()]
+		c.pendingMu.Unlock()
+		if !ok {
+			continue
+		}
+
+		select {
+		case replies <- reply:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// call sends call, passing its replies to handle until handle is done or the client replies with an error.
+func (c *workspaceConn) call(
+	call bonkv0.WorkspaceCall_builder,
+	handle func(reply *bonkv0.WorkspaceReply) (bool, error),
+) error {
+	replies := make(chan *bonkv0.WorkspaceReply, 1)
+
+	// The call is registered before it's sent, so that no replies are missed
+	id := c.nextID.Add(1)
+	call.Id = &id
+
+	c.pendingMu.Lock()
+	c.pending[id] = replies
+	c.pendingMu.Unlock()
+
+	defer func() {
+		c.pendingMu.Lock()
+		delete(c.pending, id)
+		c.pendingMu.Unlock()
+	}()
+
+	err := c.send(call.Build())
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrWorkspaceClosed, err)
+	}
+
+	for {
+		select {
+		case reply := <-replies:
+			if reply.WhichReply() == bonkv0.WorkspaceReply_Error_case {
+				return fromProtoFileError(reply.GetError())
+			}
+
+			done, err := handle(reply)
+			if err != nil || done {
+				return err
+			}
+
+		case <-c.done:
+			if c.err != nil {
+				return fmt.Errorf("%w: %w", ErrWorkspaceClosed, c.err)
+			}
+
+			return ErrWorkspaceClosed
+		}
+	}
+}
+
+// contentCache keeps the content of workspace files by digest, so that unchanged files aren't read twice.
+// Once full, the oldest content is evicted first.
+type contentCache struct {
+	entries map[string][]byte
+	order   []string
+	size    int
+	limit   int
+	mu      sync.Mutex
+}
+
+func newContentCache(limit int) *contentCache {
+	return &contentCache{
+		entries: make(map[string][]byte),
+		limit:   limit,
+	}
+}
+
+func (c *contentCache) get(digest string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, ok := c.entries[digest]
+
+	return data, ok
+}
+
+func (c *contentCache) put(digest string, data []byte) {
+	if len(data) > c.limit {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[digest]; ok {
+		return
+	}
+
+	for c.size+len(data) > c.limit {
+		evicted := c.order[0]
+		c.order = c.order[1:]
+		c.size -= len(c.entries[evicted])
+		delete(c.entries, evicted)
+	}
+
+	c.entries[digest] = data
+	c.order = append(c.order, digest)
+	c.size += len(data)
+}
+
+// contentDigest is the digest files are cached by.
+func contentDigest(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// remoteFs is a filesystem whose files are served by the client over a Workspace stream.
+// Files opened for writing can't be read from, and are written through to the client as they're written.
+type remoteFs struct {
+	conn *workspaceConn
+	root bonkv0.WorkspaceCall_Root
+}
+
+var _ afero.Fs = (*remoteFs)(nil)
+
+func newRemoteFs(conn *workspaceConn, root bonkv0.WorkspaceCall_Root) *remoteFs {
+	return &remoteFs{
+		conn: conn,
+		root: root,
+	}
+}
+
+func (rfs *remoteFs) Name() string {
+	return "RemoteWorkspaceFs"
+}
+
+func (rfs *remoteFs) Create(name string) (afero.File, error) {
+	return rfs.OpenFile(name, syscall.O_RDWR|syscall.O_CREAT|syscall.O_TRUNC, 0o666) //nolint:mnd
+}
+
+func (rfs *remoteFs) Mkdir(name string, perm fs.FileMode) error {
+	return rfs.mkdir("mkdir", name, perm, false)
+}
+
+func (rfs *remoteFs) MkdirAll(name string, perm fs.FileMode) error {
+	return rfs.mkdir("mkdirall", name, perm, true)
+}
+
+func (rfs *remoteFs) mkdir(op, name string, perm fs.FileMode, all bool) error {
+	err := rfs.conn.call(bonkv0.WorkspaceCall_builder{
+		Mkdir: bonkv0.WorkspaceCall_Mkdir_builder{
+			Root: rfs.root.Enum(),
+			Path: &name,
+			Mode: new(uint32(perm)),
+			All:  &all,
+		}.Build(),
+	}, expectDone)
+	if err != nil {
+		return &fs.PathError{Op: op, Path: name, Err: err}
+	}
+
+	return nil
+}
+
+func (rfs *remoteFs) Open(name string) (afero.File, error) {
+	return rfs.OpenFile(name, syscall.O_RDONLY, 0)
+}
+
+func (rfs *remoteFs) OpenFile(name string, flag int, perm fs.FileMode) (afero.File, error) {
+	if flag&(syscall.O_WRONLY|syscall.O_RDWR) != 0 {
+		return rfs.openWritable(name, flag, perm)
+	}
+
+	info, err := rfs.stat(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	file := &remoteFile{
+		fs:   rfs,
+		name: name,
+		info: info,
+	}
+	if info.IsDir() {
+		return file, nil
+	}
+
+	data, err := rfs.readFile(name, info.digest)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	file.content = bytes.NewReader(data)
+
+	return file, nil
+}
+
+func (rfs *remoteFs) openWritable(name string, flag int, perm fs.FileMode) (afero.File, error) {
+	file := &remoteFile{
+		fs:       rfs,
+		name:     name,
+		writable: true,
+	}
+
+	create := flag&syscall.O_CREAT != 0
+	truncate := flag&syscall.O_TRUNC != 0
+	if create || truncate {
+		// Writing nothing creates or truncates the file
+		err := rfs.writeAt(name, nil, 0, create, truncate, perm)
+		if err != nil {
+			return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+		}
+	}
+
+	if flag&syscall.O_APPEND != 0 || !truncate {
+		info, err := rfs.stat(name)
+		if err != nil {
+			return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+		}
+		file.info = info
+
+		if flag&syscall.O_APPEND != 0 {
+			file.offset = info.Size()
+		}
+	}
+
+	return file, nil
+}
+
+func (rfs *remoteFs) Remove(name string) error {
+	return rfs.remove("remove", name, false)
+}
+
+func (rfs *remoteFs) RemoveAll(name string) error {
+	return rfs.remove("removeall", name, true)
+}
+
+func (rfs *remoteFs) remove(op, name string, all bool) error {
+	err := rfs.conn.call(bonkv0.WorkspaceCall_builder{
+		Remove: bonkv0.WorkspaceCall_Remove_builder{
+			Root: rfs.root.Enum(),
+			Path: &name,
+			All:  &all,
+		}.Build(),
+	}, expectDone)
+	if err != nil {
+		return &fs.PathError{Op: op, Path: name, Err: err}
+	}
+
+	return nil
+}
+
+func (rfs *remoteFs) Rename(oldname, newname string) error {
+	err := rfs.conn.call(bonkv0.WorkspaceCall_builder{
+		Rename: bonkv0.WorkspaceCall_Rename_builder{
+			Root:    rfs.root.Enum(),
+			OldPath: &oldname,
+			NewPath: &newname,
+		}.Build(),
+	}, expectDone)
+	if err != nil {
+		return &fs.PathError{Op: "rename", Path: oldname, Err: err}
+	}
+
+	return nil
+}
+
+func (rfs *remoteFs) Stat(name string) (fs.FileInfo, error) {
+	info, err := rfs.stat(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: err}
+	}
+
+	return info, nil
+}
+
+func (rfs *remoteFs) Chmod(name string, _ fs.FileMode) error {
+	return &fs.PathError{Op: "chmod", Path: name, Err: errors.ErrUnsupported}
+}
+
+func (rfs *remoteFs) Chown(name string, _, _ int) error {
+	return &fs.PathError{Op: "chown", Path: name, Err: errors.ErrUnsupported}
+}
+
+func (rfs *remoteFs) Chtimes(name string, _, _ time.Time) error {
+	return &fs.PathError{Op: "chtimes", Path: name, Err: errors.ErrUnsupported}
+}
+
+func (rfs *remoteFs) stat(name string) (*fileInfo, error) {
+	var info *fileInfo
+	err := rfs.conn.call(bonkv0.WorkspaceCall_builder{
+		Stat: bonkv0.WorkspaceCall_Stat_builder{
+			Root: rfs.root.Enum(),
+			Path: &name,
+		}.Build(),
+	}, func(reply *bonkv0.WorkspaceReply) (bool, error) {
+		if reply.WhichReply() != bonkv0.WorkspaceReply_Info_case {
+			return true, unexpectedReply(reply)
+		}
+		info = fromProtoFileInfo(reply.GetInfo())
+
+		return true, nil
+	})
+
+	return info, err
+}
+
+func (rfs *remoteFs) readDir(name string) ([]fs.FileInfo, error) {
+	var entries []fs.FileInfo
+	err := rfs.conn.call(bonkv0.WorkspaceCall_builder{
+		ReadDir: bonkv0.WorkspaceCall_ReadDir_builder{
+			Root: rfs.root.Enum(),
+			Path: &name,
+		}.Build(),
+	}, func(reply *bonkv0.WorkspaceReply) (bool, error) {
+		if reply.WhichReply() != bonkv0.WorkspaceReply_Entries_case {
+			return true, unexpectedReply(reply)
+		}
+
+		for _, entry := range reply.GetEntries().GetEntries() {
+			entries = append(entries, fromProtoFileInfo(entry))
+		}
+
+		return true, nil
+	})
+
+	return entries, err
+}
+
+// readFile returns the content of the file, which is only read from the client if digest isn't cached.
+func (rfs *remoteFs) readFile(name, digest string) ([]byte, error) {
+	if data, ok := rfs.conn.contents.get(digest); ok {
+		return data, nil
+	}
+
+	var buf bytes.Buffer
+	err := rfs.conn.call(bonkv0.WorkspaceCall_builder{
+		ReadFile: bonkv0.WorkspaceCall_ReadFile_builder{
+			Root: rfs.root.Enum(),
+			Path: &name,
+		}.Build(),
+	}, func(reply *bonkv0.WorkspaceReply) (bool, error) {
+		if reply.WhichReply() != bonkv0.WorkspaceReply_Content_case {
+			return true, unexpectedReply(reply)
+		}
+		buf.Write(reply.GetContent().GetData())
+
+		return reply.GetContent().GetEof(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The file may have changed since it was stat'd, so it's cached by the digest of what was read
+	data := buf.Bytes()
+	rfs.conn.contents.put(contentDigest(data), data)
+
+	return data, nil
+}
+
+// writeAt writes data to the file at offset, split into as many calls as needed.
+func (rfs *remoteFs) writeAt(name string, data []byte, offset int64, create, truncate bool, perm fs.FileMode) error {
+	for {
+		chunk := data[:min(len(data), workspaceChunkSize)]
+
+		err := rfs.conn.call(bonkv0.WorkspaceCall_builder{
+			WriteFile: bonkv0.WorkspaceCall_WriteFile_builder{
+				Root:     rfs.root.Enum(),
+				Path:     &name,
+				Offset:   &offset,
+				Data:     chunk,
+				Create:   &create,
+				Truncate: &truncate,
+				Mode:     new(uint32(perm)),
+			}.Build(),
+		}, expectDone)
+		if err != nil {
+			return err
+		}
+
+		data = data[len(chunk):]
+		offset += int64(len(chunk))
+		create, truncate = false, false
+
+		if len(data) == 0 {
+			return nil
+		}
+	}
+}
+
+func expectDone(reply *bonkv0.WorkspaceReply) (bool, error) {
+	if reply.WhichReply() != bonkv0.WorkspaceReply_Done_case {
+		return true, unexpectedReply(reply)
+	}
+
+	return true, nil
+}
+
+func unexpectedReply(reply *bonkv0.WorkspaceReply) error {
+	return fmt.Errorf("unexpected workspace reply %v", reply.WhichReply())
+}
+
+// fileInfo describes a file in a remote workspace.
+type fileInfo struct {
+	name    string
+	size    int64
+	mode    fs.FileMode
+	modTime time.Time
+	digest  string
+}
+
+var _ fs.FileInfo = (*fileInfo)(nil)
+
+func fromProtoFileInfo(info *bonkv0.WorkspaceReply_FileInfo) *fileInfo {
+	return &fileInfo{
+		name:    info.GetName(),
+		size:    info.GetSize(),
+		mode:    fs.FileMode(info.GetMode()),
+		modTime: info.GetModTime().AsTime(),
+		digest:  info.GetDigest(),
+	}
+}
+
+func (fi *fileInfo) Name() string       { return fi.name }
+func (fi *fileInfo) Size() int64        { return fi.size }
+func (fi *fileInfo) Mode() fs.FileMode  { return fi.mode }
+func (fi *fileInfo) ModTime() time.Time { return fi.modTime }
+func (fi *fileInfo) IsDir() bool        { return fi.mode.IsDir() }
+func (fi *fileInfo) Sys() any           { return nil }
+
+// remoteFile is a file opened from a [remoteFs].
+// Regular files opened for reading are read entirely when opened, directories are listed when first read,
+// and files opened for writing are written through to the client.
+type remoteFile struct {
+	fs   *remoteFs
+	name string
+	info *fileInfo
+
+	content *bytes.Reader
+
+	entries    []fs.FileInfo
+	entriesErr error
+	listed     bool
+
+	writable bool
+	offset   int64
+}
+
+var _ afero.File = (*remoteFile)(nil)
+
+func (f *remoteFile) Name() string {
+	return f.name
+}
+
+func (f *remoteFile) Close() error {
+	return nil
+}
+
+func (f *remoteFile) Sync() error {
+	return nil
+}
+
+func (f *remoteFile) Stat() (fs.FileInfo, error) {
+	if f.writable || f.info == nil {
+		return f.fs.Stat(f.name)
+	}
+
+	return f.info, nil
+}
+
+func (f *remoteFile) Read(p []byte) (int, error) {
+	if f.content == nil {
+		return 0, f.badDescriptor("read")
+	}
+
+	return f.content.Read(p) //nolint:wrapcheck
+}
+
+func (f *remoteFile) ReadAt(p []byte, off int64) (int, error) {
+	if f.content == nil {
+		return 0, f.badDescriptor("read")
+	}
+
+	return f.content.ReadAt(p, off) //nolint:wrapcheck
+}
+
+func (f *remoteFile) Seek(offset int64, whence int) (int64, error) {
+	if f.content != nil {
+		return f.content.Seek(offset, whence) //nolint:wrapcheck
+	}
+	if !f.writable {
+		return 0, f.badDescriptor("seek")
+	}
+
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		info, err := f.fs.Stat(f.name)
+		if err != nil {
+			return 0, err
+		}
+		offset += info.Size()
+	default:
+		return 0, &fs.PathError{Op: "seek", Path: f.name, Err: fs.ErrInvalid}
+	}
+	if offset < 0 {
+		return 0, &fs.PathError{Op: "seek", Path: f.name, Err: fs.ErrInvalid}
+	}
+	f.offset = offset
+
+	return offset, nil
+}
+
+func (f *remoteFile) Write(p []byte) (int, error) {
+	n, err := f.WriteAt(p, f.offset)
+	f.offset += int64(n)
+
+	return n, err
+}
+
+func (f *remoteFile) WriteAt(p []byte, off int64) (int, error) {
+	if !f.writable {
+		return 0, f.badDescriptor("write")
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	err := f.fs.writeAt(f.name, p, off, false, false, 0)
+	if err != nil {
+		return 0, &fs.PathError{Op: "write", Path: f.name, Err: err}
+	}
+
+	return len(p), nil
+}
+
+func (f *remoteFile) WriteString(s string) (int, error) {
+	return f.Write([]byte(s))
+}
+
+func (f *remoteFile) Truncate(size int64) error {
+	if !f.writable {
+		return f.badDescriptor("truncate")
+	}
+	if size != 0 {
+		return &fs.PathError{Op: "truncate", Path: f.name, Err: errors.ErrUnsupported}
+	}
+
+	err := f.fs.writeAt(f.name, nil, 0, false, true, 0)
+	if err != nil {
+		return &fs.PathError{Op: "truncate", Path: f.name, Err: err}
+	}
+
+	return nil
+}
+
+func (f *remoteFile) Readdir(count int) ([]fs.FileInfo, error) {
+	if f.info == nil || !f.info.IsDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: f.name, Err: syscall.ENOTDIR}
+	}
+
+	if !f.listed {
+		f.entries, f.entriesErr = f.fs.readDir(f.name)
+		f.listed = true
+	}
+	if f.entriesErr != nil {
+		return nil, &fs.PathError{Op: "readdir", Path: f.name, Err: f.entriesErr}
+	}
+
+	if count <= 0 {
+		entries := f.entries
+		f.entries = nil
+
+		return entries, nil
+	}
+	if len(f.entries) == 0 {
+		return nil, io.EOF
+	}
+
+	entries := f.entries[:min(count, len(f.entries))]
+	f.entries = f.entries[len(entries):]
+
+	return entries, nil
+}
+
+func (f *remoteFile) Readdirnames(n int) ([]string, error) {
+	entries, err := f.Readdir(n)
+	names := make([]string, len(entries))
+	for idx, entry := range entries {
+		names[idx] = path.Base(entry.Name())
+	}
+
+	return names, err
+}
+
+func (f *remoteFile) badDescriptor(op string) error {
+	return &fs.PathError{Op: op, Path: f.name, Err: syscall.EBADF}
+}
@@ -0,0 +1,118 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package rpc_test
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"go.bonk.build/pkg/executor/mockexec"
+	"go.bonk.build/pkg/executor/rpc"
+	"go.bonk.build/pkg/task"
+)
+
+func TestRemoteWorkspace(t *testing.T) {
+	t.Parallel()
+
+	exec := mockexec.NewMockExecutor(t)
+
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	rpc.RegisterGRPCServer(server, exec)
+
+	go server.Serve(lis) //nolint:errcheck
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	client := rpc.NewGRPCClient(conn, rpc.WithRemoteWorkspaces())
+
+	// Larger files are split across many messages
+	small := []byte("hello")
+	large := bytes.Repeat([]byte("0123456789"), 300_000)
+
+	root := t.TempDir()
+	rootFs := afero.NewBasePathFs(afero.NewOsFs(), root)
+	require.NoError(t, rootFs.Mkdir("dir", 0o750))
+	require.NoError(t, afero.WriteFile(rootFs, "small.txt", small, 0o600))
+	require.NoError(t, afero.WriteFile(rootFs, filepath.Join("dir", "large.txt"), large, 0o600))
+
+	session := task.NewLocalSession(task.NewSessionID(), root)
+	tsk := task.New("Test.Task", "test.exec", nil)
+
+	exec.EXPECT().OpenSession(mock.Anything, mock.Anything).Return(nil)
+	exec.EXPECT().CloseSession(mock.Anything, session.ID())
+	exec.EXPECT().Execute(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(_ context.Context, session task.Session, tsk *task.Task, _ *task.Result) {
+			sourceFs := session.SourceFS()
+
+			data, err := afero.ReadFile(sourceFs, "small.txt")
+			assert.NoError(t, err)
+			assert.Equal(t, small, data)
+
+			data, err = afero.ReadFile(sourceFs, filepath.Join("dir", "large.txt"))
+			assert.NoError(t, err)
+			assert.Equal(t, large, data)
+
+			// The output directory was created before the task was executed
+			entries, err := afero.ReadDir(sourceFs, ".")
+			assert.NoError(t, err)
+			if assert.Len(t, entries, 3) {
+				assert.Equal(t, task.OutputDir, entries[0].Name())
+				assert.Equal(t, "dir", entries[1].Name())
+				assert.True(t, entries[1].IsDir())
+				assert.Equal(t, "small.txt", entries[2].Name())
+				assert.Equal(t, int64(len(small)), entries[2].Size())
+			}
+
+			exists, err := afero.Exists(sourceFs, "missing.txt")
+			assert.NoError(t, err)
+			assert.False(t, exists)
+
+			// Sources are read-only
+			assert.Error(t, afero.WriteFile(sourceFs, "small.txt", large, 0o600))
+
+			outputFs := task.OutputFS(session, tsk.ID)
+			assert.NoError(t, afero.WriteFile(outputFs, "small.txt", small, 0o600))
+			assert.NoError(t, afero.WriteFile(outputFs, "large.txt", large, 0o600))
+		}).
+		Return(nil)
+
+	err = client.OpenSession(t.Context(), session)
+	require.NoError(t, err)
+	defer client.CloseSession(t.Context(), session.ID())
+
+	err = client.Execute(t.Context(), session, tsk, &task.Result{})
+	require.NoError(t, err)
+
+	// Outputs are written back to the local workspace
+	outputFs := task.OutputFS(session, tsk.ID)
+
+	data, err := afero.ReadFile(outputFs, "small.txt")
+	require.NoError(t, err)
+	assert.Equal(t, small, data)
+
+	data, err = afero.ReadFile(outputFs, "large.txt")
+	require.NoError(t, err)
+	assert.Equal(t, large, data)
+}
@@ -0,0 +1,178 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"go.uber.org/multierr"
+
+	"go.bonk.build/pkg/profile"
+	"go.bonk.build/pkg/task"
+)
+
+var (
+	// ErrUnknownUpstream is returned when a task's condition checks the outcome of a task which isn't scheduled.
+	ErrUnknownUpstream = errors.New("condition checks task which isn't scheduled")
+	// ErrUpstreamFailed is returned when a task's condition checks the outcome of a task which failed.
+	ErrUpstreamFailed = errors.New("condition checks task which failed")
+	// ErrConditionCycle is returned when the conditions of tasks check each other's outcomes.
+	ErrConditionCycle = errors.New("conditions check each other's outcomes")
+)
+
+// SkipHandler is called with each task which is skipped because its condition didn't hold.
+type SkipHandler func(session task.Session, tsk *task.Task)
+
+// SetFacts sets the facts which the conditions of tasks are evaluated against,
+// unless the context they're executed with has its own, as set by [task.ContextWithFacts].
+func (s *Scheduler) SetFacts(facts task.Facts) {
+	s.facts = facts
+}
+
+// SetSkipHandler sets the function called with each task which is skipped.
+func (s *Scheduler) SetSkipHandler(handler SkipHandler) {
+	s.onSkip = handler
+}
+
+// skip evaluates the task's condition, reporting whether the task should be skipped.
+func (s *Scheduler) skip(ctx context.Context, session task.Session, tsk *task.Task) (bool, error) {
+	if tsk.When == nil {
+		return false, nil
+	}
+
+	facts, ok := task.FactsFromContext(ctx)
+	if !ok {
+		facts = s.facts
+	}
+
+	region := profile.Begin(ctx, "scheduler", "evaluate condition "+tsk.ID.String())
+	holds, err := tsk.When.Evaluate(facts, session, tsk, func(id task.ID) (task.UpstreamResult, error) {
+		return s.upstream(ctx, session, id)
+	})
+	region.End()
+
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate condition of %s: %w", tsk.ID, err)
+	}
+	if holds {
+		return false, nil
+	}
+
+	slog.DebugContext(ctx, "condition not met, skipping task", "task", tsk.ID)
+	if s.onSkip != nil {
+		s.onSkip(session, tsk)
+	}
+
+	return true, nil
+}
+
+// upstream waits for the task with the given ID to finish in session, and returns its result.
+func (s *Scheduler) upstream(ctx context.Context, session task.Session, id task.ID) (task.UpstreamResult, error) {
+	s.executedMu.Lock()
+	upstream, ok := s.executed[session.ID()][id]
+	s.executedMu.Unlock()
+
+	if !ok {
+		return task.UpstreamResult{}, fmt.Errorf("%w: %s", ErrUnknownUpstream, id)
+	}
+
+	select {
+	case <-ctx.Done():
+		return task.UpstreamResult{}, fmt.Errorf("canceled while waiting for upstream task %s: %w", id, ctx.Err())
+	case <-upstream.done:
+	}
+
+	if upstream.err != nil {
+		return task.UpstreamResult{}, fmt.Errorf("%w %s: %w", ErrUpstreamFailed, id, upstream.err)
+	}
+
+	return task.UpstreamResult{Outcome: upstream.outcome, Outputs: upstream.outputs}, nil
+}
+
+// checkConditions checks that the conditions of tasks, which are about to be scheduled beneath parent, only check
+// the outcomes of tasks which are known to be scheduled, and don't wait on each other.
+//
+// Tasks may check the outcomes of the tasks scheduled with them, of tasks scheduled directly before them,
+// and of the followups of their ancestors. Other followups may not have been returned yet.
+func checkConditions(executed map[task.ID]*executedTask, tasks []*task.Task, parent task.ID) error {
+	scheduling := make(map[task.ID]*task.Task, len(tasks))
+	for _, tsk := range tasks {
+		if _, ok := scheduling[tsk.ID]; !ok {
+			scheduling[tsk.ID] = tsk
+		}
+	}
+
+	var err error
+	for _, tsk := range tasks {
+		for _, id := range tsk.When.Upstream() {
+			if _, ok := scheduling[id]; ok {
+				continue
+			}
+
+			upstream, ok := executed[id]
+			if ok && (upstream.parent == "" || isAncestor(upstream.parent, parent)) {
+				continue
+			}
+
+			multierr.AppendInto(&err, fmt.Errorf("%w: %s checks %s", ErrUnknownUpstream, tsk.ID, id))
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	// Tasks scheduled before these can't check their outcomes, so only cycles among them need to be found
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	states := make(map[task.ID]int, len(scheduling))
+
+	var visit func(id task.ID, path []task.ID) error
+	visit = func(id task.ID, path []task.ID) error {
+		switch states[id] {
+		case visited:
+			return nil
+		case visiting:
+			cycle := append(path[slices.Index(path, id):], id)
+
+			return fmt.Errorf("%w: %v", ErrConditionCycle, cycle)
+		}
+
+		states[id] = visiting
+		for _, upstream := range scheduling[id].When.Upstream() {
+			if _, ok := scheduling[upstream]; !ok {
+				continue
+			}
+
+			err := visit(upstream, append(path, id))
+			if err != nil {
+				return err
+			}
+		}
+		states[id] = visited
+
+		return nil
+	}
+
+	for _, tsk := range tasks {
+		err := visit(tsk.ID, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isAncestor reports whether ancestor is descendant, or one of its ancestors.
+func isAncestor(ancestor, descendant task.ID) bool {
+	return descendant == ancestor || strings.HasPrefix(descendant.String(), ancestor.String()+task.TaskIDSep)
+}
@@ -0,0 +1,225 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"go.bonk.build/pkg/task"
+)
+
+var (
+	// ErrConflictingTask is returned when a task is scheduled with the ID of a different task in the same session.
+	ErrConflictingTask = errors.New("conflicting definitions of task")
+	// ErrDuplicateFailed is returned for duplicates of a task which failed.
+	ErrDuplicateFailed = errors.New("duplicate of failed task")
+)
+
+// executedTask is a task which has been scheduled in a session, and the outcome once it's known.
+type executedTask struct {
+	id task.ID
+	// definition is nil until the task is claimed, if it was expected before then.
+	definition *taskDefinition
+	// parent is the task which returned this one as a followup, or empty if it was scheduled directly.
+	parent task.ID
+	// done is closed once the task has executed.
+	done    chan struct{}
+	outputs []string
+	outcome task.Outcome
+	err     error
+}
+
+// claimTask registers tsk as executing in session.
+// If an identical task has already been scheduled, it's returned as the original, which the caller should wait for
+// instead of executing tsk. Duplicates with the same ID aren't claimed, while duplicates named beneath other tasks are
+// claimed so that the outcome of the original can be recorded under their own IDs.
+func (s *Scheduler) claimTask(session task.Session, tsk *task.Task) (*executedTask, *executedTask, error) {
+	s.executedMu.Lock()
+	defer s.executedMu.Unlock()
+
+	executed, ok := s.executed[session.ID()]
+	if !ok {
+		executed = make(map[task.ID]*executedTask)
+		s.executed[session.ID()] = executed
+	}
+
+	claimed, ok := executed[tsk.ID]
+	switch {
+	case !ok:
+		claimed = &executedTask{id: tsk.ID, done: make(chan struct{})}
+		executed[tsk.ID] = claimed
+	case claimed.definition == nil:
+		// Expected, and not yet claimed
+	case !claimed.definition.matches(tsk):
+		return nil, nil, fmt.Errorf("%w %s: scheduled with differing executor, args or inputs",
+			ErrConflictingTask, tsk.ID)
+	default:
+		return nil, claimed, nil
+	}
+
+	claimed.definition = defineTask(tsk)
+
+	// Followups of different tasks may be identical, such as the same resources emitted by different components
+	key, ok := definitionKey(tsk)
+	if !ok {
+		return claimed, nil, nil
+	}
+
+	definitions, ok := s.definitions[session.ID()]
+	if !ok {
+		definitions = make(map[string]*executedTask)
+		s.definitions[session.ID()] = definitions
+	}
+	if original, ok := definitions[key]; ok {
+		return claimed, original, nil
+	}
+	definitions[key] = claimed
+
+	return claimed, nil, nil
+}
+
+// definitionKey identifies what tsk executes, independently of the task it's named beneath.
+// Tasks with the same name, executor, inputs, args and condition are identical.
+func definitionKey(tsk *task.Task) (string, bool) {
+	key, err := json.Marshal(struct {
+		Name     string          `json:"name"`
+		Executor string          `json:"executor"`
+		Inputs   []string        `json:"inputs"`
+		Args     any             `json:"args"`
+		When     *task.Condition `json:"when"`
+	}{
+		Name:     tsk.ID.String()[strings.LastIndex(tsk.ID.String(), task.TaskIDSep)+1:],
+		Executor: tsk.Executor,
+		Inputs:   tsk.Inputs,
+		Args:     tsk.Args,
+		When:     tsk.When,
+	})
+	if err != nil {
+		// Tasks which can't be compared are only coalesced by ID
+		return "", false
+	}
+
+	return string(key), true
+}
+
+// expectTasks registers tasks which are about to be claimed in session,
+// so that the conditions of other tasks may wait for their outcomes before they're claimed.
+// parent is the task which returned tasks as followups, or empty if they're scheduled directly.
+func (s *Scheduler) expectTasks(session task.Session, tasks []*task.Task, parent task.ID) error {
+	s.executedMu.Lock()
+	defer s.executedMu.Unlock()
+
+	executed, ok := s.executed[session.ID()]
+	if !ok {
+		executed = make(map[task.ID]*executedTask)
+		s.executed[session.ID()] = executed
+	}
+
+	// Conditions are checked before any of the tasks are registered, so that none of them are left waiting
+	err := checkConditions(executed, tasks, parent)
+	if err != nil {
+		return err
+	}
+
+	for _, tsk := range tasks {
+		if _, ok := executed[tsk.ID]; !ok {
+			executed[tsk.ID] = &executedTask{id: tsk.ID, parent: parent, done: make(chan struct{})}
+		}
+	}
+
+	return nil
+}
+
+// finish records the outcome of the task, and releases any duplicates waiting on it.
+func (e *executedTask) finish(result *task.Result, skipped bool, err error) {
+	e.outputs = result.GetOutputs()
+	e.err = err
+
+	switch {
+	case err != nil:
+	case skipped:
+		e.outcome = task.OutcomeSkipped
+	case result.IsCached():
+		e.outcome = task.OutcomeCached
+	default:
+		e.outcome = task.OutcomeExecuted
+	}
+
+	close(e.done)
+}
+
+// follow waits for original, an identical task with a different ID, and records its outcome as this task's.
+func (e *executedTask) follow(ctx context.Context, original *executedTask, result *task.Result) error {
+	err := original.wait(ctx, result)
+	if err == nil {
+		e.outputs = original.outputs
+		e.outcome = original.outcome
+	}
+	e.err = err
+
+	close(e.done)
+
+	return err
+}
+
+// wait blocks until the task has executed, and adds its outputs to result.
+func (e *executedTask) wait(ctx context.Context, result *task.Result) error {
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("canceled while waiting for duplicate task %s: %w", e.id, ctx.Err())
+	case <-e.done:
+	}
+
+	if e.err != nil {
+		return fmt.Errorf("%w %s: %w", ErrDuplicateFailed, e.id, e.err)
+	}
+
+	result.AddOutputs(e.outputs...)
+
+	return nil
+}
+
+// CloseSession implements executor.Executor, forgetting the tasks executed in the session.
+func (s *Scheduler) CloseSession(ctx context.Context, sessionID task.SessionID) {
+	s.ResetSession(sessionID)
+	s.Executor.CloseSession(ctx, sessionID)
+}
+
+// ResetSession forgets the tasks executed in the session, so that they may be executed again.
+func (s *Scheduler) ResetSession(sessionID task.SessionID) {
+	s.executedMu.Lock()
+	defer s.executedMu.Unlock()
+
+	delete(s.executed, sessionID)
+	delete(s.definitions, sessionID)
+}
+
+// taskDefinition is a snapshot of what a claimed task executes, taken when it's claimed.
+// Executors may change the task while it executes, such as routers rewriting its executor.
+type taskDefinition struct {
+	executor string
+	inputs   []string
+	args     any
+}
+
+func defineTask(tsk *task.Task) *taskDefinition {
+	return &taskDefinition{
+		executor: tsk.Executor,
+		inputs:   slices.Clone(tsk.Inputs),
+		args:     task.CloneArgs(tsk.Args),
+	}
+}
+
+// matches reports whether tsk executes the same work as the claimed task.
+func (d *taskDefinition) matches(tsk *task.Task) bool {
+	return d.executor == tsk.Executor &&
+		slices.Equal(d.inputs, tsk.Inputs) &&
+		reflect.DeepEqual(d.args, tsk.Args)
+}
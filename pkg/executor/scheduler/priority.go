@@ -0,0 +1,189 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package scheduler
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.bonk.build/pkg/task"
+)
+
+// Estimator estimates how long a task and the longest chain of its followups will take to execute,
+// returning false if there's no estimate.
+type Estimator func(session task.Session, tsk *task.Task) (time.Duration, bool)
+
+// prioritySlots limits the number of tasks executing at once.
+// When every slot is taken, the next one goes to the waiting task with the highest priority,
+// with ties going to the task which has waited longest.
+type prioritySlots struct {
+	mu      sync.Mutex
+	free    int
+	limited bool
+	waiting waitQueue
+	nextSeq uint64
+}
+
+type slotWaiter struct {
+	priority time.Duration
+	seq      uint64
+	index    int
+	// ready is closed once the waiter has been given a slot.
+	ready chan struct{}
+}
+
+func newPrioritySlots(limit int) *prioritySlots {
+	return &prioritySlots{
+		free:    limit,
+		limited: limit > 0,
+	}
+}
+
+// acquire blocks until a slot is available for a task with the given priority.
+func (p *prioritySlots) acquire(ctx context.Context, priority time.Duration) error {
+	p.mu.Lock()
+
+	if !p.limited {
+		p.mu.Unlock()
+
+		return nil
+	}
+
+	if p.free > 0 && p.waiting.Len() == 0 {
+		p.free--
+		p.mu.Unlock()
+
+		return nil
+	}
+
+	waiter := &slotWaiter{
+		priority: priority,
+		seq:      p.nextSeq,
+		ready:    make(chan struct{}),
+	}
+	p.nextSeq++
+	heap.Push(&p.waiting, waiter)
+	p.mu.Unlock()
+
+	select {
+	case <-waiter.ready:
+		return nil
+
+	case <-ctx.Done():
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		select {
+		case <-waiter.ready:
+			// Handed a slot while giving up, so pass it on
+			p.releaseLocked()
+		default:
+			heap.Remove(&p.waiting, waiter.index)
+		}
+
+		return fmt.Errorf("canceled while waiting to execute: %w", ctx.Err())
+	}
+}
+
+// release gives the slot to the highest priority waiter, if any.
+func (p *prioritySlots) release() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.limited {
+		p.releaseLocked()
+	}
+}
+
+func (p *prioritySlots) releaseLocked() {
+	if p.waiting.Len() == 0 {
+		p.free++
+
+		return
+	}
+
+	waiter := heap.Pop(&p.waiting).(*slotWaiter) //nolint:forcetypeassert
+	close(waiter.ready)
+}
+
+// waitQueue is a max-heap of waiters by priority, implementing [heap.Interface].
+type waitQueue []*slotWaiter
+
+func (q waitQueue) Len() int { return len(q) }
+
+func (q waitQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority > q[j].priority
+	}
+
+	return q[i].seq < q[j].seq
+}
+
+func (q waitQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *waitQueue) Push(x any) {
+	waiter := x.(*slotWaiter) //nolint:forcetypeassert
+	waiter.index = len(*q)
+	*q = append(*q, waiter)
+}
+
+func (q *waitQueue) Pop() any {
+	old := *q
+	waiter := old[len(old)-1]
+	old[len(old)-1] = nil
+	*q = old[:len(old)-1]
+
+	return waiter
+}
+
+// criticalPaths returns the priority of each of tsks: the estimated duration of the longest chain of tasks
+// which can't start until it finishes, including itself.
+//
+// Tasks can't start until the tasks their conditions check have finished, and the estimate of each task includes
+// its followups. Conditions checking tasks outside of tsks are ignored.
+func criticalPaths(estimate func(*task.Task) time.Duration, tsks []*task.Task) map[task.ID]time.Duration {
+	dependents := make(map[task.ID][]*task.Task)
+	for _, tsk := range tsks {
+		for _, upstream := range tsk.When.Upstream() {
+			dependents[upstream] = append(dependents[upstream], tsk)
+		}
+	}
+
+	paths := make(map[task.ID]time.Duration, len(tsks))
+	visiting := make(map[task.ID]bool)
+
+	var pathOf func(tsk *task.Task) time.Duration
+	pathOf = func(tsk *task.Task) time.Duration {
+		if path, ok := paths[tsk.ID]; ok {
+			return path
+		}
+		if visiting[tsk.ID] {
+			// Condition cycles don't lengthen the path
+			return 0
+		}
+		visiting[tsk.ID] = true
+
+		var longestDependent time.Duration
+		for _, dependent := range dependents[tsk.ID] {
+			longestDependent = max(longestDependent, pathOf(dependent))
+		}
+
+		paths[tsk.ID] = estimate(tsk) + longestDependent
+
+		return paths[tsk.ID]
+	}
+
+	for _, tsk := range tsks {
+		pathOf(tsk)
+	}
+
+	return paths
+}
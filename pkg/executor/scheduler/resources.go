@@ -0,0 +1,151 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
+
+	"go.bonk.build/pkg/executor/router"
+	"go.bonk.build/pkg/task"
+)
+
+// executorResourcePrefix prefixes the implicit resources used to limit the concurrency of executors.
+const executorResourcePrefix = "executor:"
+
+var ErrInsufficientResources = errors.New("insufficient resources")
+
+// ExecutorLimits constrains the tasks routed to an executor, and every executor beneath it.
+type ExecutorLimits struct {
+	// Concurrency is the max number of the executor's tasks which may execute at once, or 0 for no limit.
+	Concurrency int `json:"concurrency,omitempty" mapstructure:"concurrency"`
+	// Resources are the least each of the executor's tasks requires, tasks may require more of their own.
+	Resources task.Resources `json:"resources,omitempty" mapstructure:"resources"`
+}
+
+// resourcePool tracks the resources available to tasks.
+// Tasks are admitted only once all of the resources they require are available at once.
+type resourcePool struct {
+	mu        sync.Mutex
+	limits    map[string]int
+	used      map[string]int
+	executors map[string]ExecutorLimits
+	matcher   router.Matcher
+	// released is closed and replaced whenever resources are released, to wake waiting tasks.
+	released chan struct{}
+}
+
+func newResourcePool() *resourcePool {
+	return &resourcePool{
+		limits:    make(map[string]int),
+		used:      make(map[string]int),
+		executors: make(map[string]ExecutorLimits),
+		released:  make(chan struct{}),
+	}
+}
+
+func (p *resourcePool) setLimit(name string, capacity int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.limits[name] = capacity
+}
+
+func (p *resourcePool) setExecutorLimits(route string, limits ExecutorLimits) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.executors[route] = limits
+	if limits.Concurrency > 0 {
+		p.limits[executorResourcePrefix+route] = limits.Concurrency
+	} else {
+		delete(p.limits, executorResourcePrefix+route)
+	}
+}
+
+// requirements returns the resources tsk needs, including those of the executors it's routed to.
+func (p *resourcePool) requirements(tsk *task.Task) task.Resources {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	required := maps.Clone(tsk.Resources)
+	if required == nil {
+		required = make(task.Resources)
+	}
+
+	for route, limits := range p.executors {
+		if !p.matcher.Matches(route, tsk.Executor) {
+			continue
+		}
+
+		for name, amount := range limits.Resources {
+			required[name] = max(required[name], amount)
+		}
+		if limits.Concurrency > 0 {
+			required[executorResourcePrefix+route] = 1
+		}
+	}
+
+	return required
+}
+
+// acquire blocks until the required resources are available, and takes them.
+// release must be called with the same resources once the task has finished.
+func (p *resourcePool) acquire(ctx context.Context, required task.Resources) error {
+	for {
+		p.mu.Lock()
+
+		fits := true
+		for name, amount := range required {
+			limit, limited := p.limits[name]
+			switch {
+			case !limited:
+			case amount > limit:
+				p.mu.Unlock()
+
+				return fmt.Errorf("%w: %d %s required, but the limit is %d",
+					ErrInsufficientResources, amount, name, limit)
+			case p.used[name]+amount > limit:
+				fits = false
+			}
+		}
+
+		if fits {
+			for name, amount := range required {
+				p.used[name] += amount
+			}
+			p.mu.Unlock()
+
+			return nil
+		}
+
+		released := p.released
+		p.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("canceled while waiting for resources: %w", ctx.Err())
+		case <-released:
+		}
+	}
+}
+
+func (p *resourcePool) release(required task.Resources) {
+	if len(required) == 0 {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for name, amount := range required {
+		p.used[name] -= amount
+	}
+
+	close(p.released)
+	p.released = make(chan struct{})
+}
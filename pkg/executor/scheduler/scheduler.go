@@ -8,40 +8,105 @@ package scheduler
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
 
 	"golang.org/x/sync/errgroup"
 
 	"go.bonk.build/pkg/executor"
+	"go.bonk.build/pkg/executor/router"
+	"go.bonk.build/pkg/profile"
 	"go.bonk.build/pkg/task"
 )
 
 const NoConcurrencyLimit int = -1
 
+// New creates a scheduler which executes at most maxConcurrency tasks at once, or any number if it isn't positive.
 func New(exec executor.Executor, maxConcurrency int) *Scheduler {
 	return &Scheduler{
-		Executor:       exec,
-		maxConcurrency: maxConcurrency,
+		Executor:    exec,
+		resources:   newResourcePool(),
+		slots:       newPrioritySlots(maxConcurrency),
+		executed:    make(map[task.SessionID]map[task.ID]*executedTask),
+		definitions: make(map[task.SessionID]map[string]*executedTask),
 	}
 }
 
 type Scheduler struct {
 	executor.Executor
 
-	maxConcurrency int
+	resources   *resourcePool
+	slots       *prioritySlots
+	estimator   Estimator
+	facts       task.Facts
+	onSkip      SkipHandler
+	onFollowups FollowupHandler
+
+	executedMu sync.Mutex
+	executed   map[task.SessionID]map[task.ID]*executedTask
+	// definitions indexes the claimed tasks of each session by what they execute, see [definitionKey].
+	definitions map[task.SessionID]map[string]*executedTask
+
+	slotsMu   sync.Mutex
+	freeSlots []int
+	numSlots  int
+}
+
+// SetResourceLimit sets the amount of the named resource available to tasks executing at once.
+func (s *Scheduler) SetResourceLimit(name string, capacity int) {
+	s.resources.setLimit(name, capacity)
+}
+
+// SetExecutorLimits constrains the tasks routed to the executor route, and the executors beneath it.
+func (s *Scheduler) SetExecutorLimits(route string, limits ExecutorLimits) {
+	s.resources.setExecutorLimits(route, limits)
+}
+
+// SetMatcher sets how tasks are matched against the routes of executor limits, such as to resolve the aliases of a
+// router.
+func (s *Scheduler) SetMatcher(matcher router.Matcher) {
+	s.resources.mu.Lock()
+	defer s.resources.mu.Unlock()
+
+	s.resources.matcher = matcher
+}
+
+// FollowupHandler is called with the followups returned by a task, once their IDs are qualified by it.
+type FollowupHandler func(session task.Session, parent *task.Task, followups []*task.Task)
+
+// SetFollowupHandler sets the function called with the followups of each task, before they're executed.
+// It may be called concurrently.
+func (s *Scheduler) SetFollowupHandler(handler FollowupHandler) {
+	s.onFollowups = handler
+}
+
+// SetEstimator sets how task durations are estimated.
+// When every slot is taken, tasks on the longest estimated chain of remaining work are executed first.
+func (s *Scheduler) SetEstimator(estimator Estimator) {
+	s.estimator = estimator
 }
 
 // Execute implements executor.Executor.
 // Execute will execute the task and all of it's followups, as well as wait for dependencies to resolve.
+// Identical tasks are only executed once per session, including followups with the same name from different tasks,
+// which share the outputs of the first to be executed.
 func (s *Scheduler) Execute(
 	ctx context.Context,
 	session task.Session,
 	tsk *task.Task,
 	result *task.Result,
 ) error {
+	err := s.expectTasks(session, []*task.Task{tsk}, "")
+	if err != nil {
+		return err
+	}
+
 	errgrp, ctx := errgroup.WithContext(ctx)
-	errgrp.SetLimit(s.maxConcurrency)
 
-	err := s.executeImpl(errgrp, ctx, session, tsk, result)
+	err = s.executeImpl(errgrp, ctx, session, tsk, s.estimate(session, tsk), result)
 	if err != nil {
 		return err
 	}
@@ -55,12 +120,20 @@ func (s *Scheduler) ExecuteMany(
 	tsks []*task.Task,
 	result *task.Result,
 ) error {
+	err := s.expectTasks(session, tsks, "")
+	if err != nil {
+		return err
+	}
+
 	errgrp, ctx := errgroup.WithContext(ctx)
-	errgrp.SetLimit(s.maxConcurrency)
+
+	priorities := criticalPaths(func(tsk *task.Task) time.Duration {
+		return s.estimate(session, tsk)
+	}, tsks)
 
 	for _, tsk := range tsks {
 		errgrp.Go(func() error {
-			return s.executeImpl(errgrp, ctx, session, tsk, result)
+			return s.executeImpl(errgrp, ctx, session, tsk, priorities[tsk.ID], result)
 		})
 	}
 
@@ -72,21 +145,95 @@ func (s *Scheduler) executeImpl(
 	ctx context.Context,
 	session task.Session,
 	tsk *task.Task,
+	priority time.Duration,
 	result *task.Result,
-) error {
-	localRes := task.Result{}
+) (err error) {
+	// Tasks with the same ID share outputs, so identical tasks are only executed once per session
+	claimed, original, err := s.claimTask(session, tsk)
+	if err != nil {
+		return err
+	}
+	if original != nil {
+		slog.DebugContext(ctx, "coalescing duplicate task", "task", tsk.ID, "original", original.id)
+
+		if claimed == nil {
+			return original.wait(ctx, result)
+		}
+
+		return claimed.follow(ctx, original, result)
+	}
+
+	var (
+		localRes task.Result
+		skipped  bool
+	)
+	defer func() {
+		claimed.finish(&localRes, skipped, err)
+	}()
+
+	// Conditions are evaluated before the task occupies a slot, since they may wait for upstream tasks
+	skipped, err = s.skip(ctx, session, tsk)
+	if err != nil || skipped {
+		return err
+	}
+
+	err = s.slots.acquire(ctx, priority)
+	if err != nil {
+		return fmt.Errorf("failed to schedule %s: %w", tsk.ID, err)
+	}
+
+	// Resources are only taken once the task is next to execute, so that they aren't held while it's queued
+	required := s.resources.requirements(tsk)
+	if len(required) > 0 {
+		region := profile.Begin(ctx, "scheduler", "wait for resources "+tsk.ID.String())
+		err = s.resources.acquire(ctx, required)
+		region.End()
+
+		if err != nil {
+			s.slots.release()
+
+			return fmt.Errorf("failed to schedule %s: %w", tsk.ID, err)
+		}
+	}
+
+	// Record the task in the lane of the concurrency slot it occupies
+	slot := s.acquireSlot()
+	ctx = profile.WithLane(ctx, fmt.Sprintf("slot %d", slot))
+	region := profile.Begin(ctx, "task", tsk.ID.String())
+	region.SetArg("executor", tsk.Executor)
+
+	err = s.Executor.Execute(ctx, session, tsk, &localRes)
+
+	region.End()
+	s.releaseSlot(slot)
+	s.slots.release()
+	s.resources.release(required)
 
-	err := s.Executor.Execute(ctx, session, tsk, &localRes)
 	if err != nil {
 		return err
 	}
 
-	for _, followup := range localRes.GetFollowupTasks() {
-		errgrp.Go(func() error {
-			// Update the ID to be the child of this task.
-			followup.ID = tsk.ID.GetChild(followup.ID.String())
+	followups := localRes.GetFollowupTasks()
+	for _, followup := range followups {
+		// Update the ID to be the child of this task.
+		followup.ID = tsk.ID.GetChild(followup.ID.String())
+	}
+
+	followups, err = task.ExpandMatrices(followups)
+	if err != nil {
+		return fmt.Errorf("failed to expand followups of %s: %w", tsk.ID, err)
+	}
+	err = s.expectTasks(session, followups, tsk.ID)
+	if err != nil {
+		return fmt.Errorf("failed to schedule followups of %s: %w", tsk.ID, err)
+	}
+	if s.onFollowups != nil && len(followups) > 0 {
+		s.onFollowups(session, tsk, followups)
+	}
 
-			return s.executeImpl(errgrp, ctx, session, followup, result)
+	for _, followup := range followups {
+		errgrp.Go(func() error {
+			return s.executeImpl(errgrp, ctx, session, followup, s.estimate(session, followup), result)
 		})
 	}
 
@@ -95,3 +242,39 @@ func (s *Scheduler) executeImpl(
 
 	return nil
 }
+
+// estimate returns the estimated duration of tsk and its followups, or 0 if it can't be estimated.
+func (s *Scheduler) estimate(session task.Session, tsk *task.Task) time.Duration {
+	if s.estimator == nil {
+		return 0
+	}
+
+	duration, _ := s.estimator(session, tsk)
+
+	return duration
+}
+
+// acquireSlot returns the lowest concurrency slot not currently occupied by a task.
+func (s *Scheduler) acquireSlot() int {
+	s.slotsMu.Lock()
+	defer s.slotsMu.Unlock()
+
+	if len(s.freeSlots) == 0 {
+		s.numSlots++
+
+		return s.numSlots - 1
+	}
+
+	slot := s.freeSlots[0]
+	s.freeSlots = s.freeSlots[1:]
+
+	return slot
+}
+
+func (s *Scheduler) releaseSlot(slot int) {
+	s.slotsMu.Lock()
+	defer s.slotsMu.Unlock()
+
+	idx, _ := slices.BinarySearch(s.freeSlots, slot)
+	s.freeSlots = slices.Insert(s.freeSlots, idx, slot)
+}
@@ -6,14 +6,20 @@ package scheduler_test
 import (
 	"context"
 	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"testing/synctest"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"go.bonk.build/pkg/executor/mockexec"
+	"go.bonk.build/pkg/executor/router"
 	"go.bonk.build/pkg/executor/scheduler"
+	"go.bonk.build/pkg/profile"
 	"go.bonk.build/pkg/task"
 )
 
@@ -148,3 +154,447 @@ func TestFollowupsErrs(t *testing.T) {
 	err = sched.Execute(t.Context(), session, tsk, &res)
 	require.ErrorIs(t, err, assert.AnError)
 }
+
+func TestProfileSlots(t *testing.T) {
+	t.Parallel()
+
+	exec := mockexec.NewMockExecutor(t)
+	session := task.NewTestSession()
+	prof := profile.New()
+	ctx := profile.NewContext(t.Context(), prof)
+
+	sched := scheduler.New(exec, 1)
+
+	exec.EXPECT().Execute(mock.Anything, session, mock.Anything, mock.Anything).Return(nil).Times(3)
+
+	err := sched.ExecuteMany(ctx, session, []*task.Task{
+		task.New("a", "none", nil),
+		task.New("b", "none", nil),
+		task.New("c", "none", nil),
+	}, &task.Result{})
+	require.NoError(t, err)
+
+	// With a concurrency of 1, every task should occupy the same slot
+	lanes := make(map[int]int)
+	for _, event := range prof.Events() {
+		if event.Category == "task" {
+			lanes[event.TID]++
+		}
+	}
+	assert.Equal(t, map[int]int{0: 3}, lanes)
+}
+
+func TestResourceLimits(t *testing.T) {
+	t.Parallel()
+
+	exec := mockexec.NewMockExecutor(t)
+	session := task.NewTestSession()
+
+	sched := scheduler.New(exec, scheduler.NoConcurrencyLimit)
+	sched.SetResourceLimit("cpu", 4)
+	sched.SetExecutorLimits("kube", scheduler.ExecutorLimits{Concurrency: 1})
+
+	var (
+		mu         sync.Mutex
+		cpu, kube  int
+		maxCPU     int
+		maxKube    int
+		executions int
+	)
+	exec.EXPECT().Execute(mock.Anything, session, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ task.Session, tsk *task.Task, _ *task.Result) error {
+			isKube := strings.HasPrefix(tsk.Executor, "kube.")
+
+			mu.Lock()
+			cpu += tsk.Resources["cpu"]
+			if isKube {
+				kube++
+			}
+			maxCPU = max(maxCPU, cpu)
+			maxKube = max(maxKube, kube)
+			executions++
+			mu.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			mu.Lock()
+			cpu -= tsk.Resources["cpu"]
+			if isKube {
+				kube--
+			}
+			mu.Unlock()
+
+			return nil
+		})
+
+	tsks := make([]*task.Task, 0, 8)
+	for idx := range 4 {
+		tsks = append(tsks,
+			task.New(task.NewID("cpu", strconv.Itoa(idx)), "none", nil, task.WithResources(task.Resources{"cpu": 3})),
+			task.New(task.NewID("kube", strconv.Itoa(idx)), "kube.apply", nil),
+		)
+	}
+
+	require.NoError(t, sched.ExecuteMany(t.Context(), session, tsks, &task.Result{}))
+
+	assert.Equal(t, 8, executions)
+	assert.LessOrEqual(t, maxCPU, 4)
+	assert.Equal(t, 1, maxKube)
+}
+
+func TestResourceLimitExceeded(t *testing.T) {
+	t.Parallel()
+
+	exec := mockexec.NewMockExecutor(t)
+	session := task.NewTestSession()
+
+	sched := scheduler.New(exec, scheduler.NoConcurrencyLimit)
+	sched.SetResourceLimit("cpu", 4)
+
+	err := sched.Execute(t.Context(), session,
+		task.New("big", "none", nil, task.WithResources(task.Resources{"cpu": 8})),
+		&task.Result{})
+	require.ErrorIs(t, err, scheduler.ErrInsufficientResources)
+}
+
+func TestCriticalPathPriority(t *testing.T) { //nolint:paralleltest
+	synctest.Test(t, func(t *testing.T) {
+		exec := mockexec.NewMockExecutor(t)
+		session := task.NewTestSession()
+
+		estimates := map[task.ID]time.Duration{
+			"blocker":   time.Second,
+			"short":     time.Second,
+			"dependent": 10 * time.Second,
+			"medium":    5 * time.Second,
+			"tiny":      time.Millisecond,
+		}
+
+		sched := scheduler.New(exec, 1)
+		sched.SetEstimator(func(_ task.Session, tsk *task.Task) (time.Duration, bool) {
+			estimate, ok := estimates[tsk.ID]
+
+			return estimate, ok
+		})
+
+		var order []task.ID
+		exec.EXPECT().Execute(mock.Anything, session, mock.Anything, mock.Anything).
+			RunAndReturn(func(_ context.Context, _ task.Session, tsk *task.Task, _ *task.Result) error {
+				order = append(order, tsk.ID)
+				time.Sleep(estimates[tsk.ID])
+
+				return nil
+			})
+
+		// The blocker occupies the only slot until the other tasks are waiting for it
+		blocked := make(chan error)
+		go func() {
+			blocked <- sched.Execute(t.Context(), session, task.New("blocker", "none", nil), &task.Result{})
+		}()
+		synctest.Wait()
+
+		err := sched.ExecuteMany(t.Context(), session, []*task.Task{
+			task.New("tiny", "none", nil),
+			task.New("medium", "none", nil),
+			task.New("dependent", "none", nil, task.WithCondition(task.Condition{
+				Results: map[task.ID]task.Outcome{"short": task.OutcomeExecuted},
+			})),
+			task.New("short", "none", nil),
+		}, &task.Result{})
+		require.NoError(t, err)
+		require.NoError(t, <-blocked)
+
+		// short is on the critical path, as dependent can't start without it, so it's executed before medium
+		assert.Equal(t, []task.ID{"blocker", "short", "medium", "dependent", "tiny"}, order)
+	})
+}
+
+func TestResourcesTakenOnceScheduled(t *testing.T) { //nolint:paralleltest
+	synctest.Test(t, func(t *testing.T) {
+		exec := mockexec.NewMockExecutor(t)
+		session := task.NewTestSession()
+
+		estimates := map[task.ID]time.Duration{
+			"blocker": time.Second,
+			"low":     time.Second,
+			"high":    10 * time.Second,
+		}
+
+		sched := scheduler.New(exec, 1)
+		sched.SetResourceLimit("kube-api", 1)
+		sched.SetEstimator(func(_ task.Session, tsk *task.Task) (time.Duration, bool) {
+			estimate, ok := estimates[tsk.ID]
+
+			return estimate, ok
+		})
+
+		var order []task.ID
+		exec.EXPECT().Execute(mock.Anything, session, mock.Anything, mock.Anything).
+			RunAndReturn(func(_ context.Context, _ task.Session, tsk *task.Task, _ *task.Result) error {
+				order = append(order, tsk.ID)
+				time.Sleep(estimates[tsk.ID])
+
+				return nil
+			})
+
+		blocked := make(chan error)
+		go func() {
+			blocked <- sched.Execute(t.Context(), session, task.New("blocker", "none", nil), &task.Result{})
+		}()
+		synctest.Wait()
+
+		// Whichever task queues first doesn't keep the resource from the other while waiting for the slot
+		kube := task.WithResources(task.Resources{"kube-api": 1})
+		err := sched.ExecuteMany(t.Context(), session, []*task.Task{
+			task.New("high", "none", nil, kube),
+			task.New("low", "none", nil, kube),
+		}, &task.Result{})
+		require.NoError(t, err)
+		require.NoError(t, <-blocked)
+
+		assert.Equal(t, []task.ID{"blocker", "high", "low"}, order)
+	})
+}
+
+func TestDuplicateTasksCoalesced(t *testing.T) {
+	t.Parallel()
+
+	exec := mockexec.NewMockExecutor(t)
+	session := task.NewTestSession()
+	sched := scheduler.New(exec, scheduler.NoConcurrencyLimit)
+
+	args := map[string]any{"value": 1}
+
+	exec.EXPECT().Execute(mock.Anything, session, task.TaskIDMatches("a"), mock.Anything).
+		RunAndReturn(func(_ context.Context, _ task.Session, _ *task.Task, res *task.Result) error {
+			res.AddFollowupTasks(task.New("shared", "none", args))
+
+			return nil
+		}).Once()
+	exec.EXPECT().Execute(mock.Anything, session, task.TaskIDMatches("b"), mock.Anything).
+		RunAndReturn(func(_ context.Context, _ task.Session, _ *task.Task, res *task.Result) error {
+			res.AddFollowupTasks(task.New("shared", "none", args))
+
+			return nil
+		}).Once()
+	exec.EXPECT().Execute(mock.Anything, session, mock.MatchedBy(isShared), mock.Anything).
+		RunAndReturn(func(_ context.Context, _ task.Session, _ *task.Task, res *task.Result) error {
+			res.AddOutputs("shared.yaml")
+
+			return nil
+		}).Once()
+
+	// Identical tasks are only executed once, whether they're followups or top-level tasks
+	tsks := []*task.Task{
+		task.New("a", "none", nil),
+		task.New("a", "none", nil),
+		task.New("a.shared", "none", args),
+		task.New("b", "none", nil),
+	}
+
+	result := task.Result{}
+	require.NoError(t, sched.ExecuteMany(t.Context(), session, tsks, &result))
+	assert.Contains(t, result.GetOutputs(), "shared.yaml")
+}
+
+func TestDuplicateFollowupsCoalesced(t *testing.T) {
+	t.Parallel()
+
+	exec := mockexec.NewMockExecutor(t)
+	session := task.NewTestSession()
+	sched := scheduler.New(exec, scheduler.NoConcurrencyLimit)
+
+	// Both parents produce the same followup, such as components emitting the same resources
+	for _, parent := range []task.ID{"a", "b"} {
+		exec.EXPECT().Execute(mock.Anything, session, task.TaskIDMatches(parent), mock.Anything).
+			RunAndReturn(func(_ context.Context, _ task.Session, _ *task.Task, res *task.Result) error {
+				res.AddFollowupTasks(
+					task.New("shared", "none", map[string]any{"value": 1}, task.WithInputs("resources.yaml")),
+					task.New("own", "none", map[string]any{"parent": parent}),
+				)
+
+				return nil
+			}).Once()
+		exec.EXPECT().Execute(mock.Anything, session, task.TaskIDMatches(parent.GetChild("own")), mock.Anything).
+			Return(nil).Once()
+	}
+
+	// Whichever followup is claimed first is executed, and the other shares its outputs
+	exec.EXPECT().Execute(mock.Anything, session, mock.MatchedBy(isShared), mock.Anything).
+		RunAndReturn(func(_ context.Context, _ task.Session, _ *task.Task, res *task.Result) error {
+			res.AddOutputs("shared.yaml")
+
+			return nil
+		}).Once()
+
+	result := task.Result{}
+	require.NoError(t, sched.ExecuteMany(t.Context(), session, []*task.Task{
+		task.New("a", "none", nil),
+		task.New("b", "none", nil),
+	}, &result))
+
+	assert.Equal(t, []string{"shared.yaml", "shared.yaml"}, result.GetOutputs())
+}
+
+func isShared(tsk *task.Task) bool {
+	return strings.HasSuffix(tsk.ID.String(), ".shared")
+}
+
+func TestDuplicateTasksConflicting(t *testing.T) {
+	t.Parallel()
+
+	exec := mockexec.NewMockExecutor(t)
+	session := task.NewTestSession()
+	sched := scheduler.New(exec, 1)
+
+	exec.EXPECT().Execute(mock.Anything, session, mock.Anything, mock.Anything).Return(nil).Once()
+	exec.EXPECT().CloseSession(mock.Anything, session.ID())
+
+	require.NoError(t, sched.Execute(t.Context(), session, task.New("a", "none", 1), &task.Result{}))
+
+	err := sched.Execute(t.Context(), session, task.New("a", "none", 2), &task.Result{})
+	require.ErrorIs(t, err, scheduler.ErrConflictingTask)
+
+	// Closing the session forgets its tasks
+	sched.CloseSession(t.Context(), session.ID())
+	exec.EXPECT().Execute(mock.Anything, session, mock.Anything, mock.Anything).Return(nil).Once()
+	require.NoError(t, sched.Execute(t.Context(), session, task.New("a", "none", 2), &task.Result{}))
+}
+
+func TestConditions(t *testing.T) {
+	t.Parallel()
+
+	exec := mockexec.NewMockExecutor(t)
+	// Mocks print their arguments while conditions read the session's files, which in-memory filesystems can't share
+	session := task.NewLocalSession(task.NewSessionID(), t.TempDir())
+	sched := scheduler.New(exec, scheduler.NoConcurrencyLimit)
+	sched.SetFacts(task.Facts{Profiles: []string{"ci"}})
+
+	var (
+		skipped   []task.ID
+		skippedMu sync.Mutex
+	)
+	sched.SetSkipHandler(func(_ task.Session, tsk *task.Task) {
+		skippedMu.Lock()
+		defer skippedMu.Unlock()
+
+		skipped = append(skipped, tsk.ID)
+	})
+
+	exec.EXPECT().Execute(mock.Anything, session, task.TaskIDMatches("Validate"), mock.Anything).
+		RunAndReturn(func(_ context.Context, _ task.Session, _ *task.Task, res *task.Result) error {
+			res.AddOutputs("report.xml")
+
+			return nil
+		}).Once()
+	exec.EXPECT().Execute(mock.Anything, session, task.TaskIDMatches("Report"), mock.Anything).Return(nil).Once()
+	exec.EXPECT().Execute(mock.Anything, session, task.TaskIDMatches("Upload"), mock.Anything).Return(nil).Once()
+
+	tsks := []*task.Task{
+		// Conditions may wait for tasks scheduled after them
+		task.New("Report", "none", nil, task.WithCondition(task.Condition{
+			Results: map[task.ID]task.Outcome{"Validate": task.OutcomeExecuted, "Helm": task.OutcomeSkipped},
+		})),
+		task.New("Validate", "none", nil, task.WithCondition(task.Condition{Profiles: []string{"ci"}})),
+		task.New("Helm", "none", nil, task.WithCondition(task.Condition{Exists: []string{"charts/*"}})),
+		task.New("Publish", "none", nil, task.WithCondition(task.Condition{
+			Results: map[task.ID]task.Outcome{"Helm": task.OutcomeExecuted},
+		})),
+		// Conditions may check the outputs of upstream tasks too
+		task.New("Upload", "none", nil, task.WithCondition(task.Condition{
+			Outputs: map[task.ID][]string{"Validate": {"*.xml"}},
+		})),
+		task.New("Package", "none", nil, task.WithCondition(task.Condition{
+			Outputs: map[task.ID][]string{"Helm": {"*.tgz"}},
+		})),
+	}
+
+	require.NoError(t, sched.ExecuteMany(t.Context(), session, tsks, &task.Result{}))
+	assert.ElementsMatch(t, []task.ID{"Helm", "Publish", "Package"}, skipped)
+
+	// Builds may be executed with their own facts
+	exec.EXPECT().Execute(mock.Anything, session, task.TaskIDMatches("Debug"), mock.Anything).Return(nil).Once()
+	ctx := task.ContextWithFacts(t.Context(), task.Facts{Profiles: []string{"dev"}})
+	require.NoError(t, sched.Execute(ctx, session, task.New("Debug", "none", nil, task.WithCondition(task.Condition{
+		Profiles: []string{"dev"},
+	})), &task.Result{}))
+
+	// Conditions can't check tasks which aren't scheduled
+	err := sched.Execute(t.Context(), session, task.New("Deploy", "none", nil, task.WithCondition(task.Condition{
+		Results: map[task.ID]task.Outcome{"Build": task.OutcomeExecuted},
+	})), &task.Result{})
+	require.ErrorIs(t, err, scheduler.ErrUnknownUpstream)
+
+	// Nor the followups of other tasks, which may not have been returned yet
+	err = sched.Execute(t.Context(), session, task.New("Deploy", "none", nil, task.WithCondition(task.Condition{
+		Results: map[task.ID]task.Outcome{"Validate.Lint": task.OutcomeExecuted},
+	})), &task.Result{})
+	require.ErrorIs(t, err, scheduler.ErrUnknownUpstream)
+
+	// Followups may check the outcomes of the other followups returned with them
+	exec.EXPECT().Execute(mock.Anything, session, task.TaskIDMatches("Chart"), mock.Anything).
+		RunAndReturn(func(_ context.Context, _ task.Session, _ *task.Task, res *task.Result) error {
+			res.AddFollowupTasks(
+				task.New("Lint", "none", nil),
+				task.New("Package", "none", nil, task.WithCondition(task.Condition{
+					Results: map[task.ID]task.Outcome{"Chart.Lint": task.OutcomeExecuted},
+				})),
+			)
+
+			return nil
+		}).Once()
+	exec.EXPECT().Execute(mock.Anything, session, task.TaskIDMatches("Chart.Lint"), mock.Anything).Return(nil).Once()
+	exec.EXPECT().Execute(mock.Anything, session, task.TaskIDMatches("Chart.Package"), mock.Anything).Return(nil).Once()
+	require.NoError(t, sched.Execute(t.Context(), session, task.New("Chart", "none", nil), &task.Result{}))
+
+	// Conditions which wait on each other are rejected before any of their tasks are executed
+	err = sched.ExecuteMany(t.Context(), session, []*task.Task{
+		task.New("Plan", "none", nil, task.WithCondition(task.Condition{
+			Results: map[task.ID]task.Outcome{"Apply": task.OutcomeExecuted},
+		})),
+		task.New("Apply", "none", nil, task.WithCondition(task.Condition{
+			Not: &task.Condition{Results: map[task.ID]task.Outcome{"Plan": task.OutcomeSkipped}},
+		})),
+	}, &task.Result{})
+	require.ErrorIs(t, err, scheduler.ErrConditionCycle)
+}
+
+func TestDuplicateTasksRouted(t *testing.T) { //nolint:paralleltest
+	synctest.Test(t, func(t *testing.T) {
+		exec := mockexec.NewMockExecutor(t)
+		session := task.NewTestSession()
+
+		// Routers rewrite the executor of the tasks they route while they execute
+		started := make(chan struct{})
+		exec.EXPECT().Execute(mock.Anything, session, task.TaskIDMatches("A"), mock.Anything).
+			RunAndReturn(func(_ context.Context, _ task.Session, _ *task.Task, res *task.Result) error {
+				close(started)
+				time.Sleep(time.Second)
+				res.AddOutputs("a.txt")
+
+				return nil
+			}).Once()
+
+		rtr := router.New()
+		require.NoError(t, rtr.RegisterExecutor("x.y", exec))
+		sched := scheduler.New(&rtr, 4)
+
+		first := task.New("A", "x.y", map[string]any{"value": []any{"a"}})
+		second := task.New("A", "x.y", map[string]any{"value": []any{"a"}})
+
+		var (
+			group  sync.WaitGroup
+			result task.Result
+		)
+		group.Go(func() {
+			assert.NoError(t, sched.Execute(t.Context(), session, first, &task.Result{}))
+		})
+		<-started
+		group.Go(func() {
+			assert.NoError(t, sched.Execute(t.Context(), session, second, &result))
+		})
+		group.Wait()
+
+		assert.Equal(t, []string{"a.txt"}, result.GetOutputs())
+	})
+}
@@ -0,0 +1,115 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package statecheck
+
+import (
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+
+	"go.bonk.build/pkg/task"
+)
+
+// inputCache remembers the checksums of tasks' input files, so that long-lived executors don't read and hash them
+// again while they're unchanged. Files are assumed to be unchanged while their modification time and size are.
+// A nil inputCache hashes the files every time.
+type inputCache struct {
+	mu      sync.Mutex
+	entries map[inputCacheKey]inputCacheEntry
+}
+
+type inputCacheKey struct {
+	root     string
+	patterns string
+}
+
+type inputCacheEntry struct {
+	stamps   []fileStamp
+	checksum uint64
+}
+
+type fileStamp struct {
+	name    string
+	modTime time.Time
+	size    int64
+}
+
+func newInputCache() *inputCache {
+	return &inputCache{
+		entries: make(map[inputCacheKey]inputCacheEntry),
+	}
+}
+
+// checksum returns the checksum of the session's source files matching patterns.
+func (c *inputCache) checksum(session task.Session, patterns []string) (uint64, error) {
+	// Only local sessions have a root which identifies them across sessions
+	localSession, ok := session.(task.LocalSession)
+	if c == nil || !ok {
+		return hashFiles(fnv.New64(), session.SourceFS(), patterns)
+	}
+
+	stamps, err := stampFiles(session.SourceFS(), patterns)
+	if err != nil {
+		return 0, err
+	}
+
+	key := inputCacheKey{
+		root:     localSession.LocalPath(),
+		patterns: strings.Join(patterns, "\x00"),
+	}
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+
+	if ok && slices.Equal(entry.stamps, stamps) {
+		return entry.checksum, nil
+	}
+
+	checksum, err := hashFiles(fnv.New64(), session.SourceFS(), patterns)
+	if err != nil {
+		return 0, err
+	}
+
+	c.mu.Lock()
+	c.entries[key] = inputCacheEntry{
+		stamps:   stamps,
+		checksum: checksum,
+	}
+	c.mu.Unlock()
+
+	return checksum, nil
+}
+
+// stampFiles stats the files matching patterns, in the order they're hashed.
+func stampFiles(root afero.Fs, patterns []string) ([]fileStamp, error) {
+	var stamps []fileStamp
+
+	for _, pattern := range patterns {
+		matches, err := afero.Glob(root, pattern)
+		if err != nil {
+			return nil, fmt.Errorf("failed to expand glob '%s': %w", pattern, err)
+		}
+
+		for _, fileName := range matches {
+			info, err := root.Stat(fileName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to stat input file %s: %w", fileName, err)
+			}
+
+			stamps = append(stamps, fileStamp{
+				name:    fileName,
+				modTime: info.ModTime(),
+				size:    info.Size(),
+			})
+		}
+	}
+
+	return stamps, nil
+}
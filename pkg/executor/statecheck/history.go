@@ -0,0 +1,81 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package statecheck
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.bonk.build/pkg/task"
+)
+
+// HistoryFile is saved alongside [StateFile], recording how long the task took when it last executed.
+// Unlike the state, it survives the task's inputs changing, so that it can be used to estimate the next run.
+const HistoryFile = "history.json"
+
+type history struct {
+	Duration time.Duration `json:"duration"`
+}
+
+// SaveHistory records how long tsk took to execute.
+func SaveHistory(session task.Session, tsk *task.Task, duration time.Duration) error {
+	taskOutput := task.OutputFS(session, tsk.ID)
+
+	err := taskOutput.MkdirAll("", 0o750)
+	if err != nil {
+		return fmt.Errorf("failed to create task directory: %w", err)
+	}
+
+	file, err := taskOutput.Create(HistoryFile)
+	if err != nil {
+		return fmt.Errorf("failed to open history file %s: %w", HistoryFile, err)
+	}
+	defer file.Close()
+
+	err = json.NewEncoder(file).Encode(history{Duration: duration})
+	if err != nil {
+		return fmt.Errorf("failed to encode history file %s: %w", HistoryFile, err)
+	}
+
+	return nil
+}
+
+// EstimateDuration estimates how long tsk and the longest chain of its followups will take to execute,
+// from how long they took when they last executed. It returns false if tsk has never executed.
+func EstimateDuration(session task.Session, tsk *task.Task) (time.Duration, bool) {
+	return estimateDuration(session, tsk.ID)
+}
+
+func estimateDuration(session task.Session, id task.ID) (time.Duration, bool) {
+	taskOutput := task.OutputFS(session, id)
+
+	file, err := taskOutput.Open(HistoryFile)
+	if err != nil {
+		return 0, false
+	}
+	defer file.Close()
+
+	var hist history
+	err = json.NewDecoder(file).Decode(&hist)
+	if err != nil {
+		return 0, false
+	}
+
+	// The followups from the last successful run are the best guess at what this run will produce
+	var longestFollowup time.Duration
+	if stateFile, err := taskOutput.Open(StateFile); err == nil {
+		defer stateFile.Close()
+
+		var state state
+		if json.NewDecoder(stateFile).Decode(&state) == nil {
+			for _, followup := range state.Result.GetFollowupTasks() {
+				followupDuration, _ := estimateDuration(session, id.GetChild(followup.ID.String()))
+				longestFollowup = max(longestFollowup, followupDuration)
+			}
+		}
+	}
+
+	return hist.Duration + longestFollowup, true
+}
@@ -2,24 +2,31 @@
 // SPDX-License-Identifier: MIT
 
 // Package statecheck provides an executor that avoids re-running tasks if they are already up to date.
-// State files are saved in the task's output fs as [StateFile].
+// State files are saved in the task's output fs as [StateFile], alongside the task's [HistoryFile].
 package statecheck
 
 import (
 	"context"
 	"log/slog"
+	"time"
 
 	"go.bonk.build/pkg/executor"
+	"go.bonk.build/pkg/profile"
 	"go.bonk.build/pkg/task"
 )
 
 type statechecker struct {
 	executor.Executor
+
+	inputs *inputCache
 }
 
+// New creates an executor which only executes tasks through child if their state has changed.
+// The checksums of unchanged input files are remembered for as long as the executor lives.
 func New(child executor.Executor) executor.Executor {
 	return statechecker{
 		Executor: child,
+		inputs:   newInputCache(),
 	}
 }
 
@@ -30,24 +37,45 @@ func (s statechecker) Execute(
 	tsk *task.Task,
 	result *task.Result,
 ) error {
-	mismatches, res := DetectStateMismatches(session, tsk)
+	region := profile.Begin(ctx, "statecheck", "check state")
+	mismatches, res := detectStateMismatches(session, tsk, s.inputs)
+	region.SetArg("cache-hit", mismatches == nil)
+	region.End()
+
 	if mismatches == nil {
 		slog.DebugContext(ctx, "states match, skipping task")
+		profile.Instant(ctx, "statecheck", "cache hit", map[string]any{"task": tsk.ID})
 		result.Append(res)
+		result.MarkCached()
 
 		return nil
 	}
 
 	slog.DebugContext(ctx, "state mismatch, running task", "mismatches", mismatches)
 
+	region = profile.Begin(ctx, "executor", "execute")
+	region.SetArg("mismatches", mismatches)
+	start := time.Now()
 	err := s.Executor.Execute(ctx, session, tsk, result)
+	duration := time.Since(start)
+	region.End()
+
 	if err != nil {
 		return err
 	}
 
+	// The history is only used for estimates, so failing to save it doesn't fail the task
+	err = SaveHistory(session, tsk, duration)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to save task history", "error", err)
+	}
+
 	slog.DebugContext(ctx, "task succeeded, saving state")
 
-	err = SaveState(session, tsk, result)
+	region = profile.Begin(ctx, "statecheck", "save state")
+	err = saveState(session, tsk, result, s.inputs)
+	region.End()
+
 	if err != nil {
 		slog.WarnContext(ctx, "failed to save task state", "error", err)
 
@@ -5,6 +5,7 @@ package statecheck_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
@@ -36,8 +37,11 @@ func TestStateCheck_SaveState(t *testing.T) {
 	followupRes := task.Result{}
 	err = checker.Execute(t.Context(), session, tsk, &followupRes)
 	require.NoError(t, err)
-	// Verify that the result returned is the same the second time as the first
-	assert.Equal(t, result, &followupRes)
+	// Verify that the result returned is the same the second time as the first, but marked as cached
+	assert.Equal(t, result.GetOutputs(), followupRes.GetOutputs())
+	assert.Equal(t, result.GetFollowupTasks(), followupRes.GetFollowupTasks())
+	assert.False(t, result.IsCached())
+	assert.True(t, followupRes.IsCached())
 }
 
 func TestStateCheck_ExecFailure(t *testing.T) {
@@ -81,3 +85,73 @@ func TestStateCheck_StateMismatches_Args(t *testing.T) {
 	err = checker.Execute(t.Context(), session, tsk, result)
 	require.NoError(t, err)
 }
+
+func TestStateCheck_EstimateDuration(t *testing.T) {
+	t.Parallel()
+
+	session := task.NewTestSession()
+	tsk := task.New(task.NewID("parent"), "exec", nil)
+
+	_, ok := statecheck.EstimateDuration(session, tsk)
+	require.False(t, ok)
+
+	// The parent's followups are estimated from their own history
+	result := task.Result{}
+	result.AddFollowupTasks(
+		task.New(task.NewID("fast"), "exec", nil),
+		task.New(task.NewID("slow"), "exec", nil),
+	)
+	require.NoError(t, statecheck.SaveState(session, tsk, &result))
+	require.NoError(t, statecheck.SaveHistory(session, tsk, time.Second))
+	require.NoError(t, statecheck.SaveHistory(session, task.New(tsk.ID.GetChild("fast"), "exec", nil), time.Second))
+	require.NoError(t, statecheck.SaveHistory(session, task.New(tsk.ID.GetChild("slow"), "exec", nil), time.Minute))
+
+	estimate, ok := statecheck.EstimateDuration(session, tsk)
+	require.True(t, ok)
+	assert.Equal(t, time.Second+time.Minute, estimate)
+}
+
+func TestStateCheck_SaveHistory(t *testing.T) {
+	t.Parallel()
+
+	exec := mockexec.NewMockExecutor(t)
+	checker := statecheck.New(exec)
+	tsk, result := makeTestTask(t)
+	session := task.NewTestSession()
+
+	exec.EXPECT().Execute(t.Context(), session, tsk, result).Return(nil)
+	require.NoError(t, checker.Execute(t.Context(), session, tsk, result))
+
+	exists, err := afero.Exists(task.OutputFS(session, tsk.ID), statecheck.HistoryFile)
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	_, ok := statecheck.EstimateDuration(session, tsk)
+	assert.True(t, ok)
+}
+
+func TestStateCheck_InputCache(t *testing.T) {
+	t.Parallel()
+
+	exec := mockexec.NewMockExecutor(t)
+	checker := statecheck.New(exec)
+	tsk, result := makeTestTask(t)
+	tsk.Inputs = []string{"input.txt"}
+	// Local sessions are read-only, so the inputs are written around them
+	root := t.TempDir()
+	rootFS := afero.NewBasePathFs(afero.NewOsFs(), root)
+	session := task.NewLocalSession(task.NewSessionID(), root)
+	require.NoError(t, afero.WriteFile(rootFS, "input.txt", []byte("a"), 0o600))
+
+	exec.EXPECT().Execute(t.Context(), session, tsk, result).Return(nil).Times(2)
+
+	require.NoError(t, checker.Execute(t.Context(), session, tsk, result))
+
+	cachedRes := task.Result{}
+	require.NoError(t, checker.Execute(t.Context(), session, tsk, &cachedRes))
+	assert.True(t, cachedRes.IsCached())
+
+	// Changing the input invalidates the remembered checksum
+	require.NoError(t, afero.WriteFile(rootFS, "input.txt", []byte("changed"), 0o600))
+	require.NoError(t, checker.Execute(t.Context(), session, tsk, result))
+}
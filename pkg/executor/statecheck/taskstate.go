@@ -33,6 +33,10 @@ type state struct {
 }
 
 func SaveState(session task.Session, tsk *task.Task, result *task.Result) error {
+	return saveState(session, tsk, result, nil)
+}
+
+func saveState(session task.Session, tsk *task.Task, result *task.Result, inputs *inputCache) error {
 	taskOutput := task.OutputFS(session, tsk.ID)
 
 	err := taskOutput.MkdirAll("", 0o750)
@@ -62,11 +66,10 @@ func SaveState(session task.Session, tsk *task.Task, result *task.Result) error
 	hasher.Reset()
 
 	// Hash the input files
-	state.InputsChecksum, err = hashFiles(hasher, session.SourceFS(), tsk.Inputs)
+	state.InputsChecksum, err = inputs.checksum(session, tsk.Inputs)
 	if err != nil {
 		return err
 	}
-	hasher.Reset()
 
 	// Hash the output files
 	state.OutputChecksum, err = hashFiles(hasher, taskOutput, result.GetOutputs())
@@ -89,6 +92,10 @@ func SaveState(session task.Session, tsk *task.Task, result *task.Result) error
 }
 
 func DetectStateMismatches(session task.Session, tsk *task.Task) ([]string, *task.Result) {
+	return detectStateMismatches(session, tsk, nil)
+}
+
+func detectStateMismatches(session task.Session, tsk *task.Task, inputs *inputCache) ([]string, *task.Result) {
 	taskOutput := task.OutputFS(session, tsk.ID)
 
 	file, err := taskOutput.Open(StateFile)
@@ -121,11 +128,10 @@ func DetectStateMismatches(session task.Session, tsk *task.Task) ([]string, *tas
 	if !reflect.DeepEqual(tsk.Inputs, state.Inputs) {
 		mismatches = append(mismatches, "inputs")
 	}
-	inputsChecksum, err := hashFiles(hasher, session.SourceFS(), tsk.Inputs)
+	inputsChecksum, err := inputs.checksum(session, tsk.Inputs)
 	if err != nil || inputsChecksum != state.InputsChecksum {
 		mismatches = append(mismatches, "inputs-checksum")
 	}
-	hasher.Reset()
 
 	outputChecksum, err := hashFiles(hasher, taskOutput, state.Result.GetOutputs())
 	if err != nil {
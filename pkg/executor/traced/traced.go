@@ -0,0 +1,141 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+// Package traced provides an executor which records an OpenTelemetry span per session and per task.
+//
+// Task spans are nested under the span of the task that emitted them as a followup,
+// or under their session's span for root tasks.
+package traced
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.bonk.build/pkg/executor"
+	"go.bonk.build/pkg/task"
+	"go.bonk.build/pkg/tracing"
+)
+
+const (
+	AttrSessionID    = attribute.Key("bonk.session.id")
+	AttrTaskID       = attribute.Key("bonk.task.id")
+	AttrTaskExecutor = attribute.Key("bonk.task.executor")
+)
+
+type tracedSession struct {
+	span trace.Span
+
+	mu        sync.RWMutex
+	taskSpans map[task.ID]trace.SpanContext
+}
+
+type traced struct {
+	executor.Executor
+
+	mu       sync.RWMutex
+	sessions map[task.SessionID]*tracedSession
+}
+
+var _ executor.Executor = (*traced)(nil)
+
+// New wraps child with an executor that records spans for sessions and tasks.
+func New(child executor.Executor) executor.Executor {
+	return &traced{
+		Executor: child,
+		sessions: make(map[task.SessionID]*tracedSession),
+	}
+}
+
+// OpenSession implements executor.Executor.
+func (t *traced) OpenSession(ctx context.Context, session task.Session) error {
+	ctx, span := tracing.Tracer().Start(ctx, "session",
+		trace.WithAttributes(AttrSessionID.String(session.ID().String())),
+	)
+
+	t.mu.Lock()
+	t.sessions[session.ID()] = &tracedSession{
+		span:      span,
+		taskSpans: make(map[task.ID]trace.SpanContext),
+	}
+	t.mu.Unlock()
+
+	err := t.Executor.OpenSession(ctx, session)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return err
+}
+
+// CloseSession implements executor.Executor.
+func (t *traced) CloseSession(ctx context.Context, sessionID task.SessionID) {
+	t.mu.Lock()
+	session, ok := t.sessions[sessionID]
+	delete(t.sessions, sessionID)
+	t.mu.Unlock()
+
+	if ok {
+		ctx = trace.ContextWithSpan(ctx, session.span)
+		defer session.span.End()
+	}
+
+	t.Executor.CloseSession(ctx, sessionID)
+}
+
+// Execute implements executor.Executor.
+func (t *traced) Execute(
+	ctx context.Context,
+	session task.Session,
+	tsk *task.Task,
+	result *task.Result,
+) error {
+	t.mu.RLock()
+	tracedSess, ok := t.sessions[session.ID()]
+	t.mu.RUnlock()
+
+	if ok {
+		ctx = trace.ContextWithSpanContext(ctx, tracedSess.parentOf(tsk.ID))
+	}
+
+	ctx, span := tracing.Tracer().Start(ctx, tsk.ID.String(),
+		trace.WithAttributes(
+			AttrSessionID.String(session.ID().String()),
+			AttrTaskID.String(tsk.ID.String()),
+			AttrTaskExecutor.String(tsk.Executor),
+		),
+	)
+	defer span.End()
+
+	if ok {
+		tracedSess.mu.Lock()
+		tracedSess.taskSpans[tsk.ID] = span.SpanContext()
+		tracedSess.mu.Unlock()
+	}
+
+	err := t.Executor.Execute(ctx, session, tsk, result)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return err
+}
+
+// parentOf finds the span of the closest ancestor of id, falling back to the session's span.
+func (s *tracedSession) parentOf(id task.ID) trace.SpanContext {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for parent, ok := id.Parent(); ok; parent, ok = parent.Parent() {
+		if spanCtx, found := s.taskSpans[parent]; found {
+			return spanCtx
+		}
+	}
+
+	return s.span.SpanContext()
+}
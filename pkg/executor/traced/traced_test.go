@@ -0,0 +1,60 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package traced_test
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"go.bonk.build/pkg/executor/mockexec"
+	"go.bonk.build/pkg/executor/traced"
+	"go.bonk.build/pkg/task"
+)
+
+func TestTraced_Nesting(t *testing.T) { //nolint:paralleltest // modifies the global tracer provider
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	exec := mockexec.NewMockExecutor(t)
+	tracer := traced.New(exec)
+	session := task.NewTestSession()
+
+	exec.EXPECT().OpenSession(mock.Anything, session).Return(nil)
+	exec.EXPECT().CloseSession(mock.Anything, session.ID())
+	exec.EXPECT().Execute(mock.Anything, session, task.TaskIDMatches("parent"), mock.Anything).Return(nil)
+	exec.EXPECT().
+		Execute(mock.Anything, session, task.TaskIDMatches("parent.child"), mock.Anything).
+		Return(assert.AnError)
+
+	require.NoError(t, tracer.OpenSession(t.Context(), session))
+	require.NoError(t, tracer.Execute(t.Context(), session, task.New("parent", "exec", nil), nil))
+	require.ErrorIs(t,
+		tracer.Execute(t.Context(), session, task.New("parent.child", "exec", nil), nil),
+		assert.AnError,
+	)
+	tracer.CloseSession(t.Context(), session.ID())
+
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	require.Len(t, spans, 3)
+
+	sessionSpan := spans["session"]
+	parentSpan := spans["parent"]
+	childSpan := spans["parent.child"]
+
+	assert.Equal(t, sessionSpan.SpanContext().SpanID(), parentSpan.Parent().SpanID())
+	assert.Equal(t, parentSpan.SpanContext().SpanID(), childSpan.Parent().SpanID())
+	assert.Equal(t, sessionSpan.SpanContext().TraceID(), childSpan.SpanContext().TraceID())
+	assert.Len(t, childSpan.Events(), 1)
+}
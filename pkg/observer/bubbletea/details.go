@@ -0,0 +1,80 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package bubbletea
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+
+	"go.bonk.build/pkg/task"
+)
+
+// detailsLogLines is the number of trailing log lines shown when the window size is unknown.
+const detailsLogLines = 10
+
+var (
+	detailsStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			Padding(0, 1)
+	detailsHeadingStyle = lipgloss.NewStyle().Bold(true)
+	detailsFaintStyle   = lipgloss.NewStyle().Faint(true)
+)
+
+// renderDetails renders the side pane describing node, including the tail of its log file.
+func renderDetails(node *taskNode, log string, width int) string {
+	if node == nil {
+		return detailsStyle.Render(detailsFaintStyle.Render("no task selected"))
+	}
+
+	sections := make([]string, 0, 6) //nolint:mnd
+
+	sections = append(sections,
+		detailsHeadingStyle.Render(node.id.String()),
+		fmt.Sprintf("executor: %s\nstatus:   %s", node.executor, node.status),
+	)
+
+	if node.err != nil {
+		sections = append(sections, detailsSection("error", node.err.Error()))
+	}
+
+	if node.args != nil {
+		args, err := json.MarshalIndent(node.args, "", "  ")
+		if err != nil {
+			args = []byte(fmt.Sprintf("%v", node.args))
+		}
+
+		sections = append(sections, detailsSection("args", string(args)))
+	}
+
+	if outputs := node.result.GetOutputs(); len(outputs) > 0 {
+		sections = append(sections, detailsSection("outputs", strings.Join(outputs, "\n")))
+	}
+
+	if log != "" {
+		sections = append(sections, detailsSection("log", log))
+	}
+
+	style := detailsStyle
+	if width > 0 {
+		style = style.Width(width)
+	}
+
+	return style.Render(strings.Join(sections, "\n\n"))
+}
+
+func detailsSection(heading, body string) string {
+	return detailsHeadingStyle.Render(heading) + "\n" + body
+}
+
+// readLogTail returns the last lines lines of the task's text log, or an empty string if there isn't one.
+func readLogTail(session task.Session, id task.ID, lines int) string {
+	if lines <= 0 {
+		lines = detailsLogLines
+	}
+
+	return task.ReadLogTail(session, id, lines)
+}
@@ -5,6 +5,7 @@ package bubbletea
 
 import (
 	"reflect"
+	"time"
 
 	"charm.land/lipgloss/v2"
 
@@ -15,22 +16,42 @@ import (
 	"go.bonk.build/pkg/executor/observable"
 )
 
+// logRefreshInterval is how often the selected task's log is re-read from disk.
+const logRefreshInterval = 250 * time.Millisecond
+
+// buildFinishedMsg is sent when the build has completed, but the user wishes to keep browsing.
+type buildFinishedMsg struct{}
+
+// logRefreshMsg triggers a re-read of the selected task's log.
+type logRefreshMsg struct{}
+
+var helpStyle = lipgloss.NewStyle().Faint(true)
+
 // teaModel is responsible for handling task invocation and status tracking.
 type teaModel struct {
 	tree taskTree
 	view tea.View
 
+	width   int
+	height  int
+	logTail string
+
+	finished  bool
 	debugDump bool
+
+	// interrupt cancels the build, and is called the first time ctrl+c is pressed during it.
+	interrupt   func()
+	interrupted bool
 }
 
 var _ tea.Model = (*teaModel)(nil)
 
 // Init implements tea.Model.
 func (t *teaModel) Init() tea.Cmd {
-	cmds := make([]tea.Cmd, 0, 1)
+	cmds := make([]tea.Cmd, 0, 2) //nolint:mnd
 
 	t.tree = newTaskTree()
-	cmds = append(cmds, t.tree.Init())
+	cmds = append(cmds, t.tree.Init(), scheduleLogRefresh())
 
 	return tea.Batch(cmds...)
 }
@@ -45,9 +66,34 @@ func (t *teaModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		if msg.Key().Mod.Contains(tea.ModCtrl) && msg.Key().Code == 'c' {
-			cmds = append(cmds, tea.Quit)
+			// Give the build a chance to stop gracefully, unless the user insists
+			if !t.finished && !t.interrupted && t.interrupt != nil {
+				t.interrupted = true
+				t.interrupt()
+			} else {
+				cmds = append(cmds, tea.Quit)
+			}
+		}
+
+		// Only allow casual quitting once the build is done.
+		if msg, ok := msg.(tea.KeyPressMsg); ok && t.finished {
+			switch msg.String() {
+			case "q", "esc":
+				cmds = append(cmds, tea.Quit)
+			}
 		}
 
+	case tea.WindowSizeMsg:
+		t.width = msg.Width
+		t.height = msg.Height
+
+	case buildFinishedMsg:
+		t.finished = true
+
+	case logRefreshMsg:
+		t.refreshLog()
+		cmds = append(cmds, scheduleLogRefresh())
+
 	case observable.TaskStatusMsg:
 		// noop
 
@@ -61,14 +107,33 @@ func (t *teaModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	_, cmd = t.tree.Update(msg)
 	cmds = append(cmds, cmd)
 
+	// The selection may have changed, so make sure the details are current.
+	if _, ok := msg.(tea.KeyPressMsg); ok {
+		t.refreshLog()
+	}
+
 	return t, tea.Batch(cmds...)
 }
 
 // View implements tea.ViewModel.
 func (t *teaModel) View() tea.View {
-	component := make([]string, 0, 2) //nolint:mnd
+	component := make([]string, 0, 3) //nolint:mnd
+
+	treeView := t.tree.String()
+
+	detailsWidth := 0
+	if t.width > 0 {
+		detailsWidth = max(t.width-lipgloss.Width(treeView)-1, t.width/3) //nolint:mnd
+	}
 
-	component = append(component, t.tree.String())
+	component = append(component,
+		lipgloss.JoinHorizontal(lipgloss.Top,
+			treeView,
+			" ",
+			renderDetails(t.tree.selected, t.logTail, detailsWidth),
+		),
+		t.help(),
+	)
 
 	// Append empty string to get a blank line at the bottom
 	component = append(component, "")
@@ -77,3 +142,40 @@ func (t *teaModel) View() tea.View {
 
 	return t.view
 }
+
+func (t *teaModel) help() string {
+	help := "↑/↓ navigate • ←/→ collapse/expand • f failures only"
+	if t.tree.filter.failuresOnly {
+		help = "↑/↓ navigate • ←/→ collapse/expand • f show all"
+	}
+
+	if t.finished {
+		help += " • build finished, q to quit"
+	} else if t.interrupted {
+		help += " • canceling, ctrl+c again to quit"
+	}
+
+	return helpStyle.Render(help)
+}
+
+// refreshLog re-reads the log of the selected task.
+func (t *teaModel) refreshLog() {
+	if t.tree.selected == nil {
+		t.logTail = ""
+
+		return
+	}
+
+	lines := detailsLogLines
+	if t.height > 0 {
+		lines = max(detailsLogLines, t.height/2) //nolint:mnd
+	}
+
+	t.logTail = readLogTail(t.tree.selected.session, t.tree.selected.id, lines)
+}
+
+func scheduleLogRefresh() tea.Cmd {
+	return tea.Tick(logRefreshInterval, func(time.Time) tea.Msg {
+		return logRefreshMsg{}
+	})
+}
@@ -21,11 +21,13 @@ type observer struct {
 }
 
 // New creates a new scheduler driven by bubbletea.
-func New(ctx context.Context, debugDump bool) *observer {
+// If interrupt is non-nil, it's called to cancel the build the first time ctrl+c is pressed.
+func New(ctx context.Context, debugDump bool, interrupt func()) *observer {
 	result := &observer{
 		program: tea.NewProgram(
 			&teaModel{
 				debugDump: debugDump,
+				interrupt: interrupt,
 			},
 			tea.WithContext(ctx),
 		),
@@ -62,11 +64,20 @@ func New(ctx context.Context, debugDump bool) *observer {
 	return result
 }
 
+// OnTaskStatusMsg forwards task status changes to the program.
 func (o *observer) OnTaskStatusMsg(tsm observable.TaskStatusMsg) {
 	o.program.Send(tsm)
 }
 
+// Quit stops the program immediately.
 func (o *observer) Quit() {
 	o.program.Quit()
 	o.waiter.Wait()
 }
+
+// Wait notifies the program that the build has finished and blocks until the user quits,
+// so that the task tree can be browsed after the build.
+func (o *observer) Wait() {
+	o.program.Send(buildFinishedMsg{})
+	o.waiter.Wait()
+}
@@ -13,15 +13,23 @@ type StatusStyles map[observable.TaskStatus]lipgloss.Style
 
 var (
 	StatusStyleClear = StatusStyles{
-		observable.StatusNone:    lipgloss.NewStyle().SetString("  ").Faint(true),
-		observable.StatusRunning: lipgloss.NewStyle().SetString("🔘 "),
-		observable.StatusSuccess: lipgloss.NewStyle().SetString("✔️ ").Foreground(lipgloss.Green),
-		observable.StatusError:   lipgloss.NewStyle().SetString("❌ ").Foreground(lipgloss.Red),
+		observable.StatusNone:     lipgloss.NewStyle().SetString("  ").Faint(true),
+		observable.StatusRunning:  lipgloss.NewStyle().SetString("🔘 "),
+		observable.StatusSuccess:  lipgloss.NewStyle().SetString("✔️ ").Foreground(lipgloss.Green),
+		observable.StatusError:    lipgloss.NewStyle().SetString("❌ ").Foreground(lipgloss.Red),
+		observable.StatusCached:   lipgloss.NewStyle().SetString("✔️ ").Foreground(lipgloss.Green).Faint(true),
+		observable.StatusCanceled: lipgloss.NewStyle().SetString("⛔ ").Foreground(lipgloss.Yellow),
+		observable.StatusRetrying: lipgloss.NewStyle().SetString("🔁 ").Foreground(lipgloss.Yellow),
+		observable.StatusSkipped:  lipgloss.NewStyle().SetString("⏭️ ").Faint(true),
 	}
 	StatusStyleCircle = StatusStyles{
-		observable.StatusNone:    lipgloss.NewStyle().SetString("  ").Faint(true),
-		observable.StatusRunning: lipgloss.NewStyle().SetString("🔵 "),
-		observable.StatusSuccess: lipgloss.NewStyle().SetString("🟢 ").Foreground(lipgloss.Green),
-		observable.StatusError:   lipgloss.NewStyle().SetString("🔴 ").Foreground(lipgloss.Red),
+		observable.StatusNone:     lipgloss.NewStyle().SetString("  ").Faint(true),
+		observable.StatusRunning:  lipgloss.NewStyle().SetString("🔵 "),
+		observable.StatusSuccess:  lipgloss.NewStyle().SetString("🟢 ").Foreground(lipgloss.Green),
+		observable.StatusError:    lipgloss.NewStyle().SetString("🔴 ").Foreground(lipgloss.Red),
+		observable.StatusCached:   lipgloss.NewStyle().SetString("🟢 ").Foreground(lipgloss.Green).Faint(true),
+		observable.StatusCanceled: lipgloss.NewStyle().SetString("🟡 ").Foreground(lipgloss.Yellow),
+		observable.StatusRetrying: lipgloss.NewStyle().SetString("🟠 ").Foreground(lipgloss.Yellow),
+		observable.StatusSkipped:  lipgloss.NewStyle().SetString("⚪ ").Faint(true),
 	}
 )
@@ -5,6 +5,7 @@ package bubbletea
 
 import (
 	"strings"
+	"time"
 
 	"charm.land/lipgloss/v2"
 	"charm.land/lipgloss/v2/tree"
@@ -13,22 +14,49 @@ import (
 	"github.com/elliotchance/orderedmap/v3"
 
 	"go.bonk.build/pkg/executor/observable"
+	"go.bonk.build/pkg/task"
 )
 
 // taskNode is responsible for rendering task state to the terminal.
 type taskNode struct {
+	id     task.ID
 	name   string
 	status observable.TaskStatus
 	err    error
+	// updated is the time of the status shown, so that statuses delivered out of order don't replace newer ones.
+	updated time.Time
+
+	// Details of the most recent invocation, used by the details pane.
+	session  task.Session
+	executor string
+	args     any
+	result   *task.Result
+
+	// View state shared by every node in the tree.
+	filter *treeFilter
+
+	collapsed bool
+	selected  bool
 
 	children taskNodeChildren
 }
 
-var _ tree.Node = (*taskNode)(nil)
+// treeFilter contains the view state which affects which nodes are visible.
+type treeFilter struct {
+	failuresOnly bool
+}
+
+var (
+	_ tree.Node = (*taskNode)(nil)
 
-func makeTaskNode(name string) *taskNode {
+	selectedStyle = lipgloss.NewStyle().Reverse(true)
+)
+
+func makeTaskNode(id task.ID, name string, filter *treeFilter) *taskNode {
 	return &taskNode{
-		name: name,
+		id:     id,
+		name:   name,
+		filter: filter,
 		children: taskNodeChildren{
 			OrderedMap: orderedmap.NewOrderedMap[string, *taskNode](),
 		},
@@ -37,12 +65,16 @@ func makeTaskNode(name string) *taskNode {
 
 // Children implements tree.Node.
 func (t *taskNode) Children() tree.Children {
+	if t.collapsed {
+		return tree.NodeChildren(nil)
+	}
+
 	return t.children
 }
 
 // Hidden implements tree.Node.
 func (t *taskNode) Hidden() bool {
-	return false
+	return t.filter != nil && t.filter.failuresOnly && !t.hasFailure()
 }
 
 // SetHidden implements tree.Node.
@@ -52,7 +84,16 @@ func (t *taskNode) SetHidden(bool) {
 // Value implements tree.Node.
 func (t *taskNode) Value() string {
 	result := strings.Builder{}
-	result.WriteString(t.name)
+
+	if t.collapsed && t.children.Len() > 0 {
+		result.WriteString("▸ ")
+	}
+
+	if t.selected {
+		result.WriteString(selectedStyle.Render(t.name))
+	} else {
+		result.WriteString(t.name)
+	}
 
 	if t.err != nil {
 		result.WriteString(": ")
@@ -69,8 +110,17 @@ func (t *taskNode) Value() string {
 // SetValue implements tree.Node.
 func (t *taskNode) SetValue(value any) {
 	if status, ok := value.(observable.TaskStatusMsg); ok {
+		if status.Time.Before(t.updated) {
+			return
+		}
+
+		t.updated = status.Time
 		t.status = status.Status
 		t.err = status.Error
+		t.session = status.Session
+		t.executor = status.Executor
+		t.args = status.Args
+		t.result = status.Result
 	} else {
 		panic("unimplemented " + spew.Sdump(value))
 	}
@@ -81,6 +131,40 @@ func (t *taskNode) String() string {
 	panic("unimplemented")
 }
 
+// hasFailure returns true if this node or any of its descendants has failed.
+func (t *taskNode) hasFailure() bool {
+	if t.status == observable.StatusError {
+		return true
+	}
+
+	for child := range t.children.Values() {
+		if child.hasFailure() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// visible appends this node and all of its expanded, unfiltered descendants to nodes.
+func (t *taskNode) visible(nodes []*taskNode) []*taskNode {
+	if t.Hidden() {
+		return nodes
+	}
+
+	nodes = append(nodes, t)
+
+	if t.collapsed {
+		return nodes
+	}
+
+	for child := range t.children.Values() {
+		nodes = child.visible(nodes)
+	}
+
+	return nodes
+}
+
 type taskNodeChildren struct {
 	*orderedmap.OrderedMap[string, *taskNode]
 }
@@ -11,12 +11,16 @@ import (
 	tea "charm.land/bubbletea/v2"
 
 	"go.bonk.build/pkg/executor/observable"
+	"go.bonk.build/pkg/task"
 )
 
 type taskTree struct {
 	tree.Tree
 
 	view tea.View
+
+	filter   *treeFilter
+	selected *taskNode
 }
 
 var _ tea.Model = (*taskTree)(nil)
@@ -26,6 +30,7 @@ func newTaskTree() taskTree {
 		Tree: *tree.New().
 			Enumerator(tree.RoundedEnumerator).
 			ItemStyleFunc(taskNodeStyle(StatusStyleClear)),
+		filter: &treeFilter{},
 	}
 }
 
@@ -38,58 +43,192 @@ func (t *taskTree) Init() tea.Cmd {
 func (t *taskTree) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
-	if msg, ok := msg.(observable.TaskStatusMsg); ok {
-		curName, childPath, hasChildren := msg.TaskID.Cut()
+	switch msg := msg.(type) {
+	case observable.TaskStatusMsg:
+		cur := t.findOrCreate(msg.TaskID)
 
-		var cur *taskNode
+		// Now update cur
+		cur.SetValue(msg)
 
-		// Search the top level list for the node
-		treeChildren := t.Children()
-		for idx := range treeChildren.Length() {
-			taskNode, ok := treeChildren.At(idx).(*taskNode)
-			if !ok {
-				panic("unexpected child!")
-			}
-			if taskNode.name == curName {
-				cur = taskNode
+		// Select the first task to arrive so there's always something in the details pane.
+		if t.selected == nil {
+			t.selectNode(cur)
+		}
 
-				break
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "up", "k":
+			t.moveSelection(-1)
+		case "down", "j":
+			t.moveSelection(1)
+		case "home", "g":
+			t.moveSelection(-len(t.visible()))
+		case "end", "G":
+			t.moveSelection(len(t.visible()))
+		case "left", "h":
+			t.collapseSelection()
+		case "right", "l":
+			t.expandSelection()
+		case "enter", "space":
+			if t.selected != nil {
+				t.selected.collapsed = !t.selected.collapsed
 			}
+		case "f":
+			t.filter.failuresOnly = !t.filter.failuresOnly
+			t.ensureSelectionVisible()
 		}
-		if cur == nil {
-			cur = makeTaskNode(curName)
-			t.Child(cur)
+	}
+
+	return t, tea.Batch(cmds...)
+}
+
+// View implements tea.ViewModel.
+func (t *taskTree) View() tea.View {
+	t.view.SetContent(t.String())
+
+	return t.view
+}
+
+// findOrCreate searches the tree for the node with the given ID, creating it and any parents if necessary.
+func (t *taskTree) findOrCreate(id task.ID) *taskNode {
+	curName, childPath, hasChildren := id.Cut()
+	curID := task.NewID(curName)
+
+	var cur *taskNode
+
+	// Search the top level list for the node
+	treeChildren := t.Children()
+	for idx := range treeChildren.Length() {
+		taskNode, ok := treeChildren.At(idx).(*taskNode)
+		if !ok {
+			panic("unexpected child!")
 		}
+		if taskNode.name == curName {
+			cur = taskNode
 
-		// Now find the sub task inside of that
-		for hasChildren {
-			curName, childPath, hasChildren = strings.Cut(childPath, ".")
+			break
+		}
+	}
+	if cur == nil {
+		cur = makeTaskNode(curID, curName, t.filter)
+		t.Child(cur)
+	}
 
-			newChild, ok := cur.children.Get(curName)
+	// Now find the sub task inside of that
+	for hasChildren {
+		curName, childPath, hasChildren = strings.Cut(childPath, task.TaskIDSep)
+		curID = curID.GetChild(curName)
 
-			// If there isn't already a child node, add & initialize it
-			if !ok {
-				newChild = makeTaskNode(curName)
-				cur.children.Set(curName, newChild)
-			}
+		newChild, ok := cur.children.Get(curName)
 
-			cur = newChild
+		// If there isn't already a child node, add & initialize it
+		if !ok {
+			newChild = makeTaskNode(curID, curName, t.filter)
+			cur.children.Set(curName, newChild)
 		}
 
-		if cur == nil {
-			panic("invalid!")
+		cur = newChild
+	}
+
+	if cur == nil {
+		panic("invalid!")
+	}
+
+	return cur
+}
+
+// visible returns every node that is currently rendered, in render order.
+func (t *taskTree) visible() []*taskNode {
+	var nodes []*taskNode
+
+	treeChildren := t.Children()
+	for idx := range treeChildren.Length() {
+		node, ok := treeChildren.At(idx).(*taskNode)
+		if !ok {
+			panic("unexpected child!")
 		}
 
-		// Now update cur
-		cur.SetValue(msg)
+		nodes = node.visible(nodes)
 	}
 
-	return t, tea.Batch(cmds...)
+	return nodes
 }
 
-// View implements tea.ViewModel.
-func (t *taskTree) View() tea.View {
-	t.view.SetContent(t.String())
+func (t *taskTree) selectNode(node *taskNode) {
+	if t.selected != nil {
+		t.selected.selected = false
+	}
 
-	return t.view
+	t.selected = node
+
+	if node != nil {
+		node.selected = true
+	}
+}
+
+// moveSelection moves the cursor delta rows, clamping to the visible range.
+func (t *taskTree) moveSelection(delta int) {
+	nodes := t.visible()
+	if len(nodes) == 0 {
+		return
+	}
+
+	idx := 0
+	for ii, node := range nodes {
+		if node == t.selected {
+			idx = ii
+
+			break
+		}
+	}
+
+	t.selectNode(nodes[max(0, min(len(nodes)-1, idx+delta))])
+}
+
+// collapseSelection collapses the selected node, or moves to its parent if it's already collapsed.
+func (t *taskTree) collapseSelection() {
+	if t.selected == nil {
+		return
+	}
+
+	if !t.selected.collapsed && t.selected.children.Len() > 0 {
+		t.selected.collapsed = true
+
+		return
+	}
+
+	if parentID, ok := t.selected.id.Parent(); ok {
+		t.selectNode(t.findOrCreate(parentID))
+	}
+}
+
+// expandSelection expands the selected node, or moves to its first child if it's already expanded.
+func (t *taskTree) expandSelection() {
+	if t.selected == nil {
+		return
+	}
+
+	if t.selected.collapsed {
+		t.selected.collapsed = false
+
+		return
+	}
+
+	t.moveSelection(1)
+}
+
+// ensureSelectionVisible moves the selection to the nearest visible node after a filter change.
+func (t *taskTree) ensureSelectionVisible() {
+	nodes := t.visible()
+	for _, node := range nodes {
+		if node == t.selected {
+			return
+		}
+	}
+
+	if len(nodes) > 0 {
+		t.selectNode(nodes[0])
+	} else {
+		t.selectNode(nil)
+	}
 }
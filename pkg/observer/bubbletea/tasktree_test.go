@@ -0,0 +1,130 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package bubbletea
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tea "charm.land/bubbletea/v2"
+
+	"go.bonk.build/pkg/executor/observable"
+	"go.bonk.build/pkg/task"
+)
+
+func makeTestTree(t *testing.T) *taskTree {
+	t.Helper()
+
+	tree := newTaskTree()
+	session := task.NewTestSession()
+
+	for _, id := range []task.ID{"A", "A.B", "A.C", "D"} {
+		tree.Update(observable.TaskFinishedMsg(session, task.New(id, "exec", nil), nil, nil))
+	}
+
+	return &tree
+}
+
+func press(tree *taskTree, key string) {
+	var code rune
+
+	switch key {
+	case "down":
+		code = tea.KeyDown
+	case "up":
+		code = tea.KeyUp
+	case "left":
+		code = tea.KeyLeft
+	case "right":
+		code = tea.KeyRight
+	default:
+		code = rune(key[0])
+	}
+
+	tree.Update(tea.KeyPressMsg{Code: code})
+}
+
+func TestTaskTree_Navigate(t *testing.T) {
+	t.Parallel()
+
+	tree := makeTestTree(t)
+	require.NotNil(t, tree.selected)
+	assert.Equal(t, task.ID("A"), tree.selected.id)
+
+	press(tree, "down")
+	assert.Equal(t, task.ID("A.B"), tree.selected.id)
+
+	press(tree, "down")
+	press(tree, "down")
+	assert.Equal(t, task.ID("D"), tree.selected.id)
+
+	// Moving past the end should clamp
+	press(tree, "down")
+	assert.Equal(t, task.ID("D"), tree.selected.id)
+
+	press(tree, "up")
+	press(tree, "left")
+	assert.Equal(t, task.ID("A"), tree.selected.id)
+}
+
+func TestTaskTree_Collapse(t *testing.T) {
+	t.Parallel()
+
+	tree := makeTestTree(t)
+
+	press(tree, "left")
+	assert.True(t, tree.selected.collapsed)
+	assert.Len(t, tree.visible(), 2)
+
+	press(tree, "down")
+	assert.Equal(t, task.ID("D"), tree.selected.id)
+
+	press(tree, "up")
+	press(tree, "right")
+	assert.False(t, tree.selected.collapsed)
+	assert.Len(t, tree.visible(), 4)
+}
+
+func TestTaskTree_FailuresOnly(t *testing.T) {
+	t.Parallel()
+
+	tree := makeTestTree(t)
+	tree.Update(observable.TaskFinishedMsg(
+		task.NewTestSession(),
+		task.New("A.C", "exec", nil),
+		nil,
+		assert.AnError,
+	))
+
+	press(tree, "f")
+
+	visible := tree.visible()
+	require.Len(t, visible, 2)
+	assert.Equal(t, task.ID("A"), visible[0].id)
+	assert.Equal(t, task.ID("A.C"), visible[1].id)
+}
+
+func TestTaskTree_OutOfOrder(t *testing.T) {
+	t.Parallel()
+
+	session := task.NewTestSession()
+	tsk := task.New("A", "exec", nil)
+
+	running := observable.TaskRunningMsg(session, tsk)
+	finished := observable.TaskFinishedMsg(session, tsk, nil, assert.AnError)
+	finished.Time = running.Time.Add(time.Second)
+
+	// The task's running status is delivered after it finished, and is ignored
+	tree := newTaskTree()
+	tree.Update(finished)
+	tree.Update(running)
+
+	visible := tree.visible()
+	require.Len(t, visible, 1)
+	assert.Equal(t, observable.StatusError, visible[0].status)
+	assert.ErrorIs(t, visible[0].err, assert.AnError)
+}
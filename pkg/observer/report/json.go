@@ -0,0 +1,22 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+func writeJSON(writer io.Writer, report *Report) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	err := encoder.Encode(report)
+	if err != nil {
+		return fmt.Errorf("failed to encode json report: %w", err)
+	}
+
+	return nil
+}
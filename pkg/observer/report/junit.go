@@ -0,0 +1,164 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"go.bonk.build/pkg/executor/observable"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     float64          `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Skipped    int             `xml:"skipped,attr"`
+	Time       float64         `xml:"time,attr"`
+	Timestamp  string          `xml:"timestamp,attr,omitempty"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	Cases      []junitTestCase `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      float64       `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+// writeJUnit writes a testsuite per root task, containing a testcase for it and each of its followups.
+// Testcases are named after the last segment of their ID, and classed by the rest.
+// Each testsuite has a session property naming the session its tasks executed in.
+func writeJUnit(writer io.Writer, report *Report) error {
+	suites := junitTestSuites{
+		Name: "bonk",
+		Time: report.Duration.Seconds(),
+	}
+
+	for _, root := range report.Tasks {
+		suite := junitTestSuite{
+			Name: root.ID.String(),
+		}
+		if !root.Start.IsZero() {
+			suite.Timestamp = root.Start.Format("2006-01-02T15:04:05")
+		}
+		if root.Session != "" {
+			suite.Properties = append(suite.Properties, junitProperty{Name: "session", Value: root.Session})
+		}
+
+		walk([]*TaskReport{root}, func(tsk *TaskReport) {
+			testCase := junitTestCase{
+				Name:      tsk.ID.String(),
+				ClassName: root.ID.String(),
+				Time:      tsk.Duration.Seconds(),
+			}
+			if parent, ok := tsk.ID.Parent(); ok {
+				testCase.ClassName = parent.String()
+				testCase.Name = strings.TrimPrefix(tsk.ID.String(), parent.String()+".")
+			}
+
+			var out []string
+			if tsk.Executor != "" {
+				out = append(out, "executor: "+tsk.Executor)
+			}
+			if tsk.LogFile != "" {
+				out = append(out, "log: "+tsk.LogFile)
+			}
+			if len(tsk.Outputs) > 0 {
+				out = append(out, "outputs: "+strings.Join(tsk.Outputs, ", "))
+			}
+			if tsk.Attempts > 1 {
+				out = append(out, fmt.Sprintf("attempts: %d", tsk.Attempts))
+			}
+
+			switch tsk.status { //nolint:exhaustive
+			case observable.StatusError:
+				testCase.Failure = &junitFailure{
+					Message: tsk.Error,
+					Type:    "error",
+					Body:    tsk.Error,
+				}
+				suite.Failures++
+
+			case observable.StatusCached:
+				out = append(out, "cached: true")
+
+			case observable.StatusSkipped:
+				testCase.Skipped = &junitSkipped{
+					Message: "task condition not met",
+				}
+				suite.Skipped++
+
+			case observable.StatusCanceled:
+				testCase.Skipped = &junitSkipped{
+					Message: "task canceled",
+				}
+				suite.Skipped++
+
+			case observable.StatusNone, observable.StatusRunning:
+				// The task never finished
+				testCase.Skipped = &junitSkipped{
+					Message: "task did not finish",
+				}
+				suite.Skipped++
+			}
+
+			testCase.SystemOut = strings.Join(out, "\n")
+
+			suite.Tests++
+			suite.Time += tsk.Duration.Seconds()
+			suite.Cases = append(suite.Cases, testCase)
+		})
+
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Skipped += suite.Skipped
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	_, err := io.WriteString(writer, xml.Header)
+	if err != nil {
+		return fmt.Errorf("failed to write junit report: %w", err)
+	}
+
+	encoder := xml.NewEncoder(writer)
+	encoder.Indent("", "  ")
+
+	err = encoder.Encode(suites)
+	if err != nil {
+		return fmt.Errorf("failed to encode junit report: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,277 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+// Package report provides an observer which collects the outcome of every task in a build,
+// and writes it out in machine-readable formats for consumption by CI.
+package report
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+
+	"go.bonk.build/pkg/executor/observable"
+	"go.bonk.build/pkg/task"
+)
+
+// Format is a supported report file format.
+type Format string
+
+const (
+	// FormatJSON writes the task tree as a JSON document.
+	FormatJSON Format = "json"
+	// FormatJUnit writes tasks as JUnit XML testcases, grouped into a testsuite per root task.
+	FormatJUnit Format = "junit"
+)
+
+var ErrUnknownFormat = errors.New("unknown report format")
+
+// TaskReport describes the outcome of a single task.
+type TaskReport struct {
+	ID task.ID `json:"id"`
+	// Session is the ID of the session the task executed in, as tasks in different sessions may share IDs.
+	Session  string `json:"session,omitempty"`
+	Executor string `json:"executor"`
+	Status   string `json:"status"`
+
+	Start time.Time `json:"start"`
+	// Duration is the task's execution time in nanoseconds.
+	Duration time.Duration `json:"duration"`
+
+	Outputs []string `json:"outputs,omitempty"`
+	Error   string   `json:"error,omitempty"`
+	LogFile string   `json:"logFile,omitempty"`
+	// Attempts is how many times the task was executed, if it was retried.
+	Attempts int `json:"attempts,omitempty"`
+
+	// Followups contains the reports of any tasks spawned by this one.
+	Followups []*TaskReport `json:"followups,omitempty"`
+
+	status  observable.TaskStatus
+	end     time.Time
+	session task.Session
+}
+
+// Report is the root of a build report.
+type Report struct {
+	Start time.Time `json:"start"`
+	// Duration is the wall time of the build in nanoseconds.
+	Duration time.Duration `json:"duration"`
+
+	// Counts contains the number of tasks in each status.
+	Counts map[string]int `json:"counts"`
+
+	Tasks []*TaskReport `json:"tasks"`
+}
+
+// Reporter is an observer which records every task status change it's notified of.
+type Reporter struct {
+	mu    sync.Mutex
+	tasks map[reportKey]*TaskReport
+	order []reportKey
+}
+
+// reportKey identifies a task within the session it executed in.
+type reportKey struct {
+	session task.SessionID
+	id      task.ID
+}
+
+// New creates an empty [Reporter].
+func New() *Reporter {
+	return &Reporter{
+		tasks: make(map[reportKey]*TaskReport),
+	}
+}
+
+// OnTaskStatusMsg implements observable.Observer.
+func (r *Reporter) OnTaskStatusMsg(tsm observable.TaskStatusMsg) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := reportKey{id: tsm.TaskID}
+	if tsm.Session != nil {
+		key.session = tsm.Session.ID()
+	}
+
+	report, ok := r.tasks[key]
+	if !ok {
+		report = &TaskReport{
+			ID: tsm.TaskID,
+		}
+		if tsm.Session != nil {
+			report.Session = key.session.String()
+		}
+		r.tasks[key] = report
+		r.order = append(r.order, key)
+	}
+
+	report.Executor = tsm.Executor
+
+	// Retries don't change the task's status, which is running until its last attempt finishes
+	if tsm.Status == observable.StatusRetrying {
+		report.Attempts = max(report.Attempts, tsm.Attempt+1)
+
+		return
+	}
+	if tsm.Attempt > 1 {
+		report.Attempts = max(report.Attempts, tsm.Attempt)
+	}
+
+	if tsm.Status == observable.StatusRunning {
+		report.Start = tsm.Time
+
+		// Observers are notified concurrently, so the finished message may have arrived first.
+		if report.status != observable.StatusNone {
+			report.Duration = report.end.Sub(report.Start)
+
+			return
+		}
+	} else {
+		report.end = tsm.Time
+		report.session = tsm.Session
+		report.Outputs = tsm.Result.GetOutputs()
+		report.LogFile = logFile(tsm.Session, tsm.TaskID)
+
+		if !report.Start.IsZero() {
+			report.Duration = report.end.Sub(report.Start)
+		}
+
+		if tsm.Error != nil {
+			report.Error = tsm.Error.Error()
+		}
+	}
+
+	report.status = tsm.Status
+	report.Status = tsm.Status.String()
+}
+
+// Report builds the followup tree of every task observed so far.
+func (r *Reporter) Report() *Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := &Report{
+		Counts: make(map[string]int),
+	}
+
+	var end time.Time
+
+	// Copy each report, so that the tree doesn't change under the caller
+	reports := make(map[reportKey]*TaskReport, len(r.order))
+	for _, key := range r.order {
+		report := *r.tasks[key]
+		reports[key] = &report
+
+		if !report.Start.IsZero() && (result.Start.IsZero() || report.Start.Before(result.Start)) {
+			result.Start = report.Start
+		}
+		if report.end.After(end) {
+			end = report.end
+		}
+
+		result.Counts[report.Status]++
+	}
+
+	// Attach each task to its closest observed ancestor in the same session
+	for _, key := range r.order {
+		report := reports[key]
+
+		parent := findParent(reports, key)
+		if parent == nil {
+			result.Tasks = append(result.Tasks, report)
+		} else {
+			parent.Followups = append(parent.Followups, report)
+		}
+	}
+
+	if !end.IsZero() {
+		result.Duration = end.Sub(result.Start)
+	}
+
+	return result
+}
+
+// Write writes the report in the given format.
+func (r *Reporter) Write(writer io.Writer, format Format) error {
+	switch format {
+	case FormatJSON:
+		return writeJSON(writer, r.Report())
+	case FormatJUnit:
+		return writeJUnit(writer, r.Report())
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+}
+
+// WriteFile writes the report to a file in the given format.
+func (r *Reporter) WriteFile(fs afero.Fs, filePath string, format Format) error {
+	file, err := fs.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to create report file %s: %w", filePath, err)
+	}
+
+	err = r.Write(file, format)
+	if err != nil {
+		_ = file.Close()
+
+		return err
+	}
+
+	err = file.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close report file %s: %w", filePath, err)
+	}
+
+	return nil
+}
+
+func findParent(reports map[reportKey]*TaskReport, key reportKey) *TaskReport {
+	for parent, ok := key.id.Parent(); ok; parent, ok = parent.Parent() {
+		if report, found := reports[reportKey{session: key.session, id: parent}]; found {
+			return report
+		}
+	}
+
+	return nil
+}
+
+// logFile returns the path to the task's text log, if one was written.
+// Paths for local sessions are absolute, otherwise they're relative to the session's output directory.
+func logFile(session task.Session, id task.ID) string {
+	if session == nil {
+		return ""
+	}
+
+	exists, err := afero.Exists(task.OutputFS(session, id), task.LogFileText)
+	if err != nil || !exists {
+		return ""
+	}
+
+	if local, ok := session.(task.LocalSession); ok {
+		return filepath.Join(local.LocalPath(), task.OutputDir, id.String(), task.LogFileText)
+	}
+
+	return path.Join(id.String(), task.LogFileText)
+}
+
+// walk calls fun for each task in the tree in depth-first order.
+func walk(tasks []*TaskReport, fun func(*TaskReport)) {
+	for _, tsk := range slices.SortedStableFunc(slices.Values(tasks), compareReports) {
+		fun(tsk)
+		walk(tsk.Followups, fun)
+	}
+}
+
+func compareReports(a, b *TaskReport) int {
+	return cmp.Or(strings.Compare(a.Session, b.Session), strings.Compare(a.ID.String(), b.ID.String()))
+}
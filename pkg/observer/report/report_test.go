@@ -0,0 +1,219 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package report_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.bonk.build/pkg/executor/observable"
+	"go.bonk.build/pkg/observer/report"
+	"go.bonk.build/pkg/task"
+)
+
+var errTest = errors.New("test error")
+
+func runBuild(t *testing.T) *report.Reporter {
+	t.Helper()
+
+	session := task.NewTestSession()
+	reporter := report.New()
+
+	parent := task.New(task.NewID("Test", "Parent"), "test.Parent", nil)
+	child := task.New(task.NewID("Test", "Parent", "Child"), "test.Child", nil)
+	cached := task.New(task.NewID("Test", "Cached"), "test.Cached", nil)
+	skipped := task.New(task.NewID("Test", "Skipped"), "test.Skipped", nil)
+
+	require.NoError(t, afero.WriteFile(
+		task.OutputFS(session, parent.ID), task.LogFileText, []byte("log"), 0o600))
+
+	parentResult := &task.Result{}
+	parentResult.AddOutputs("out.txt")
+	parentResult.AddFollowupTasks(child)
+
+	cachedResult := &task.Result{}
+	cachedResult.MarkCached()
+
+	reporter.OnTaskStatusMsg(observable.TaskRunningMsg(session, parent))
+	reporter.OnTaskStatusMsg(observable.TaskFinishedMsg(session, parent, parentResult, nil))
+
+	// Finished before running and retrying, as may happen with concurrent observers
+	finished := observable.TaskFinishedMsg(session, child, &task.Result{}, errTest)
+	finished.Attempt = 2
+	running := observable.TaskRunningMsg(session, child)
+	running.Time = finished.Time.Add(-1)
+	reporter.OnTaskStatusMsg(finished)
+	reporter.OnTaskStatusMsg(running)
+	reporter.OnTaskStatusMsg(observable.TaskRetryingMsg(session, child, 1, errTest))
+
+	reporter.OnTaskStatusMsg(observable.TaskRunningMsg(session, cached))
+	reporter.OnTaskStatusMsg(observable.TaskFinishedMsg(session, cached, cachedResult, nil))
+
+	reporter.OnTaskStatusMsg(observable.TaskSkippedMsg(session, skipped))
+
+	return reporter
+}
+
+func TestReport(t *testing.T) {
+	t.Parallel()
+
+	rep := runBuild(t).Report()
+
+	assert.Equal(t, map[string]int{"success": 1, "failed": 1, "cached": 1, "skipped": 1}, rep.Counts)
+	require.Len(t, rep.Tasks, 3)
+
+	parent := rep.Tasks[0]
+	assert.Equal(t, "Test.Parent", parent.ID.String())
+	assert.Equal(t, "test.Parent", parent.Executor)
+	assert.Equal(t, "success", parent.Status)
+	assert.Equal(t, []string{"out.txt"}, parent.Outputs)
+	assert.Equal(t, "Test.Parent/"+task.LogFileText, parent.LogFile)
+	require.Len(t, parent.Followups, 1)
+
+	child := parent.Followups[0]
+	assert.Equal(t, "failed", child.Status)
+	assert.Equal(t, errTest.Error(), child.Error)
+	assert.Positive(t, child.Duration)
+	assert.Empty(t, child.LogFile)
+	assert.Equal(t, 2, child.Attempts)
+
+	assert.Equal(t, "cached", rep.Tasks[1].Status)
+	assert.Equal(t, "skipped", rep.Tasks[2].Status)
+}
+
+func TestReport_Sessions(t *testing.T) {
+	t.Parallel()
+
+	reporter := report.New()
+	parent := task.New(task.NewID("Test"), "test.Test", nil)
+	child := task.New(task.NewID("Test", "Child"), "test.Child", nil)
+
+	// Sessions may execute tasks with the same IDs
+	sessions := []task.Session{
+		task.NewLocalSession(task.NewSessionID(), t.TempDir()),
+		task.NewLocalSession(task.NewSessionID(), t.TempDir()),
+	}
+	for idx, session := range sessions {
+		var err error
+		if idx == 1 {
+			err = errTest
+		}
+
+		reporter.OnTaskStatusMsg(observable.TaskFinishedMsg(session, parent, &task.Result{}, nil))
+		reporter.OnTaskStatusMsg(observable.TaskFinishedMsg(session, child, &task.Result{}, err))
+	}
+
+	rep := reporter.Report()
+	assert.Equal(t, map[string]int{"success": 3, "failed": 1}, rep.Counts)
+	require.Len(t, rep.Tasks, 2)
+
+	for idx, session := range sessions {
+		assert.Equal(t, session.ID().String(), rep.Tasks[idx].Session)
+		require.Len(t, rep.Tasks[idx].Followups, 1)
+		assert.Equal(t, session.ID().String(), rep.Tasks[idx].Followups[0].Session)
+	}
+	assert.Equal(t, "failed", rep.Tasks[1].Followups[0].Status)
+
+	var buf bytes.Buffer
+	require.NoError(t, reporter.WriteSummary(&buf, 0))
+	assert.Contains(t, buf.String(), "FAILED Test.Child [session "+sessions[1].ID().String()+"] (test.Child)")
+}
+
+func TestWriteJSON(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, runBuild(t).Write(&buf, report.FormatJSON))
+
+	var rep report.Report
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &rep))
+	require.Len(t, rep.Tasks, 3)
+	assert.Len(t, rep.Tasks[0].Followups, 1)
+}
+
+func TestWriteJUnit(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, runBuild(t).Write(&buf, report.FormatJUnit))
+
+	var suites struct {
+		Tests    int `xml:"tests,attr"`
+		Failures int `xml:"failures,attr"`
+		Skipped  int `xml:"skipped,attr"`
+		Suites   []struct {
+			Name  string `xml:"name,attr"`
+			Cases []struct {
+				Name      string    `xml:"name,attr"`
+				ClassName string    `xml:"classname,attr"`
+				Failure   *struct{} `xml:"failure"`
+				Skipped   *struct{} `xml:"skipped"`
+			} `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &suites))
+
+	assert.Equal(t, 4, suites.Tests)
+	assert.Equal(t, 1, suites.Failures)
+	assert.Equal(t, 1, suites.Skipped)
+	require.Len(t, suites.Suites, 3)
+
+	suite := suites.Suites[0]
+	assert.Equal(t, "Test.Parent", suite.Name)
+	require.Len(t, suite.Cases, 2)
+	assert.Equal(t, "Child", suite.Cases[1].Name)
+	assert.Equal(t, "Test.Parent", suite.Cases[1].ClassName)
+	assert.NotNil(t, suite.Cases[1].Failure)
+
+	require.Len(t, suites.Suites[2].Cases, 1)
+	assert.NotNil(t, suites.Suites[2].Cases[0].Skipped)
+}
+
+func TestUnknownFormat(t *testing.T) {
+	t.Parallel()
+
+	err := report.New().Write(&bytes.Buffer{}, "yaml")
+	require.ErrorIs(t, err, report.ErrUnknownFormat)
+}
+
+func TestWriteSummary(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, runBuild(t).WriteSummary(&buf, 1))
+
+	summary := buf.String()
+	assert.Contains(t, summary, "Tasks: 2 run, 1 cached, 1 failed, 1 skipped")
+	assert.Contains(t, summary, "Slowest tasks:")
+	assert.Contains(t, summary, "FAILED Test.Parent.Child (test.Child)")
+	assert.Contains(t, summary, "error: "+errTest.Error())
+}
+
+func TestWriteSummaryLogTail(t *testing.T) {
+	t.Parallel()
+
+	session := task.NewTestSession()
+	reporter := report.New()
+	tsk := task.New(task.NewID("Test"), "test.Test", nil)
+
+	require.NoError(t, afero.WriteFile(
+		task.OutputFS(session, tsk.ID), task.LogFileText, []byte("first\nsecond\n"), 0o600))
+
+	reporter.OnTaskStatusMsg(observable.TaskRunningMsg(session, tsk))
+	reporter.OnTaskStatusMsg(observable.TaskFinishedMsg(session, tsk, &task.Result{}, errTest))
+
+	var buf bytes.Buffer
+	require.NoError(t, reporter.WriteSummary(&buf, 0))
+
+	summary := buf.String()
+	assert.NotContains(t, summary, "Slowest tasks:")
+	assert.Contains(t, summary, "    first\n    second\n")
+}
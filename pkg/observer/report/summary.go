@@ -0,0 +1,105 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package report
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"go.bonk.build/pkg/executor/observable"
+	"go.bonk.build/pkg/task"
+)
+
+// DefaultSlowest is the default number of slowest tasks listed in a summary.
+const DefaultSlowest = 5
+
+// summaryLogLines is the number of trailing log lines shown for each failure.
+const summaryLogLines = 10
+
+// WriteSummary writes a human-readable summary of the build, listing the slowest tasks and the details of any failures.
+// If slowest is zero, the slowest tasks aren't listed. Tasks are named with their sessions if the build had several.
+func (r *Reporter) WriteSummary(writer io.Writer, slowest int) error {
+	report := r.Report()
+
+	var (
+		all      []*TaskReport
+		failed   []*TaskReport
+		summed   time.Duration
+		sessions = make(map[string]struct{})
+		builder  strings.Builder
+	)
+
+	walk(report.Tasks, func(tsk *TaskReport) {
+		all = append(all, tsk)
+		summed += tsk.Duration
+		sessions[tsk.Session] = struct{}{}
+
+		if tsk.status == observable.StatusError {
+			failed = append(failed, tsk)
+		}
+	})
+
+	fmt.Fprintf(&builder, "Tasks: %d run, %d cached, %d failed, %d skipped",
+		report.Counts[observable.StatusSuccess.String()]+report.Counts[observable.StatusError.String()],
+		report.Counts[observable.StatusCached.String()],
+		report.Counts[observable.StatusError.String()],
+		report.Counts[observable.StatusSkipped.String()],
+	)
+	if canceled := report.Counts[observable.StatusCanceled.String()]; canceled > 0 {
+		fmt.Fprintf(&builder, ", %d canceled", canceled)
+	}
+	builder.WriteString("\n")
+	fmt.Fprintf(&builder, "Time:  %s wall, %s summed across tasks\n",
+		report.Duration.Round(time.Millisecond), summed.Round(time.Millisecond))
+
+	if slowest > 0 && len(all) > 0 {
+		slices.SortStableFunc(all, func(a, b *TaskReport) int {
+			return cmp.Compare(b.Duration, a.Duration)
+		})
+
+		builder.WriteString("\nSlowest tasks:\n")
+		for _, tsk := range all[:min(slowest, len(all))] {
+			fmt.Fprintf(&builder, "  %10s  %s\n", tsk.Duration.Round(time.Millisecond), summaryName(tsk, sessions))
+		}
+	}
+
+	for _, tsk := range failed {
+		fmt.Fprintf(&builder, "\nFAILED %s (%s)\n", summaryName(tsk, sessions), tsk.Executor)
+		fmt.Fprintf(&builder, "  error: %s\n", tsk.Error)
+
+		logTail := task.ReadLogTail(tsk.session, tsk.ID, summaryLogLines)
+		if logTail == "" {
+			continue
+		}
+
+		if tsk.LogFile != "" {
+			fmt.Fprintf(&builder, "  log (%s):\n", tsk.LogFile)
+		} else {
+			builder.WriteString("  log:\n")
+		}
+		for line := range strings.SplitSeq(logTail, "\n") {
+			builder.WriteString("    " + line + "\n")
+		}
+	}
+
+	_, err := io.WriteString(writer, builder.String())
+	if err != nil {
+		return fmt.Errorf("failed to write summary: %w", err)
+	}
+
+	return nil
+}
+
+// summaryName names tsk in a summary, along with its session if the build had several.
+func summaryName(tsk *TaskReport, sessions map[string]struct{}) string {
+	if len(sessions) < 2 {
+		return tsk.ID.String()
+	}
+
+	return fmt.Sprintf("%s [session %s]", tsk.ID, tsk.Session)
+}
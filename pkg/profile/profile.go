@@ -0,0 +1,223 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+// Package profile records where a build spends its time as a Chrome trace-event file,
+// which may be opened with chrome://tracing or https://ui.perfetto.dev.
+//
+// A [Profiler] is carried through the build in a [context.Context].
+// If no profiler is present, all recording functions are no-ops.
+package profile
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// DefaultLane is the lane used for events recorded without a lane in their context.
+const DefaultLane = "main"
+
+// Event phases, as defined by the trace-event format.
+const (
+	PhaseComplete = "X"
+	PhaseInstant  = "i"
+	PhaseMetadata = "M"
+)
+
+// Event is a single entry in the trace-event format.
+type Event struct {
+	Name     string         `json:"name"`
+	Category string         `json:"cat,omitempty"`
+	Phase    string         `json:"ph"`
+	Time     int64          `json:"ts"`
+	Duration int64          `json:"dur,omitempty"`
+	PID      int            `json:"pid"`
+	TID      int            `json:"tid"`
+	Scope    string         `json:"s,omitempty"`
+	Args     map[string]any `json:"args,omitempty"`
+}
+
+type traceFile struct {
+	TraceEvents     []Event `json:"traceEvents"`
+	DisplayTimeUnit string  `json:"displayTimeUnit"`
+}
+
+// Profiler accumulates trace events.
+type Profiler struct {
+	start time.Time
+
+	mu     sync.Mutex
+	events []Event
+	lanes  map[string]int
+}
+
+// New creates a new [Profiler] whose timestamps are relative to now.
+func New() *Profiler {
+	return &Profiler{
+		start: time.Now(),
+		lanes: make(map[string]int),
+	}
+}
+
+type (
+	profilerKey struct{}
+	laneKey     struct{}
+)
+
+// NewContext returns a context carrying prof.
+func NewContext(ctx context.Context, prof *Profiler) context.Context {
+	return context.WithValue(ctx, profilerKey{}, prof)
+}
+
+// FromContext returns the profiler carried by ctx, or nil.
+func FromContext(ctx context.Context) *Profiler {
+	prof, _ := ctx.Value(profilerKey{}).(*Profiler)
+
+	return prof
+}
+
+// WithLane returns a context whose events are recorded in the named lane.
+// If ctx doesn't carry a profiler it is returned unchanged.
+func WithLane(ctx context.Context, lane string) context.Context {
+	if FromContext(ctx) == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, laneKey{}, lane)
+}
+
+func laneFromContext(ctx context.Context) string {
+	if lane, ok := ctx.Value(laneKey{}).(string); ok {
+		return lane
+	}
+
+	return DefaultLane
+}
+
+// Region is an in-progress complete event.
+type Region struct {
+	prof     *Profiler
+	lane     string
+	category string
+	name     string
+	start    time.Time
+	args     map[string]any
+}
+
+// Begin starts a region in the lane associated with ctx. Call [Region.End] to record it.
+func Begin(ctx context.Context, category, name string) *Region {
+	prof := FromContext(ctx)
+	if prof == nil {
+		return nil
+	}
+
+	return &Region{
+		prof:     prof,
+		lane:     laneFromContext(ctx),
+		category: category,
+		name:     name,
+		start:    time.Now(),
+	}
+}
+
+// SetArg attaches an argument to the region, which is displayed alongside it.
+func (r *Region) SetArg(key string, value any) {
+	if r == nil {
+		return
+	}
+
+	if r.args == nil {
+		r.args = make(map[string]any, 1)
+	}
+
+	r.args[key] = value
+}
+
+// End records the region.
+func (r *Region) End() {
+	if r == nil {
+		return
+	}
+
+	r.prof.record(r.lane, Event{
+		Name:     r.name,
+		Category: r.category,
+		Phase:    PhaseComplete,
+		Time:     r.prof.since(r.start),
+		Duration: time.Since(r.start).Microseconds(),
+		Args:     r.args,
+	})
+}
+
+// Instant records a point-in-time event in the lane associated with ctx.
+func Instant(ctx context.Context, category, name string, args map[string]any) {
+	prof := FromContext(ctx)
+	if prof == nil {
+		return
+	}
+
+	prof.record(laneFromContext(ctx), Event{
+		Name:     name,
+		Category: category,
+		Phase:    PhaseInstant,
+		Time:     prof.since(time.Now()),
+		Scope:    "t",
+		Args:     args,
+	})
+}
+
+// Events returns a copy of the events recorded so far.
+func (p *Profiler) Events() []Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]Event(nil), p.events...)
+}
+
+// WriteFile writes the recorded events to path as a JSON trace-event file.
+func (p *Profiler) WriteFile(fs afero.Fs, path string) error {
+	data, err := json.Marshal(traceFile{
+		TraceEvents:     p.Events(),
+		DisplayTimeUnit: "ms",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode profile: %w", err)
+	}
+
+	err = afero.WriteFile(fs, path, data, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to write profile %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func (p *Profiler) since(t time.Time) int64 {
+	return t.Sub(p.start).Microseconds()
+}
+
+func (p *Profiler) record(lane string, event Event) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	tid, ok := p.lanes[lane]
+	if !ok {
+		tid = len(p.lanes)
+		p.lanes[lane] = tid
+
+		// Name the lane so it's legible in the viewer
+		p.events = append(p.events, Event{
+			Name:  "thread_name",
+			Phase: PhaseMetadata,
+			TID:   tid,
+			Args:  map[string]any{"name": lane},
+		})
+	}
+
+	event.TID = tid
+	p.events = append(p.events, event)
+}
@@ -0,0 +1,77 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package profile_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.bonk.build/pkg/profile"
+)
+
+func TestNoProfiler(t *testing.T) {
+	t.Parallel()
+
+	ctx := profile.WithLane(t.Context(), "lane")
+	assert.Equal(t, t.Context(), ctx)
+
+	// Should be no-ops
+	region := profile.Begin(ctx, "category", "name")
+	region.SetArg("key", "value")
+	region.End()
+	profile.Instant(ctx, "category", "name", nil)
+}
+
+func TestLanes(t *testing.T) {
+	t.Parallel()
+
+	prof := profile.New()
+	ctx := profile.NewContext(t.Context(), prof)
+
+	profile.Begin(ctx, "category", "first").End()
+
+	region := profile.Begin(profile.WithLane(ctx, "other"), "category", "second")
+	region.SetArg("key", "value")
+	region.End()
+
+	events := prof.Events()
+	require.Len(t, events, 4)
+
+	// Each lane is named before its first event
+	assert.Equal(t, profile.PhaseMetadata, events[0].Phase)
+	assert.Equal(t, profile.DefaultLane, events[0].Args["name"])
+	assert.Equal(t, "first", events[1].Name)
+	assert.Equal(t, events[0].TID, events[1].TID)
+
+	assert.Equal(t, "other", events[2].Args["name"])
+	assert.Equal(t, "second", events[3].Name)
+	assert.Equal(t, events[2].TID, events[3].TID)
+	assert.NotEqual(t, events[1].TID, events[3].TID)
+	assert.Equal(t, "value", events[3].Args["key"])
+}
+
+func TestWriteFile(t *testing.T) {
+	t.Parallel()
+
+	prof := profile.New()
+	ctx := profile.NewContext(t.Context(), prof)
+	profile.Instant(ctx, "category", "instant", nil)
+
+	fs := afero.NewMemMapFs()
+	require.NoError(t, prof.WriteFile(fs, "profile.json"))
+
+	data, err := afero.ReadFile(fs, "profile.json")
+	require.NoError(t, err)
+
+	var decoded struct {
+		TraceEvents []profile.Event `json:"traceEvents"`
+	}
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Len(t, decoded.TraceEvents, 2)
+	assert.Equal(t, profile.PhaseInstant, decoded.TraceEvents[1].Phase)
+}
@@ -0,0 +1,206 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+// Package server provides the bonk build server, a daemon which executes builds submitted over a unix socket.
+// Plugins and caches stay warm between builds, so that each build doesn't start from scratch.
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/spf13/afero"
+
+	"go.bonk.build/pkg/executor/observable"
+	"go.bonk.build/pkg/executor/rpc"
+	"go.bonk.build/pkg/task"
+)
+
+// DefaultIdleTimeout is the default value of [Options.IdleTimeout].
+const DefaultIdleTimeout = 30 * time.Minute
+
+var (
+	// ErrAlreadyRunning is returned when starting a server on a socket another server is listening on.
+	ErrAlreadyRunning = errors.New("a build server is already listening")
+	// ErrNotRunning is returned when connecting to a socket no server is listening on.
+	ErrNotRunning = errors.New("no build server is listening")
+)
+
+// Options configures [Serve].
+type Options struct {
+	// Socket is the path of the unix socket to listen on.
+	Socket string
+	// IdleTimeout is how long the server waits for a build before shutting down.
+	// If it isn't positive, the server runs until it's canceled.
+	IdleTimeout time.Duration
+	// Configuration identifies the options the server's builder was created with.
+	// Builds are only accepted from clients with the same configuration, see [rpc.NewBuildClient].
+	Configuration string
+}
+
+// Serve executes the builds submitted to the socket with builder, until ctx is canceled
+// or no build has been submitted for the idle timeout. Builds still executing when ctx is canceled are canceled.
+func Serve(ctx context.Context, builder rpc.Builder, options Options) error {
+	listener, err := listen(ctx, options.Socket)
+	if err != nil {
+		return err
+	}
+
+	idle := newIdleTracker(builder, options.IdleTimeout)
+	defer idle.stop()
+
+	server := grpc.NewServer()
+	rpc.RegisterBuildServer(server, idle, options.Configuration)
+
+	served := make(chan error, 1)
+	go func() {
+		served <- server.Serve(listener)
+	}()
+
+	slog.InfoContext(ctx, "build server listening", "socket", options.Socket, "idle-timeout", options.IdleTimeout)
+
+	select {
+	case err := <-served:
+		return fmt.Errorf("build server failed: %w", err)
+
+	case <-idle.idle:
+		slog.InfoContext(ctx, "build server idle, shutting down")
+		server.GracefulStop()
+
+	case <-ctx.Done():
+		slog.InfoContext(ctx, "build server stopping")
+		server.Stop()
+	}
+
+	return nil
+}
+
+// Dial connects to the server listening on socket.
+// It returns [ErrNotRunning] if nothing is listening, so that callers can build without a server instead.
+func Dial(ctx context.Context, socket string) (*grpc.ClientConn, error) {
+	// GRPC connects lazily, so check that there's a server first
+	probe, err := (&net.Dialer{}).DialContext(ctx, "unix", socket)
+	if err != nil {
+		return nil, fmt.Errorf("%w at %s: %w", ErrNotRunning, socket, err)
+	}
+	_ = probe.Close()
+
+	conn, err := grpc.NewClient("unix:"+socket, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to build server: %w", err)
+	}
+
+	return conn, nil
+}
+
+// listen creates the socket, replacing it if it was left behind by a server which didn't shut down cleanly.
+func listen(ctx context.Context, socket string) (net.Listener, error) {
+	osFs := afero.NewOsFs()
+
+	err := osFs.MkdirAll(filepath.Dir(socket), 0o700)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create socket directory: %w", err)
+	}
+
+	if exists, _ := afero.Exists(osFs, socket); exists {
+		conn, err := Dial(ctx, socket)
+		if err == nil {
+			_ = conn.Close()
+
+			return nil, fmt.Errorf("%w at %s", ErrAlreadyRunning, socket)
+		}
+
+		slog.DebugContext(ctx, "removing stale socket", "socket", socket)
+
+		err = osFs.Remove(socket)
+		if err != nil {
+			return nil, fmt.Errorf("failed to remove stale socket: %w", err)
+		}
+	}
+
+	listener, err := (&net.ListenConfig{}).Listen(ctx, "unix", socket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", socket, err)
+	}
+
+	return listener, nil
+}
+
+// idleTracker wraps a builder, signaling once no builds have been executing for the timeout.
+type idleTracker struct {
+	rpc.Builder
+
+	idle     chan struct{}
+	timeout  time.Duration
+	timer    *time.Timer
+	active   int
+	mu       sync.Mutex
+	idleOnce sync.Once
+}
+
+func newIdleTracker(builder rpc.Builder, timeout time.Duration) *idleTracker {
+	tracker := &idleTracker{
+		Builder: builder,
+		idle:    make(chan struct{}),
+		timeout: timeout,
+	}
+
+	if timeout > 0 {
+		tracker.timer = time.AfterFunc(timeout, tracker.expire)
+	}
+
+	return tracker
+}
+
+// Build implements rpc.Builder.
+func (t *idleTracker) Build(
+	ctx context.Context,
+	sessions map[task.Session][]*task.Task,
+	result *task.Result,
+	observers ...observable.Observer,
+) error {
+	t.mu.Lock()
+	t.active++
+	if t.timer != nil {
+		t.timer.Stop()
+	}
+	t.mu.Unlock()
+
+	defer func() {
+		t.mu.Lock()
+		t.active--
+		if t.timer != nil && t.active == 0 {
+			t.timer.Reset(t.timeout)
+		}
+		t.mu.Unlock()
+	}()
+
+	return t.Builder.Build(ctx, sessions, result, observers...) //nolint:wrapcheck
+}
+
+func (t *idleTracker) expire() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// A build may have started while the timer was firing
+	if t.active == 0 {
+		t.idleOnce.Do(func() {
+			close(t.idle)
+		})
+	}
+}
+
+func (t *idleTracker) stop() {
+	if t.timer != nil {
+		t.timer.Stop()
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package server_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.bonk.build/pkg/executor/observable"
+	"go.bonk.build/pkg/executor/rpc"
+	"go.bonk.build/pkg/server"
+	"go.bonk.build/pkg/task"
+)
+
+type countingBuilder struct {
+	builds chan struct{}
+}
+
+func (b countingBuilder) Build(
+	context.Context,
+	map[task.Session][]*task.Task,
+	*task.Result,
+	...observable.Observer,
+) error {
+	b.builds <- struct{}{}
+
+	return nil
+}
+
+func serve(t *testing.T, options server.Options) (countingBuilder, <-chan error) {
+	t.Helper()
+
+	builder := countingBuilder{builds: make(chan struct{}, 1)}
+	served := make(chan error, 1)
+
+	go func() {
+		served <- server.Serve(t.Context(), builder, options)
+	}()
+
+	// Wait for the socket to be listened on
+	require.Eventually(t, func() bool {
+		conn, err := server.Dial(t.Context(), options.Socket)
+		if err != nil {
+			return false
+		}
+
+		return conn.Close() == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	return builder, served
+}
+
+func TestServe(t *testing.T) {
+	t.Parallel()
+
+	socket := filepath.Join(t.TempDir(), "server.sock")
+	builder, _ := serve(t, server.Options{Socket: socket})
+
+	conn, err := server.Dial(t.Context(), socket)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	session := task.NewLocalSession(task.NewSessionID(), t.TempDir())
+	err = rpc.NewBuildClient(conn, "").Build(t.Context(), map[task.Session][]*task.Task{session: nil}, nil)
+	require.NoError(t, err)
+	assert.Len(t, builder.builds, 1)
+}
+
+func TestServe_AlreadyRunning(t *testing.T) {
+	t.Parallel()
+
+	socket := filepath.Join(t.TempDir(), "server.sock")
+	serve(t, server.Options{Socket: socket})
+
+	err := server.Serve(t.Context(), countingBuilder{}, server.Options{Socket: socket})
+	require.ErrorIs(t, err, server.ErrAlreadyRunning)
+}
+
+func TestServe_IdleTimeout(t *testing.T) {
+	t.Parallel()
+
+	socket := filepath.Join(t.TempDir(), "server.sock")
+	_, served := serve(t, server.Options{
+		Socket:      socket,
+		IdleTimeout: 100 * time.Millisecond,
+	})
+
+	select {
+	case err := <-served:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "server didn't shut down once idle")
+	}
+
+	_, err := server.Dial(t.Context(), socket)
+	require.ErrorIs(t, err, server.ErrNotRunning)
+}
+
+func TestDial_NotRunning(t *testing.T) {
+	t.Parallel()
+
+	_, err := server.Dial(t.Context(), filepath.Join(t.TempDir(), "server.sock"))
+	require.ErrorIs(t, err, server.ErrNotRunning)
+}
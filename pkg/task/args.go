@@ -0,0 +1,87 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package task
+
+import "reflect"
+
+// CloneArgs returns a deep copy of a task's args, so that the copy isn't changed by changes to the original.
+func CloneArgs(args any) any {
+	if args == nil {
+		return nil
+	}
+
+	return copyValue(reflect.ValueOf(args), func(str string) string { return str }).Interface()
+}
+
+// copyValue returns a deep copy of value, with each string it contains replaced by str.
+func copyValue(value reflect.Value, str func(string) string) reflect.Value {
+	switch value.Kind() { //nolint:exhaustive
+	case reflect.String:
+		return reflect.ValueOf(str(value.String())).Convert(value.Type())
+
+	case reflect.Interface, reflect.Pointer:
+		if value.IsNil() {
+			return value
+		}
+
+		elem := copyValue(value.Elem(), str)
+		if value.Kind() == reflect.Pointer {
+			ptr := reflect.New(elem.Type())
+			ptr.Elem().Set(elem)
+
+			return ptr
+		}
+
+		out := reflect.New(value.Type()).Elem()
+		out.Set(elem)
+
+		return out
+
+	case reflect.Map:
+		if value.IsNil() {
+			return value
+		}
+
+		out := reflect.MakeMapWithSize(value.Type(), value.Len())
+		for iter := value.MapRange(); iter.Next(); {
+			out.SetMapIndex(iter.Key(), copyValue(iter.Value(), str))
+		}
+
+		return out
+
+	case reflect.Slice:
+		if value.IsNil() {
+			return value
+		}
+
+		out := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		for idx := range value.Len() {
+			out.Index(idx).Set(copyValue(value.Index(idx), str))
+		}
+
+		return out
+
+	case reflect.Array, reflect.Struct:
+		out := reflect.New(value.Type()).Elem()
+		out.Set(value)
+
+		if value.Kind() == reflect.Array {
+			for idx := range value.Len() {
+				out.Index(idx).Set(copyValue(value.Index(idx), str))
+			}
+		} else {
+			// Unexported fields can't be set, so are left as they were
+			for idx := range value.NumField() {
+				if out.Field(idx).CanSet() {
+					out.Field(idx).Set(copyValue(value.Field(idx), str))
+				}
+			}
+		}
+
+		return out
+
+	default:
+		return value
+	}
+}
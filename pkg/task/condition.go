@@ -0,0 +1,192 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package task
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+
+	"github.com/spf13/afero"
+)
+
+// Outcome describes how an executed task finished, which the conditions of the tasks after it may check.
+type Outcome string
+
+const (
+	// OutcomeExecuted means the task was executed, and succeeded.
+	OutcomeExecuted Outcome = "executed"
+	// OutcomeCached means the task was up to date, and its previous result was reused.
+	OutcomeCached Outcome = "cached"
+	// OutcomeSkipped means the task's condition didn't hold, so it wasn't executed.
+	OutcomeSkipped Outcome = "skipped"
+)
+
+// UpstreamResult is the result of an upstream task, which the conditions of the tasks after it may check.
+type UpstreamResult struct {
+	Outcome Outcome
+	// Outputs are the outputs the task produced, which is empty if it was skipped.
+	Outputs []string
+}
+
+// Facts are what's known about a build before its tasks are executed, which their conditions are evaluated against.
+type Facts struct {
+	// Profiles are the names of the profiles selected for the build, such as "ci".
+	Profiles []string `json:"profiles,omitempty"`
+	// Env contains the environment variables of the build.
+	Env map[string]string `json:"env,omitempty"`
+}
+
+type factsKey struct{}
+
+// ContextWithFacts returns a context for a build with its own facts,
+// which conditions are evaluated against instead of those of the scheduler executing it.
+func ContextWithFacts(ctx context.Context, facts Facts) context.Context {
+	return context.WithValue(ctx, factsKey{}, facts)
+}
+
+// FactsFromContext returns the facts of the build ctx belongs to, if it has its own.
+func FactsFromContext(ctx context.Context) (Facts, bool) {
+	facts, ok := ctx.Value(factsKey{}).(Facts)
+
+	return facts, ok
+}
+
+// Condition decides whether a task is executed, and is evaluated when the task is scheduled.
+// Every clause which is set must hold for the task to be executed, otherwise it's skipped.
+type Condition struct {
+	// Profiles lists profiles, at least one of which must be selected.
+	Profiles []string `json:"profiles,omitempty" mapstructure:"profiles"`
+	// Env maps environment variables to the patterns their values must match, as matched by [path.Match].
+	// Variables which aren't set never match, so "*" only requires that the variable is set.
+	Env map[string]string `json:"env,omitempty" mapstructure:"env"`
+	// Exists lists patterns relative to [Session.SourceFS], each of which must match at least one file.
+	Exists []string `json:"exists,omitempty" mapstructure:"exists"`
+	// InputsExist requires that each of the task's inputs matches at least one file.
+	InputsExist bool `json:"inputsExist,omitempty" mapstructure:"inputs-exist"`
+	// Results maps upstream tasks to the outcomes they must finish with.
+	// Evaluating the condition waits for each of them to finish, so they must be scheduled with the task or before it,
+	// or be followups of its ancestors, and their conditions must not check the task in turn.
+	Results map[ID]Outcome `json:"results,omitempty" mapstructure:"results"`
+	// Outputs maps upstream tasks to patterns, each of which must match at least one of their outputs,
+	// as matched by [path.Match]. Evaluating the condition waits for each of them to finish, as with Results.
+	Outputs map[ID][]string `json:"outputs,omitempty" mapstructure:"outputs"`
+	// Not is a condition which must not hold.
+	Not *Condition `json:"not,omitempty" mapstructure:"not"`
+}
+
+// Evaluate reports whether the condition of tsk holds for its execution in session.
+// upstream is called to wait for the result of each upstream task in the condition.
+func (c *Condition) Evaluate(
+	facts Facts,
+	session Session,
+	tsk *Task,
+	upstream func(id ID) (UpstreamResult, error),
+) (bool, error) {
+	if len(c.Profiles) > 0 && !slices.ContainsFunc(c.Profiles, func(profile string) bool {
+		return slices.Contains(facts.Profiles, profile)
+	}) {
+		return false, nil
+	}
+
+	for name, pattern := range c.Env {
+		value, ok := facts.Env[name]
+		if !ok {
+			return false, nil
+		}
+
+		matched, err := path.Match(pattern, value)
+		if err != nil {
+			return false, fmt.Errorf("invalid pattern for environment variable %s: %w", name, err)
+		}
+		if !matched {
+			return false, nil
+		}
+	}
+
+	patterns := slices.Clone(c.Exists)
+	if c.InputsExist {
+		patterns = append(patterns, tsk.Inputs...)
+	}
+	for _, pattern := range patterns {
+		matches, err := afero.Glob(session.SourceFS(), pattern)
+		if err != nil {
+			return false, fmt.Errorf("failed to match %s: %w", pattern, err)
+		}
+		if len(matches) == 0 {
+			return false, nil
+		}
+	}
+
+	for id, expected := range c.Results {
+		result, err := upstream(id)
+		if err != nil {
+			return false, err
+		}
+		if result.Outcome != expected {
+			return false, nil
+		}
+	}
+
+	for id, patterns := range c.Outputs {
+		result, err := upstream(id)
+		if err != nil {
+			return false, err
+		}
+
+		for _, pattern := range patterns {
+			matched, err := matchAny(pattern, result.Outputs)
+			if err != nil {
+				return false, fmt.Errorf("invalid pattern for outputs of %s: %w", id, err)
+			}
+			if !matched {
+				return false, nil
+			}
+		}
+	}
+
+	if c.Not != nil {
+		holds, err := c.Not.Evaluate(facts, session, tsk, upstream)
+
+		return !holds, err
+	}
+
+	return true, nil
+}
+
+// Upstream returns the IDs of the tasks whose results the condition checks.
+func (c *Condition) Upstream() []ID {
+	if c == nil {
+		return nil
+	}
+
+	upstream := make([]ID, 0, len(c.Results)+len(c.Outputs))
+	for id := range c.Results {
+		upstream = append(upstream, id)
+	}
+	for id := range c.Outputs {
+		if _, ok := c.Results[id]; !ok {
+			upstream = append(upstream, id)
+		}
+	}
+	slices.Sort(upstream)
+
+	return append(upstream, c.Not.Upstream()...)
+}
+
+// matchAny reports whether pattern matches any of values.
+func matchAny(pattern string, values []string) (bool, error) {
+	for _, value := range values {
+		matched, err := path.Match(pattern, value)
+		if err != nil || matched {
+			return matched, err //nolint:wrapcheck
+		}
+	}
+
+	// Match against nothing, so that invalid patterns are reported even without values
+	_, err := path.Match(pattern, "")
+
+	return false, err //nolint:wrapcheck
+}
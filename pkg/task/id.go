@@ -33,3 +33,13 @@ func (id ID) GetChild(names ...string) ID {
 func (id ID) Cut() (string, string, bool) {
 	return strings.Cut(id.String(), TaskIDSep)
 }
+
+// Parent returns the ID this one is a child of, if it has one.
+func (id ID) Parent() (ID, bool) {
+	idx := strings.LastIndex(id.String(), TaskIDSep)
+	if idx < 0 {
+		return "", false
+	}
+
+	return id[:idx], true
+}
@@ -0,0 +1,36 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package task
+
+import (
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+const (
+	// LogFileText is the name of the human-readable log file written to each task's output directory.
+	LogFileText = "log.txt"
+	// LogFileJSON is the name of the structured log file written to each task's output directory.
+	LogFileJSON = "log.jsonl"
+)
+
+// ReadLogTail returns the last lines lines of a task's text log, or an empty string if there isn't one.
+func ReadLogTail(session Session, id ID, lines int) string {
+	if session == nil {
+		return ""
+	}
+
+	contents, err := afero.ReadFile(OutputFS(session, id), LogFileText)
+	if err != nil {
+		return ""
+	}
+
+	logLines := strings.Split(strings.TrimRight(string(contents), "\n"), "\n")
+	if len(logLines) > lines {
+		logLines = logLines[len(logLines)-lines:]
+	}
+
+	return strings.Join(logLines, "\n")
+}
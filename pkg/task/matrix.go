@@ -0,0 +1,256 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package task
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"go.uber.org/multierr"
+)
+
+// ErrInvalidMatrix is returned when a task's matrix can't be expanded.
+var ErrInvalidMatrix = errors.New("invalid matrix")
+
+// Matrix maps the names of parameters to the values a task is expanded over, such as
+// {"env": ["dev", "prod"], "region": ["us", "eu"]}.
+type Matrix map[string][]string
+
+// matrixPlaceholder matches the placeholders substituted with a cell's values, such as ${matrix.env}.
+var matrixPlaceholder = regexp.MustCompile(`\$\{matrix\.([^}]*)\}`)
+
+// ExpandMatrices replaces each task with a matrix by one task for each combination of its values, called a cell.
+//
+// Cells are children of the task, with the values of each cell appended to its ID in the order of their names,
+// so that the cell of {"env": "dev", "region": "us"} of the task Render is Render.dev.us.
+// Placeholders such as ${matrix.env} in the strings of each cell's args, inputs and dependencies are substituted
+// with the cell's values.
+//
+// Tasks may depend on a whole matrix by its ID, on a row of it such as Render.dev, or on a single cell,
+// and are made to depend on every matching cell. Likewise, conditions checking the results or outputs of a matrix
+// are made to check those of every matching cell.
+func ExpandMatrices(tasks []*Task) ([]*Task, error) {
+	var (
+		err      error
+		expanded = make([]*Task, 0, len(tasks))
+		cells    = make(map[ID][]ID)
+	)
+	for _, tsk := range tasks {
+		if len(tsk.Matrix) == 0 {
+			expanded = append(expanded, tsk)
+
+			continue
+		}
+
+		tskCells, cellErr := expandMatrix(tsk)
+		if multierr.AppendInto(&err, cellErr) {
+			continue
+		}
+
+		for _, cell := range tskCells {
+			cells[tsk.ID] = append(cells[tsk.ID], cell.ID)
+		}
+		expanded = append(expanded, tskCells...)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cells) == 0 {
+		return expanded, nil
+	}
+
+	// Dependencies and conditions are rewritten on copies, so that the tasks passed in aren't modified
+	for idx, tsk := range expanded {
+		dependencies := cellDependencies(cells, tsk.Dependencies)
+		when := cellCondition(cells, tsk.When)
+		if !slices.Equal(dependencies, tsk.Dependencies) || when != tsk.When {
+			rewritten := *tsk
+			rewritten.Dependencies = dependencies
+			rewritten.When = when
+			expanded[idx] = &rewritten
+		}
+	}
+
+	return expanded, nil
+}
+
+// expandMatrix returns the cells of the task's matrix.
+func expandMatrix(tsk *Task) ([]*Task, error) {
+	names := make([]string, 0, len(tsk.Matrix))
+	for name, values := range tsk.Matrix {
+		if len(values) == 0 {
+			return nil, fmt.Errorf("%w: %s has no values for %s", ErrInvalidMatrix, tsk.ID, name)
+		}
+
+		for _, value := range values {
+			if value == "" || strings.Contains(value, TaskIDSep) {
+				return nil, fmt.Errorf("%w: %s has value %q for %s, which can't be part of a task ID",
+					ErrInvalidMatrix, tsk.ID, value, name)
+			}
+		}
+
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var cells []*Task
+
+	// Combinations are enumerated with the last name changing fastest, in the order of each name's values
+	indices := make([]int, len(names))
+	for {
+		values := make(map[string]string, len(names))
+		parts := make([]string, len(names))
+		for idx, name := range names {
+			values[name] = tsk.Matrix[name][indices[idx]]
+			parts[idx] = values[name]
+		}
+
+		cell, err := expandCell(tsk, tsk.ID.GetChild(parts...), values)
+		if err != nil {
+			return nil, err
+		}
+		cells = append(cells, cell)
+
+		idx := len(names) - 1
+		for ; idx >= 0; idx-- {
+			indices[idx]++
+			if indices[idx] < len(tsk.Matrix[names[idx]]) {
+				break
+			}
+			indices[idx] = 0
+		}
+		if idx < 0 {
+			return cells, nil
+		}
+	}
+}
+
+// expandCell returns a copy of the task with the given ID and its placeholders substituted with values.
+func expandCell(tsk *Task, id ID, values map[string]string) (*Task, error) {
+	sub := substituter{values: values}
+
+	cell := *tsk
+	cell.ID = id
+	cell.Matrix = nil
+
+	cell.Inputs = nil
+	for _, input := range tsk.Inputs {
+		cell.Inputs = append(cell.Inputs, sub.string(input))
+	}
+
+	cell.Dependencies = nil
+	for _, dependency := range tsk.Dependencies {
+		cell.Dependencies = append(cell.Dependencies, ID(sub.string(dependency.String())))
+	}
+
+	if tsk.Args != nil {
+		cell.Args = copyValue(reflect.ValueOf(tsk.Args), sub.string).Interface()
+	}
+
+	if sub.err != nil {
+		return nil, fmt.Errorf("%w: failed to expand %s: %w", ErrInvalidMatrix, id, sub.err)
+	}
+
+	return &cell, nil
+}
+
+// cellDependencies replaces dependencies on matrices, or rows of them, with dependencies on their matching cells.
+func cellDependencies(cells map[ID][]ID, dependencies []ID) []ID {
+	var rewritten []ID
+	for _, dependency := range dependencies {
+		var matched []ID
+		for matrix, matrixCells := range cells {
+			if dependency != matrix && !strings.HasPrefix(dependency.String(), matrix.String()+TaskIDSep) {
+				continue
+			}
+
+			for _, cell := range matrixCells {
+				if cell == dependency || strings.HasPrefix(cell.String(), dependency.String()+TaskIDSep) {
+					matched = append(matched, cell)
+				}
+			}
+		}
+
+		// Dependencies on anything else, such as the followups of a cell, are left alone
+		if len(matched) == 0 {
+			matched = []ID{dependency}
+		}
+		rewritten = append(rewritten, matched...)
+	}
+
+	return rewritten
+}
+
+// cellCondition replaces the upstream tasks of cond which are matrices, or rows of them, with their matching cells,
+// each of which must meet the requirement of the matrix. cond is copied if any are replaced.
+func cellCondition(cells map[ID][]ID, cond *Condition) *Condition {
+	if cond == nil {
+		return nil
+	}
+
+	results, resultsChanged := cellUpstream(cells, cond.Results)
+	outputs, outputsChanged := cellUpstream(cells, cond.Outputs)
+	not := cellCondition(cells, cond.Not)
+	if !resultsChanged && !outputsChanged && not == cond.Not {
+		return cond
+	}
+
+	rewritten := *cond
+	rewritten.Results = results
+	rewritten.Outputs = outputs
+	rewritten.Not = not
+
+	return &rewritten
+}
+
+// cellUpstream replaces the matrices, or rows of them, among the keys of upstream with their matching cells,
+// reporting whether any were replaced.
+func cellUpstream[V any](cells map[ID][]ID, upstream map[ID]V) (map[ID]V, bool) {
+	var (
+		rewritten = make(map[ID]V, len(upstream))
+		changed   bool
+	)
+	for id, value := range upstream {
+		matched := cellDependencies(cells, []ID{id})
+		if len(matched) != 1 || matched[0] != id {
+			changed = true
+		}
+
+		for _, cell := range matched {
+			rewritten[cell] = value
+		}
+	}
+	if !changed {
+		return upstream, false
+	}
+
+	return rewritten, true
+}
+
+// substituter substitutes the placeholders in strings with the values of a matrix cell.
+type substituter struct {
+	values map[string]string
+	err    error
+}
+
+// string returns str with its placeholders substituted.
+func (s *substituter) string(str string) string {
+	return matrixPlaceholder.ReplaceAllStringFunc(str, func(placeholder string) string {
+		name := matrixPlaceholder.FindStringSubmatch(placeholder)[1]
+
+		value, ok := s.values[name]
+		if !ok {
+			multierr.AppendInto(&s.err, fmt.Errorf("no matrix value for placeholder %s", placeholder))
+
+			return placeholder
+		}
+
+		return value
+	})
+}
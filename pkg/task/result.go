@@ -18,6 +18,8 @@ type Result struct {
 	outputs []string
 	// followupTasks is a list of tasks to be executed after this task completes.
 	followupTasks []Task
+	// cached is set if the result was restored from a previous execution instead of being produced by an executor.
+	cached bool
 }
 
 type resultJson struct {
@@ -86,6 +88,30 @@ func (r *Result) AddFollowupTasks(tasks ...*Task) {
 	r.followupTasks = newFollowups
 }
 
+// MarkCached records that the result was restored from a previous execution.
+func (r *Result) MarkCached() {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cached = true
+}
+
+// IsCached returns true if the result was restored from a previous execution.
+func (r *Result) IsCached() bool {
+	if r == nil {
+		return false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.cached
+}
+
 func (r *Result) Append(other *Result) {
 	if r == nil || other == nil || r == other {
 		return
@@ -8,6 +8,9 @@ import (
 	"github.com/spf13/afero"
 )
 
+// OutputDir is the directory, relative to the project root, that a [LocalSession] writes outputs to.
+const OutputDir = ".bonk"
+
 // SessionID is a unique identifier per-session.
 type SessionID = uuid.UUID
 
@@ -83,7 +86,7 @@ func NewLocalSession(id SessionID, localPath string) LocalSession {
 		localPath: localPath,
 
 		sourceFs: afero.NewReadOnlyFs(sessionRoot),
-		outputFs: afero.NewBasePathFs(sessionRoot, ".bonk"),
+		outputFs: afero.NewBasePathFs(sessionRoot, OutputDir),
 	}
 }
 
@@ -3,6 +3,8 @@
 
 package task
 
+import "time"
+
 // Task represents a unit of work to be executed.
 type Task struct {
 	// ID describes how this task is addressed.
@@ -16,6 +18,55 @@ type Task struct {
 	Dependencies []ID `json:"dependencies,omitempty"`
 	// Args contains any arguments that may be passed to the executor.
 	Args any `json:"args"`
+	// Resources describes what this task needs while executing, which the scheduler must have available.
+	Resources Resources `json:"resources,omitempty"`
+	// Timeout bounds how long each attempt at executing this task may take, overriding the executor's default.
+	// Tasks without a timeout from either may execute forever.
+	Timeout time.Duration `json:"timeout,omitempty"`
+	// Retry describes how this task is retried when it fails, overriding the executor's default.
+	Retry *RetryPolicy `json:"retry,omitempty"`
+	// Matrix expands this task into one task for each combination of its values, see [ExpandMatrices].
+	Matrix Matrix `json:"matrix,omitempty"`
+	// When is the condition under which this task is executed. Tasks without a condition are always executed.
+	When *Condition `json:"when,omitempty"`
+}
+
+// Resources are amounts of named resources, such as {"cpu": 4} or {"kube-api": 1}.
+// Resources without a configured limit are unlimited.
+//
+// Amounts are always whole numbers of units, there are no named classes such as "memory: heavy".
+// Classes are instead expressed as units of a resource whose limit is chosen to match, e.g. with a memory limit of 8,
+// heavy tasks might require {"memory": 4} and light tasks {"memory": 1}.
+type Resources map[string]int
+
+// RetryPolicy describes how a task which fails is retried.
+type RetryPolicy struct {
+	// MaxAttempts is the most times the task is executed, including the first. 0 or 1 never retries the task.
+	MaxAttempts int `json:"maxAttempts,omitempty" mapstructure:"max-attempts"`
+	// Backoff is the delay before the first retry, which doubles for each retry after it.
+	Backoff time.Duration `json:"backoff,omitempty" mapstructure:"backoff"`
+	// MaxBackoff caps the delay between attempts, if set.
+	MaxBackoff time.Duration `json:"maxBackoff,omitempty" mapstructure:"max-backoff"`
+	// RetryOn are the kinds of errors which are retried, such as "deadline-exceeded" to retry attempts which time out.
+	// Errors which their executor marked as retryable are always retried.
+	RetryOn []string `json:"retryOn,omitempty" mapstructure:"retry-on"`
+}
+
+// Delay returns how long to wait after the given attempt fails, before the next attempt.
+func (p *RetryPolicy) Delay(attempt int) time.Duration {
+	delay := p.Backoff
+	for range attempt - 1 {
+		if p.MaxBackoff > 0 && delay >= p.MaxBackoff {
+			break
+		}
+		delay *= 2
+	}
+
+	if p.MaxBackoff > 0 {
+		delay = min(delay, p.MaxBackoff)
+	}
+
+	return delay
 }
 
 type Option func(*Task)
@@ -53,3 +104,50 @@ func WithDependencies(dependencies ...ID) Option {
 		tsk.Dependencies = append(tsk.Dependencies, dependencies...)
 	}
 }
+
+// WithResources adds resource requirements to this task.
+func WithResources(resources Resources) Option {
+	return func(tsk *Task) {
+		if tsk.Resources == nil {
+			tsk.Resources = make(Resources, len(resources))
+		}
+
+		for name, amount := range resources {
+			tsk.Resources[name] += amount
+		}
+	}
+}
+
+// WithMatrix expands this task into one task for each combination of the matrix's values.
+func WithMatrix(matrix Matrix) Option {
+	return func(tsk *Task) {
+		if tsk.Matrix == nil {
+			tsk.Matrix = make(Matrix, len(matrix))
+		}
+
+		for name, values := range matrix {
+			tsk.Matrix[name] = append(tsk.Matrix[name], values...)
+		}
+	}
+}
+
+// WithCondition only executes this task when condition holds, skipping it otherwise.
+func WithCondition(condition Condition) Option {
+	return func(tsk *Task) {
+		tsk.When = &condition
+	}
+}
+
+// WithTimeout bounds how long each attempt at executing this task may take.
+func WithTimeout(timeout time.Duration) Option {
+	return func(tsk *Task) {
+		tsk.Timeout = timeout
+	}
+}
+
+// WithRetry retries this task according to policy when it fails.
+func WithRetry(policy RetryPolicy) Option {
+	return func(tsk *Task) {
+		tsk.Retry = &policy
+	}
+}
@@ -5,7 +5,9 @@ package task_test
 
 import (
 	"testing"
+	"time"
 
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -70,3 +72,225 @@ func TestNewWithDependencies(t *testing.T) {
 	require.Len(t, tsk.Dependencies, 1)
 	require.Equal(t, task.ID("root.sibling"), tsk.Dependencies[0])
 }
+
+func TestIDParent(t *testing.T) {
+	t.Parallel()
+
+	parent, ok := task.NewID("root", "child", "grandchild").Parent()
+	require.True(t, ok)
+	require.Equal(t, task.NewID("root", "child"), parent)
+
+	parent, ok = parent.Parent()
+	require.True(t, ok)
+	require.Equal(t, task.ID("root"), parent)
+
+	_, ok = parent.Parent()
+	require.False(t, ok)
+}
+
+func TestNewWithResources(t *testing.T) {
+	t.Parallel()
+
+	tsk := task.New(
+		task.NewID("root", "child"),
+		"exec",
+		nil,
+		task.WithResources(task.Resources{"cpu": 2}),
+		task.WithResources(task.Resources{"cpu": 1, "kube-api": 1}),
+	)
+
+	require.NotNil(t, tsk)
+	assert.Equal(t, task.Resources{"cpu": 3, "kube-api": 1}, tsk.Resources)
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	t.Parallel()
+
+	policy := task.RetryPolicy{
+		MaxAttempts: 5,
+		Backoff:     time.Second,
+		MaxBackoff:  3 * time.Second,
+	}
+
+	tsk := task.New(task.NewID("root"), "exec", nil, task.WithTimeout(time.Minute), task.WithRetry(policy))
+	require.NotNil(t, tsk.Retry)
+	assert.Equal(t, time.Minute, tsk.Timeout)
+
+	assert.Equal(t, time.Second, tsk.Retry.Delay(1))
+	assert.Equal(t, 2*time.Second, tsk.Retry.Delay(2))
+	assert.Equal(t, 3*time.Second, tsk.Retry.Delay(3))
+	assert.Equal(t, 3*time.Second, tsk.Retry.Delay(4))
+}
+
+func TestExpandMatrices(t *testing.T) {
+	t.Parallel()
+
+	render := task.New(
+		task.NewID("Render"),
+		"exec",
+		map[string]any{
+			"env":      "${matrix.env}",
+			"release":  []string{"app-${matrix.env}-${matrix.region}"},
+			"replicas": 3,
+		},
+		task.WithInputs("envs/${matrix.env}/*.yaml"),
+		task.WithMatrix(task.Matrix{"region": {"us", "eu"}, "env": {"dev", "prod"}}),
+	)
+	deploy := task.New(
+		task.NewID("Deploy"),
+		"exec",
+		nil,
+		task.WithDependencies(task.NewID("Render", "${matrix.env}")),
+		task.WithMatrix(task.Matrix{"env": {"prod"}}),
+	)
+	test := task.New(task.NewID("Test"), "exec", nil, task.WithDependencies(task.NewID("Render", "dev", "us")))
+	publish := task.New(task.NewID("Publish"), "exec", nil, task.WithDependencies(task.NewID("Render")))
+
+	tasks, err := task.ExpandMatrices([]*task.Task{render, deploy, test, publish})
+	require.NoError(t, err)
+
+	ids := make([]task.ID, len(tasks))
+	for idx, tsk := range tasks {
+		ids[idx] = tsk.ID
+	}
+	assert.Equal(t, []task.ID{
+		"Render.dev.us", "Render.dev.eu", "Render.prod.us", "Render.prod.eu", "Deploy.prod", "Test", "Publish",
+	}, ids)
+
+	// Cells have the matrix values substituted
+	assert.Equal(t, map[string]any{
+		"env":      "prod",
+		"release":  []string{"app-prod-us"},
+		"replicas": 3,
+	}, tasks[2].Args)
+	assert.Equal(t, []string{"envs/prod/*.yaml"}, tasks[2].Inputs)
+	assert.Nil(t, tasks[2].Matrix)
+
+	// Dependents depend on a row, a single cell, or every cell
+	assert.Equal(t, []task.ID{"Render.prod.us", "Render.prod.eu"}, tasks[4].Dependencies)
+	assert.Equal(t, []task.ID{"Render.dev.us"}, tasks[5].Dependencies)
+	assert.Equal(t, []task.ID{
+		"Render.dev.us", "Render.dev.eu", "Render.prod.us", "Render.prod.eu",
+	}, tasks[6].Dependencies)
+
+	// The tasks passed in aren't modified
+	assert.Equal(t, "${matrix.env}", render.Args.(map[string]any)["env"]) //nolint:forcetypeassert
+	assert.Equal(t, []task.ID{"Render"}, publish.Dependencies)
+}
+
+func TestExpandMatrices_Conditions(t *testing.T) {
+	t.Parallel()
+
+	render := task.New(task.NewID("Render"), "exec", nil,
+		task.WithMatrix(task.Matrix{"region": {"us", "eu"}, "env": {"dev", "prod"}}))
+	notify := task.New(task.NewID("Notify"), "exec", nil, task.WithCondition(task.Condition{
+		Results: map[task.ID]task.Outcome{"Render.prod": task.OutcomeExecuted},
+		Not: &task.Condition{
+			Outputs: map[task.ID][]string{"Render": {"*.yaml"}, "Lint": {"*.txt"}},
+		},
+	}))
+
+	tasks, err := task.ExpandMatrices([]*task.Task{render, notify})
+	require.NoError(t, err)
+	require.Len(t, tasks, 5)
+
+	// Conditions check every matching cell, and other tasks are left alone
+	when := tasks[4].When
+	assert.Equal(t, map[task.ID]task.Outcome{
+		"Render.prod.us": task.OutcomeExecuted,
+		"Render.prod.eu": task.OutcomeExecuted,
+	}, when.Results)
+	assert.Equal(t, map[task.ID][]string{
+		"Render.dev.us":  {"*.yaml"},
+		"Render.dev.eu":  {"*.yaml"},
+		"Render.prod.us": {"*.yaml"},
+		"Render.prod.eu": {"*.yaml"},
+		"Lint":           {"*.txt"},
+	}, when.Not.Outputs)
+
+	// The tasks passed in aren't modified
+	assert.Equal(t, map[task.ID]task.Outcome{"Render.prod": task.OutcomeExecuted}, notify.When.Results)
+}
+
+func TestExpandMatrices_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for name, tsk := range map[string]*task.Task{
+		"no values": task.New("Task", "exec", nil, task.WithMatrix(task.Matrix{"env": {}})),
+		"separator": task.New("Task", "exec", nil, task.WithMatrix(task.Matrix{"version": {"1.2"}})),
+		"unknown matrix value": task.New("Task", "exec", nil,
+			task.WithInputs("${matrix.region}"), task.WithMatrix(task.Matrix{"env": {"dev"}})),
+	} {
+		_, err := task.ExpandMatrices([]*task.Task{tsk})
+		require.ErrorIs(t, err, task.ErrInvalidMatrix, name)
+	}
+}
+
+func TestConditionEvaluate(t *testing.T) {
+	t.Parallel()
+
+	session := task.NewTestSession()
+	require.NoError(t, afero.WriteFile(session.SourceFS(), "charts/app/Chart.yaml", nil, 0o644))
+
+	facts := task.Facts{
+		Profiles: []string{"ci"},
+		Env:      map[string]string{"CI": "true", "BRANCH": "release/1.0"},
+	}
+	results := map[task.ID]task.UpstreamResult{
+		"Render": {Outcome: task.OutcomeExecuted, Outputs: []string{"manifests/app.yaml", "manifests/crds.yaml"}},
+		"Lint":   {Outcome: task.OutcomeSkipped},
+	}
+	upstream := func(id task.ID) (task.UpstreamResult, error) {
+		return results[id], nil
+	}
+
+	for name, test := range map[string]struct {
+		condition task.Condition
+		inputs    []string
+		expected  bool
+	}{
+		"empty":        {condition: task.Condition{}, expected: true},
+		"profile":      {condition: task.Condition{Profiles: []string{"dev", "ci"}}, expected: true},
+		"unselected":   {condition: task.Condition{Profiles: []string{"dev"}}},
+		"env":          {condition: task.Condition{Env: map[string]string{"BRANCH": "release/*"}}, expected: true},
+		"env mismatch": {condition: task.Condition{Env: map[string]string{"CI": "false"}}},
+		"env unset":    {condition: task.Condition{Env: map[string]string{"DEPLOY": "*"}}},
+		"exists":       {condition: task.Condition{Exists: []string{"charts/*/Chart.yaml"}}, expected: true},
+		"missing":      {condition: task.Condition{Exists: []string{"kustomize/*"}}},
+		"inputs exist": {
+			condition: task.Condition{InputsExist: true},
+			inputs:    []string{"charts/*"},
+			expected:  true,
+		},
+		"inputs missing": {condition: task.Condition{InputsExist: true}, inputs: []string{"charts/*", "values/*"}},
+		"result": {
+			condition: task.Condition{Results: map[task.ID]task.Outcome{"Render": "executed"}},
+			expected:  true,
+		},
+		"result mismatch": {condition: task.Condition{Results: map[task.ID]task.Outcome{"Lint": "executed"}}},
+		"outputs": {
+			condition: task.Condition{Outputs: map[task.ID][]string{"Render": {"manifests/crds.yaml", "*/app.*"}}},
+			expected:  true,
+		},
+		"outputs missing": {condition: task.Condition{Outputs: map[task.ID][]string{"Render": {"charts/*"}}}},
+		"skipped outputs": {condition: task.Condition{Outputs: map[task.ID][]string{"Lint": {"*"}}}},
+		"not": {
+			condition: task.Condition{Not: &task.Condition{Profiles: []string{"dev"}}},
+			expected:  true,
+		},
+		"not held": {condition: task.Condition{Not: &task.Condition{Profiles: []string{"ci"}}}},
+		"all clauses": {
+			condition: task.Condition{Profiles: []string{"ci"}, Env: map[string]string{"CI": "*"}},
+			expected:  true,
+		},
+		"one clause failed": {
+			condition: task.Condition{Profiles: []string{"ci"}, Env: map[string]string{"CI": "false"}},
+		},
+	} {
+		tsk := task.New("Task", "exec", nil, task.WithInputs(test.inputs...), task.WithCondition(test.condition))
+
+		holds, err := tsk.When.Evaluate(facts, session, tsk, upstream)
+		require.NoError(t, err, name)
+		assert.Equal(t, test.expected, holds, name)
+	}
+}
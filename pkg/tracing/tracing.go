@@ -0,0 +1,156 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+// Package tracing configures OpenTelemetry tracing for bonk and the plugins it launches.
+//
+// Traces may be exported to an OTLP gRPC endpoint, or written to a file as newline-delimited JSON.
+// Plugin processes receive their configuration through the environment (see [Environ]),
+// and trace context is propagated across gRPC so that plugin-side spans nest under the host's.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/multierr"
+
+	"github.com/spf13/afero"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+)
+
+const (
+	// TracerName is the instrumentation scope used for all bonk spans.
+	TracerName = "go.bonk.build"
+
+	// EnvEndpoint is the environment variable used to pass [Config.Endpoint] to plugins.
+	EnvEndpoint = "BONK_TRACE_ENDPOINT"
+	// EnvFile is the environment variable used to pass [Config.File] to plugins.
+	EnvFile = "BONK_TRACE_FILE"
+)
+
+var ErrConflictingConfig = errors.New("only one of trace endpoint and trace file may be set")
+
+// Config describes where traces should be exported to.
+type Config struct {
+	// ServiceName is reported as the service.name resource attribute.
+	ServiceName string
+	// Endpoint is the URL of an OTLP gRPC collector, such as http://localhost:4317.
+	Endpoint string
+	// File is the path of a file that spans are written to as newline-delimited JSON.
+	File string
+}
+
+// Enabled returns true if the config describes an exporter.
+func (cfg Config) Enabled() bool {
+	return cfg.Endpoint != "" || cfg.File != ""
+}
+
+var (
+	activeMu sync.RWMutex
+	active   Config
+)
+
+// ShutdownFunc flushes any buffered spans and releases the exporter.
+type ShutdownFunc = func(ctx context.Context) error
+
+// Setup installs a global tracer provider exporting to the destination described by cfg.
+// If cfg doesn't describe an exporter, the default no-op provider is left in place.
+// Trace context propagation is always enabled, so that plugins may participate in a host's trace.
+func Setup(ctx context.Context, cfg Config) (ShutdownFunc, error) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	if !cfg.Enabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	if cfg.Endpoint != "" && cfg.File != "" {
+		return nil, ErrConflictingConfig
+	}
+
+	var (
+		exporter sdktrace.SpanExporter
+		closer   func() error
+		err      error
+	)
+
+	if cfg.Endpoint != "" {
+		exporter, err = otlptracegrpc.New(ctx, otlptracegrpc.WithEndpointURL(cfg.Endpoint))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create otlp exporter: %w", err)
+		}
+	} else {
+		file, err := afero.NewOsFs().Create(cfg.File)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create trace file %s: %w", cfg.File, err)
+		}
+
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create file exporter: %w", err)
+		}
+
+		closer = file.Close
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(cfg.ServiceName))),
+	)
+
+	otel.SetTracerProvider(provider)
+
+	activeMu.Lock()
+	active = cfg
+	activeMu.Unlock()
+
+	return func(ctx context.Context) error {
+		activeMu.Lock()
+		active = Config{}
+		activeMu.Unlock()
+
+		err := provider.Shutdown(ctx)
+		if closer != nil {
+			multierr.AppendInto(&err, closer())
+		}
+
+		return err
+	}, nil
+}
+
+// Environ returns the environment variables needed for the named plugin to export to the active config.
+// When exporting to a file, each plugin writes to its own file next to the host's, suffixed with its name.
+func Environ(pluginName string) []string {
+	activeMu.RLock()
+	defer activeMu.RUnlock()
+
+	switch {
+	case active.Endpoint != "":
+		return []string{EnvEndpoint + "=" + active.Endpoint}
+
+	case active.File != "":
+		ext := filepath.Ext(active.File)
+		file := strings.TrimSuffix(active.File, ext) + "." + pluginName + ext
+
+		return []string{EnvFile + "=" + file}
+
+	default:
+		return nil
+	}
+}
+
+// Tracer returns the tracer used for bonk spans.
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
@@ -0,0 +1,49 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package tracing_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.bonk.build/pkg/tracing"
+)
+
+func TestSetup_File(t *testing.T) { //nolint:paralleltest // modifies the global tracer provider
+	traceFile := filepath.Join(t.TempDir(), "trace.json")
+
+	shutdown, err := tracing.Setup(t.Context(), tracing.Config{
+		ServiceName: "test",
+		File:        traceFile,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		[]string{tracing.EnvFile + "=" + filepath.Join(filepath.Dir(traceFile), "trace.plugin.json")},
+		tracing.Environ("plugin"),
+	)
+
+	_, span := tracing.Tracer().Start(t.Context(), "test-span")
+	span.End()
+
+	require.NoError(t, shutdown(t.Context()))
+
+	contents, err := afero.ReadFile(afero.NewOsFs(), traceFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(contents), "test-span")
+}
+
+func TestSetup_Conflicting(t *testing.T) {
+	t.Parallel()
+
+	_, err := tracing.Setup(t.Context(), tracing.Config{
+		Endpoint: "http://localhost:4317",
+		File:     "trace.json",
+	})
+	require.ErrorIs(t, err, tracing.ErrConflictingConfig)
+}